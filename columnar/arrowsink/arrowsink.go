@@ -0,0 +1,235 @@
+package arrowsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/senzing-garage/sz-sdk-go/columnar"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Sink struct is a columnar.Sink that builds an Arrow record batch from each batch
+with an array.RecordBuilder, and passes it to a RecordWriter per table.
+Create one with [NewSink] and call Release when done.
+*/
+type Sink struct {
+	memberships        *array.RecordBuilder
+	membershipWriter   RecordWriter
+	relationships      *array.RecordBuilder
+	relationshipWriter RecordWriter
+}
+
+/*
+Type ParquetSink struct is a columnar.Sink that writes each table to its own Parquet file with a
+pqarrow.FileWriter, one row group per batch. Create one with [NewParquetSink] and call Close when done.
+*/
+type ParquetSink struct {
+	*Sink
+
+	memberships   *pqarrow.FileWriter
+	relationships *pqarrow.FileWriter
+}
+
+/*
+Type RecordWriter interface receives Arrow record batches, e.g. a pqarrow.FileWriter or an ipc.Writer.
+A record batch is released after Write returns, so a RecordWriter must retain any it keeps.
+*/
+type RecordWriter interface {
+	Write(record arrow.RecordBatch) error
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// MembershipSchema is columnar.MembershipSchema as an Arrow schema.
+var MembershipSchema = arrowSchema(columnar.MembershipSchema)
+
+// RelationshipSchema is columnar.RelationshipSchema as an Arrow schema.
+var RelationshipSchema = arrowSchema(columnar.RelationshipSchema)
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewSink creates a Sink.
+
+Input
+  - allocator: Allocates the record batches, e.g. memory.DefaultAllocator. Nil means memory.DefaultAllocator.
+  - memberships: Receives record batches with MembershipSchema.
+  - relationships: Receives record batches with RelationshipSchema.
+*/
+func NewSink(allocator memory.Allocator, memberships RecordWriter, relationships RecordWriter) *Sink {
+	if allocator == nil {
+		allocator = memory.DefaultAllocator
+	}
+
+	return &Sink{
+		memberships:        array.NewRecordBuilder(allocator, MembershipSchema),
+		membershipWriter:   memberships,
+		relationships:      array.NewRecordBuilder(allocator, RelationshipSchema),
+		relationshipWriter: relationships,
+	}
+}
+
+/*
+Function NewParquetSink creates a ParquetSink.
+
+Input
+  - memberships: Receives the membership table as a Parquet file.
+  - relationships: Receives the relationship table as a Parquet file.
+    Each writer is closed by ParquetSink.Close if it is an io.Closer.
+
+Output
+  - A ParquetSink. Close it to write the Parquet footers.
+*/
+func NewParquetSink(memberships io.Writer, relationships io.Writer) (*ParquetSink, error) {
+	membershipWriter, err := pqarrow.NewFileWriter(
+		MembershipSchema, memberships, nil, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, fmt.Errorf("arrowsink cannot create membership Parquet writer: %w", err)
+	}
+
+	relationshipWriter, err := pqarrow.NewFileWriter(
+		RelationshipSchema, relationships, nil, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("arrowsink cannot create relationship Parquet writer: %w", err),
+			membershipWriter.Close(),
+		)
+	}
+
+	return &ParquetSink{
+		Sink:          NewSink(nil, membershipWriter, relationshipWriter),
+		memberships:   membershipWriter,
+		relationships: relationshipWriter,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Close writes the Parquet footers, closes the writers, and releases the record builders.
+*/
+func (sink *ParquetSink) Close() error {
+	defer sink.Release()
+
+	err := errors.Join(sink.memberships.Close(), sink.relationships.Close())
+	if err != nil {
+		return fmt.Errorf("arrowsink cannot close Parquet writers: %w", err)
+	}
+
+	return nil
+}
+
+/*
+Method Memberships builds a record batch from the batch and writes it.
+*/
+func (sink *Sink) Memberships(_ context.Context, batch *columnar.MembershipBatch) error {
+	err := writeRecord(sink.memberships, sink.membershipWriter, membershipColumns(batch))
+	if err != nil {
+		return fmt.Errorf("arrowsink cannot write membership record batch: %w", err)
+	}
+
+	return nil
+}
+
+/*
+Method Relationships builds a record batch from the batch and writes it.
+*/
+func (sink *Sink) Relationships(_ context.Context, batch *columnar.RelationshipBatch) error {
+	err := writeRecord(sink.relationships, sink.relationshipWriter, relationshipColumns(batch))
+	if err != nil {
+		return fmt.Errorf("arrowsink cannot write relationship record batch: %w", err)
+	}
+
+	return nil
+}
+
+/*
+Method Release releases the record builders.
+*/
+func (sink *Sink) Release() {
+	sink.memberships.Release()
+	sink.relationships.Release()
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func arrowSchema(columns []columnar.Column) *arrow.Schema {
+	fields := make([]arrow.Field, 0, len(columns))
+
+	for _, column := range columns {
+		var dataType arrow.DataType
+
+		switch column.Type {
+		case "bool":
+			dataType = arrow.FixedWidthTypes.Boolean
+		case "int64":
+			dataType = arrow.PrimitiveTypes.Int64
+		default:
+			dataType = arrow.BinaryTypes.String
+		}
+
+		fields = append(fields, arrow.Field{
+			Metadata: arrow.NewMetadata([]string{"description"}, []string{column.Description}),
+			Name:     column.Name,
+			Nullable: false,
+			Type:     dataType,
+		})
+	}
+
+	return arrow.NewSchema(fields, nil)
+}
+
+// Returns the column slices in the order of columnar.MembershipSchema.
+func membershipColumns(batch *columnar.MembershipBatch) []any {
+	return []any{batch.EntityID, batch.DataSource, batch.RecordID, batch.MatchKey, batch.ErruleCode}
+}
+
+// Returns the column slices in the order of columnar.RelationshipSchema.
+func relationshipColumns(batch *columnar.RelationshipBatch) []any {
+	return []any{
+		batch.EntityID,
+		batch.RelatedEntityID,
+		batch.MatchLevelCode,
+		batch.MatchKey,
+		batch.ErruleCode,
+		batch.IsDisclosed,
+		batch.IsAmbiguous,
+	}
+}
+
+// The builders were created from the schema of the columns, so their types match the slices.
+func writeRecord(builder *array.RecordBuilder, writer RecordWriter, columns []any) error {
+	for index, values := range columns {
+		switch values := values.(type) {
+		case []bool:
+			builder.Field(index).(*array.BooleanBuilder).AppendValues(values, nil) //nolint:forcetypeassert
+		case []int64:
+			builder.Field(index).(*array.Int64Builder).AppendValues(values, nil) //nolint:forcetypeassert
+		case []string:
+			builder.Field(index).(*array.StringBuilder).AppendValues(values, nil) //nolint:forcetypeassert
+		}
+	}
+
+	record := builder.NewRecordBatch()
+	defer record.Release()
+
+	return writer.Write(record) //nolint:wrapcheck
+}
@@ -0,0 +1,125 @@
+package arrowsink_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/senzing-garage/sz-sdk-go/columnar"
+	"github.com/senzing-garage/sz-sdk-go/columnar/arrowsink"
+	"github.com/senzing-garage/sz-sdk-go/internal/exporttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const export = `{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "RECORDS": [` +
+	`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "MATCH_KEY": "", "ERRULE_CODE": ""}, ` +
+	`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002", "MATCH_KEY": "+NAME+DOB", "ERRULE_CODE": "CNAME_CFF"}]}, ` +
+	`"RELATED_ENTITIES": [{"ENTITY_ID": 2, "MATCH_LEVEL_CODE": "POSSIBLY_RELATED", "MATCH_KEY": "+ADDRESS", ` +
+	`"ERRULE_CODE": "SF1", "IS_DISCLOSED": 0, "IS_AMBIGUOUS": 1}]}
+{"RESOLVED_ENTITY": {"ENTITY_ID": 2, "RECORDS": [` +
+	`{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "1006", "MATCH_KEY": "", "ERRULE_CODE": ""}]}, ` +
+	`"RELATED_ENTITIES": [{"ENTITY_ID": 1, "MATCH_LEVEL_CODE": "POSSIBLY_RELATED", "MATCH_KEY": "+ADDRESS", ` +
+	`"ERRULE_CODE": "SF1", "IS_DISCLOSED": 0, "IS_AMBIGUOUS": 1}]}
+{"RESOLVED_ENTITY": {"ENTITY_ID": 3, "RECORDS": [` +
+	`{"DATA_SOURCE": "REFERENCE", "RECORD_ID": "2001", "MATCH_KEY": "", "ERRULE_CODE": ""}]}}`
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Reads a Parquet file back as column name to values.
+func readParquet(test *testing.T, content []byte) (*arrow.Schema, map[string][]any) {
+	test.Helper()
+
+	reader, err := file.NewParquetReader(bytes.NewReader(content))
+	require.NoError(test, err)
+
+	properties := pqarrow.ArrowReadProperties{} //exhaustruct:ignore
+
+	fileReader, err := pqarrow.NewFileReader(reader, properties, memory.DefaultAllocator)
+	require.NoError(test, err)
+
+	table, err := fileReader.ReadTable(test.Context())
+	require.NoError(test, err)
+
+	defer table.Release()
+
+	result := map[string][]any{}
+
+	for index, field := range table.Schema().Fields() {
+		values := []any{}
+
+		for _, chunk := range table.Column(index).Data().Chunks() {
+			for row := range chunk.Len() {
+				values = append(values, chunk.GetOneForMarshal(row))
+			}
+		}
+
+		result[field.Name] = values
+	}
+
+	return table.Schema(), result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParquetSink(test *testing.T) {
+	test.Parallel()
+
+	var memberships, relationships bytes.Buffer
+
+	sink, err := arrowsink.NewParquetSink(&memberships, &relationships)
+	require.NoError(test, err)
+
+	engine := &exporttest.Engine{ChunkSize: 37, Export: export} //exhaustruct:ignore
+
+	stats, err := columnar.Export(test.Context(), engine, sink, columnar.Config{BatchSize: 3}) //exhaustruct:ignore
+	require.NoError(test, err)
+	require.NoError(test, sink.Close())
+	assert.Equal(test, columnar.Stats{Entities: 3, Memberships: 4, Relationships: 1}, stats)
+
+	schema, columns := readParquet(test, memberships.Bytes())
+	require.Len(test, schema.Fields(), len(columnar.MembershipSchema))
+	assert.Equal(test, []any{int64(1), int64(1), int64(2), int64(3)}, columns["entity_id"])
+	assert.Equal(test, []any{"CUSTOMERS", "CUSTOMERS", "WATCHLIST", "REFERENCE"}, columns["data_source"])
+	assert.Equal(test, []any{"1001", "1002", "1006", "2001"}, columns["record_id"])
+	assert.Equal(test, []any{"", "+NAME+DOB", "", ""}, columns["match_key"])
+	assert.Equal(test, []any{"", "CNAME_CFF", "", ""}, columns["errule_code"])
+
+	schema, columns = readParquet(test, relationships.Bytes())
+	require.Len(test, schema.Fields(), len(columnar.RelationshipSchema))
+	assert.Equal(test, map[string][]any{
+		"entity_id":         {int64(1)},
+		"errule_code":       {"SF1"},
+		"is_ambiguous":      {true},
+		"is_disclosed":      {false},
+		"match_key":         {"+ADDRESS"},
+		"match_level_code":  {"POSSIBLY_RELATED"},
+		"related_entity_id": {int64(2)},
+	}, columns)
+}
+
+func TestSchema(test *testing.T) {
+	test.Parallel()
+
+	for schema, columns := range map[*arrow.Schema][]columnar.Column{
+		arrowsink.MembershipSchema:   columnar.MembershipSchema,
+		arrowsink.RelationshipSchema: columnar.RelationshipSchema,
+	} {
+		require.Len(test, schema.Fields(), len(columns))
+
+		for index, field := range schema.Fields() {
+			assert.Equal(test, columns[index].Name, field.Name)
+			assert.Equal(test, columns[index].Type, map[arrow.Type]string{
+				arrow.BOOL: "bool", arrow.INT64: "int64", arrow.STRING: "string",
+			}[field.Type.ID()], field.Name)
+			assert.False(test, field.Nullable, field.Name)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+/*
+Package arrowsink writes the tables of the columnar package as Arrow record batches or Parquet files.
+
+It is a separate module, so that the Arrow dependencies are only required by programs that import it.
+
+[ParquetSink] writes each table to a Parquet file, with the Arrow schemas
+[MembershipSchema] and [RelationshipSchema]:
+
+	memberships, err := os.Create("memberships.parquet")
+	...
+	relationships, err := os.Create("relationships.parquet")
+	...
+	sink, err := arrowsink.NewParquetSink(memberships, relationships)
+	...
+	stats, err := columnar.Export(ctx, szEngine, sink, columnar.Config{BatchSize: 50000})
+	...
+	err = sink.Close() // Writes the Parquet footers and closes the files.
+
+[Sink] builds an Arrow record batch from each batch and passes it to any [RecordWriter],
+e.g. an Arrow IPC writer.
+*/
+package arrowsink
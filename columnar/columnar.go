@@ -0,0 +1,363 @@
+package columnar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Column struct describes one column of a table.
+*/
+type Column struct {
+	Description string
+	Name        string
+	Type        string // "int64", "string", or "bool". No column is nullable; missing strings are empty.
+}
+
+/*
+Type Config struct configures [Export].
+*/
+type Config struct {
+	BatchSize      int   // Rows per batch of each table. Default: DefaultBatchSize.
+	BothDirections bool  // Write each relationship from both entities instead of once.
+	Flags          int64 // Export flags. Default: Flags.
+}
+
+/*
+Type MembershipBatch struct is rows of the membership table, one slice per column.
+The slices are reused after the sink returns, so a sink must copy what it keeps.
+*/
+type MembershipBatch struct {
+	DataSource []string
+	EntityID   []int64
+	ErruleCode []string
+	MatchKey   []string
+	RecordID   []string
+}
+
+/*
+Type RelationshipBatch struct is rows of the relationship table, one slice per column.
+The slices are reused after the sink returns, so a sink must copy what it keeps.
+*/
+type RelationshipBatch struct {
+	EntityID        []int64
+	ErruleCode      []string
+	IsAmbiguous     []bool
+	IsDisclosed     []bool
+	MatchKey        []string
+	MatchLevelCode  []string
+	RelatedEntityID []int64
+}
+
+/*
+Type Sink interface receives full batches, and the last partial batch of each table.
+The arrowsink module implements it for Arrow record batches and Parquet files.
+*/
+type Sink interface {
+	Memberships(ctx context.Context, batch *MembershipBatch) error
+	Relationships(ctx context.Context, batch *RelationshipBatch) error
+}
+
+/*
+Type Stats struct counts what an export wrote.
+*/
+type Stats struct {
+	Entities      int64
+	Memberships   int64
+	Relationships int64
+}
+
+type exporter struct {
+	config        Config
+	memberships   *MembershipBatch
+	relationships *RelationshipBatch
+	sink          Sink
+	stats         Stats
+}
+
+type exportEntity struct {
+	RelatedEntities []struct {
+		EntityID       int64  `json:"ENTITY_ID"`
+		ErruleCode     string `json:"ERRULE_CODE"`
+		IsAmbiguous    int64  `json:"IS_AMBIGUOUS"`
+		IsDisclosed    int64  `json:"IS_DISCLOSED"`
+		MatchKey       string `json:"MATCH_KEY"`
+		MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	} `json:"RELATED_ENTITIES"`
+	ResolvedEntity struct {
+		EntityID int64 `json:"ENTITY_ID"`
+		Records  []struct {
+			DataSource string `json:"DATA_SOURCE"`
+			ErruleCode string `json:"ERRULE_CODE"`
+			MatchKey   string `json:"MATCH_KEY"`
+			RecordID   string `json:"RECORD_ID"`
+		} `json:"RECORDS"`
+	} `json:"RESOLVED_ENTITY"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultBatchSize is the rows per batch when Config.BatchSize is not set.
+const DefaultBatchSize = 10000
+
+// Flags is the default export flags: all entities with their records, and all relationships
+// with their match information.
+const Flags = senzing.SzExportIncludeAllEntities |
+	senzing.SzEntityIncludeRecordData |
+	senzing.SzEntityIncludeRecordMatchingInfo |
+	senzing.SzEntityIncludeAllRelations |
+	senzing.SzEntityIncludeRelatedMatchingInfo
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// MembershipSchema is the columns of the membership table: one row per record.
+var MembershipSchema = []Column{
+	{Description: "The entity the record is in.", Name: "entity_id", Type: "int64"},
+	{Description: "The data source code of the record.", Name: "data_source", Type: "string"},
+	{Description: "The record ID.", Name: "record_id", Type: "string"},
+	{Description: "The match key that added the record to the entity.", Name: "match_key", Type: "string"},
+	{Description: "The rule that added the record to the entity.", Name: "errule_code", Type: "string"},
+}
+
+// RelationshipSchema is the columns of the relationship table: one row per related pair of entities.
+var RelationshipSchema = []Column{
+	{Description: "The entity. Smaller than related_entity_id unless BothDirections.", Name: "entity_id", Type: "int64"},
+	{Description: "The related entity.", Name: "related_entity_id", Type: "int64"},
+	{
+		Description: "e.g. POSSIBLY_SAME, POSSIBLY_RELATED, NAME_ONLY, or DISCLOSED.",
+		Name:        "match_level_code",
+		Type:        "string",
+	},
+	{Description: "The match key of the relationship.", Name: "match_key", Type: "string"},
+	{Description: "The rule of the relationship.", Name: "errule_code", Type: "string"},
+	{Description: "True for a disclosed relationship.", Name: "is_disclosed", Type: "bool"},
+	{Description: "True for an ambiguous relationship.", Name: "is_ambiguous", Type: "bool"},
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+func newExporter(sink Sink, config Config) *exporter {
+	return &exporter{
+		config: config,
+		memberships: &MembershipBatch{
+			DataSource: make([]string, 0, config.BatchSize),
+			EntityID:   make([]int64, 0, config.BatchSize),
+			ErruleCode: make([]string, 0, config.BatchSize),
+			MatchKey:   make([]string, 0, config.BatchSize),
+			RecordID:   make([]string, 0, config.BatchSize),
+		},
+		relationships: &RelationshipBatch{
+			EntityID:        make([]int64, 0, config.BatchSize),
+			ErruleCode:      make([]string, 0, config.BatchSize),
+			IsAmbiguous:     make([]bool, 0, config.BatchSize),
+			IsDisclosed:     make([]bool, 0, config.BatchSize),
+			MatchKey:        make([]string, 0, config.BatchSize),
+			MatchLevelCode:  make([]string, 0, config.BatchSize),
+			RelatedEntityID: make([]int64, 0, config.BatchSize),
+		},
+		sink:  sink,
+		stats: Stats{Entities: 0, Memberships: 0, Relationships: 0},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Export streams SzEngine.ExportJSONEntityReport into batches of the membership
+and relationship tables. Memory holds at most one batch of each table and one entity.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - sink: Receives the batches.
+  - config: The batch size, relationship direction, and export flags.
+
+Output
+  - The counts of entities and rows written.
+*/
+func Export(ctx context.Context, szEngine senzing.SzEngine, sink Sink, config Config) (stats Stats, err error) {
+	if config.BatchSize < 1 {
+		config.BatchSize = DefaultBatchSize
+	}
+
+	if config.Flags == 0 {
+		config.Flags = Flags
+	}
+
+	exportHandle, err := senzing.OpenJSONExport(ctx, szEngine, config.Flags, nil)
+	if err != nil {
+		return stats, err //nolint:wrapcheck
+	}
+
+	defer func() {
+		closeErr := exportHandle.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	exporter := newExporter(sink, config)
+
+	for line, err := range exportHandle.Lines() {
+		if err != nil {
+			return exporter.stats, err //nolint:wrapcheck
+		}
+
+		err = exporter.add(ctx, line)
+		if err != nil {
+			return exporter.stats, err
+		}
+	}
+
+	err = exporter.flush(ctx)
+
+	return exporter.stats, err
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Len returns the number of rows.
+*/
+func (batch *MembershipBatch) Len() int {
+	return len(batch.EntityID)
+}
+
+/*
+Method Len returns the number of rows.
+*/
+func (batch *RelationshipBatch) Len() int {
+	return len(batch.EntityID)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Adds the rows of one exported entity, writing batches as they fill.
+func (exporter *exporter) add(ctx context.Context, line []byte) error {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil
+	}
+
+	entity := exportEntity{} //exhaustruct:ignore
+
+	err := json.Unmarshal(line, &entity)
+	if err != nil {
+		return fmt.Errorf("columnar cannot unmarshal entity %d: %w", exporter.stats.Entities+1, err)
+	}
+
+	exporter.stats.Entities++
+	entityID := entity.ResolvedEntity.EntityID
+
+	for _, record := range entity.ResolvedEntity.Records {
+		batch := exporter.memberships
+		batch.DataSource = append(batch.DataSource, record.DataSource)
+		batch.EntityID = append(batch.EntityID, entityID)
+		batch.ErruleCode = append(batch.ErruleCode, record.ErruleCode)
+		batch.MatchKey = append(batch.MatchKey, record.MatchKey)
+		batch.RecordID = append(batch.RecordID, record.RecordID)
+		exporter.stats.Memberships++
+
+		if batch.Len() >= exporter.config.BatchSize {
+			err = exporter.flushMemberships(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, related := range entity.RelatedEntities {
+		if !exporter.config.BothDirections && related.EntityID < entityID {
+			continue
+		}
+
+		batch := exporter.relationships
+		batch.EntityID = append(batch.EntityID, entityID)
+		batch.ErruleCode = append(batch.ErruleCode, related.ErruleCode)
+		batch.IsAmbiguous = append(batch.IsAmbiguous, related.IsAmbiguous != 0)
+		batch.IsDisclosed = append(batch.IsDisclosed, related.IsDisclosed != 0)
+		batch.MatchKey = append(batch.MatchKey, related.MatchKey)
+		batch.MatchLevelCode = append(batch.MatchLevelCode, related.MatchLevelCode)
+		batch.RelatedEntityID = append(batch.RelatedEntityID, related.EntityID)
+		exporter.stats.Relationships++
+
+		if batch.Len() >= exporter.config.BatchSize {
+			err = exporter.flushRelationships(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (exporter *exporter) flush(ctx context.Context) error {
+	err := exporter.flushMemberships(ctx)
+	if err != nil {
+		return err
+	}
+
+	return exporter.flushRelationships(ctx)
+}
+
+func (exporter *exporter) flushMemberships(ctx context.Context) error {
+	batch := exporter.memberships
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	err := exporter.sink.Memberships(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("columnar cannot write memberships: %w", err)
+	}
+
+	batch.DataSource = batch.DataSource[:0]
+	batch.EntityID = batch.EntityID[:0]
+	batch.ErruleCode = batch.ErruleCode[:0]
+	batch.MatchKey = batch.MatchKey[:0]
+	batch.RecordID = batch.RecordID[:0]
+
+	return nil
+}
+
+func (exporter *exporter) flushRelationships(ctx context.Context) error {
+	batch := exporter.relationships
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	err := exporter.sink.Relationships(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("columnar cannot write relationships: %w", err)
+	}
+
+	batch.EntityID = batch.EntityID[:0]
+	batch.ErruleCode = batch.ErruleCode[:0]
+	batch.IsAmbiguous = batch.IsAmbiguous[:0]
+	batch.IsDisclosed = batch.IsDisclosed[:0]
+	batch.MatchKey = batch.MatchKey[:0]
+	batch.MatchLevelCode = batch.MatchLevelCode[:0]
+	batch.RelatedEntityID = batch.RelatedEntityID[:0]
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package columnar_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/columnar"
+	"github.com/senzing-garage/sz-sdk-go/internal/exporttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const export = `{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "RECORDS": [` +
+	`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "MATCH_KEY": "", "ERRULE_CODE": ""}, ` +
+	`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002", "MATCH_KEY": "+NAME+DOB", "ERRULE_CODE": "CNAME_CFF"}]}, ` +
+	`"RELATED_ENTITIES": [{"ENTITY_ID": 2, "MATCH_LEVEL_CODE": "POSSIBLY_RELATED", "MATCH_KEY": "+ADDRESS", ` +
+	`"ERRULE_CODE": "SF1", "IS_DISCLOSED": 0, "IS_AMBIGUOUS": 1}]}
+{"RESOLVED_ENTITY": {"ENTITY_ID": 2, "RECORDS": [` +
+	`{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "1006", "MATCH_KEY": "", "ERRULE_CODE": ""}]}, ` +
+	`"RELATED_ENTITIES": [{"ENTITY_ID": 1, "MATCH_LEVEL_CODE": "POSSIBLY_RELATED", "MATCH_KEY": "+ADDRESS", ` +
+	`"ERRULE_CODE": "SF1", "IS_DISCLOSED": 0, "IS_AMBIGUOUS": 1}]}
+{"RESOLVED_ENTITY": {"ENTITY_ID": 3, "RECORDS": [` +
+	`{"DATA_SOURCE": "REFERENCE", "RECORD_ID": "2001", "MATCH_KEY": "", "ERRULE_CODE": ""}]}}`
+
+var errSink = errors.New("sink failed")
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockSink struct {
+	err           error
+	memberships   columnar.MembershipBatch
+	relationships columnar.RelationshipBatch
+	sizes         []int
+}
+
+func (sink *mockSink) Memberships(_ context.Context, batch *columnar.MembershipBatch) error {
+	sink.sizes = append(sink.sizes, batch.Len())
+	sink.memberships.DataSource = append(sink.memberships.DataSource, batch.DataSource...)
+	sink.memberships.EntityID = append(sink.memberships.EntityID, batch.EntityID...)
+	sink.memberships.ErruleCode = append(sink.memberships.ErruleCode, batch.ErruleCode...)
+	sink.memberships.MatchKey = append(sink.memberships.MatchKey, batch.MatchKey...)
+	sink.memberships.RecordID = append(sink.memberships.RecordID, batch.RecordID...)
+
+	return sink.err
+}
+
+func (sink *mockSink) Relationships(_ context.Context, batch *columnar.RelationshipBatch) error {
+	sink.relationships.EntityID = append(sink.relationships.EntityID, batch.EntityID...)
+	sink.relationships.ErruleCode = append(sink.relationships.ErruleCode, batch.ErruleCode...)
+	sink.relationships.IsAmbiguous = append(sink.relationships.IsAmbiguous, batch.IsAmbiguous...)
+	sink.relationships.IsDisclosed = append(sink.relationships.IsDisclosed, batch.IsDisclosed...)
+	sink.relationships.MatchKey = append(sink.relationships.MatchKey, batch.MatchKey...)
+	sink.relationships.MatchLevelCode = append(sink.relationships.MatchLevelCode, batch.MatchLevelCode...)
+	sink.relationships.RelatedEntityID = append(sink.relationships.RelatedEntityID, batch.RelatedEntityID...)
+
+	return sink.err
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestExport(test *testing.T) {
+	test.Parallel()
+
+	engine := &exporttest.Engine{ChunkSize: 37, Export: export} //exhaustruct:ignore
+	sink := &mockSink{}                                         //exhaustruct:ignore
+
+	stats, err := columnar.Export(test.Context(), engine, sink, columnar.Config{BatchSize: 3}) //exhaustruct:ignore
+	require.NoError(test, err)
+	assert.True(test, engine.IsClosed)
+	assert.Equal(test, columnar.Flags, engine.Flags)
+	assert.Equal(test, columnar.Stats{Entities: 3, Memberships: 4, Relationships: 1}, stats)
+	assert.Equal(test, []int{3, 1}, sink.sizes)
+
+	assert.Equal(test, columnar.MembershipBatch{
+		DataSource: []string{"CUSTOMERS", "CUSTOMERS", "WATCHLIST", "REFERENCE"},
+		EntityID:   []int64{1, 1, 2, 3},
+		ErruleCode: []string{"", "CNAME_CFF", "", ""},
+		MatchKey:   []string{"", "+NAME+DOB", "", ""},
+		RecordID:   []string{"1001", "1002", "1006", "2001"},
+	}, sink.memberships)
+	assert.Equal(test, columnar.RelationshipBatch{
+		EntityID:        []int64{1},
+		ErruleCode:      []string{"SF1"},
+		IsAmbiguous:     []bool{true},
+		IsDisclosed:     []bool{false},
+		MatchKey:        []string{"+ADDRESS"},
+		MatchLevelCode:  []string{"POSSIBLY_RELATED"},
+		RelatedEntityID: []int64{2},
+	}, sink.relationships)
+}
+
+func TestExport_BothDirections(test *testing.T) {
+	test.Parallel()
+
+	engine := &exporttest.Engine{ChunkSize: 1000, Export: export} //exhaustruct:ignore
+	sink := &mockSink{}                                           //exhaustruct:ignore
+
+	stats, err := columnar.Export(test.Context(), engine, sink, columnar.Config{BothDirections: true}) //exhaustruct:ignore
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), stats.Relationships)
+	assert.Equal(test, []int64{1, 2}, sink.relationships.EntityID)
+	assert.Equal(test, []int64{2, 1}, sink.relationships.RelatedEntityID)
+	assert.Equal(test, []int{4}, sink.sizes)
+}
+
+func TestExport_SinkError(test *testing.T) {
+	test.Parallel()
+
+	engine := &exporttest.Engine{ChunkSize: 1000, Export: export} //exhaustruct:ignore
+	sink := &mockSink{err: errSink}                               //exhaustruct:ignore
+
+	_, err := columnar.Export(test.Context(), engine, sink, columnar.Config{BatchSize: 1}) //exhaustruct:ignore
+	require.ErrorIs(test, err, errSink)
+	assert.True(test, engine.IsClosed)
+	assert.Equal(test, []int{1}, sink.sizes)
+}
+
+func TestSchema(test *testing.T) {
+	test.Parallel()
+
+	for _, schema := range [][]columnar.Column{columnar.MembershipSchema, columnar.RelationshipSchema} {
+		for _, column := range schema {
+			assert.Contains(test, []string{"bool", "int64", "string"}, column.Type, column.Name)
+			assert.NotEmpty(test, column.Description, column.Name)
+		}
+	}
+
+	names := func(schema []columnar.Column) []string {
+		result := []string{}
+		for _, column := range schema {
+			result = append(result, column.Name)
+		}
+
+		return result
+	}
+
+	assert.True(test, slices.Contains(names(columnar.MembershipSchema), "record_id"))
+	assert.True(test, slices.Contains(names(columnar.RelationshipSchema), "related_entity_id"))
+}
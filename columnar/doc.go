@@ -0,0 +1,19 @@
+/*
+Package columnar streams SzEngine.ExportJSONEntityReport as two tables in column batches.
+
+The membership table has one row per record and the entity it is in; its columns are
+[MembershipSchema]. The relationship table has one row per pair of related entities;
+its columns are [RelationshipSchema]. [Export] fills a [MembershipBatch] and a
+[RelationshipBatch] with one slice per column, and passes each to a [Sink] when it
+reaches Config.BatchSize rows. Memory is bounded by the batch size and the largest
+entity, not by the size of the repository.
+
+The Arrow and Parquet sinks are in the separate module
+github.com/senzing-garage/sz-sdk-go/columnar/arrowsink, so that the Arrow dependencies
+are only required by programs that use them.
+
+Batches are reused after the Sink returns, so a Sink must copy any slices it keeps.
+Each relationship is written once, from the entity with the smaller ID,
+unless Config.BothDirections is set.
+*/
+package columnar
@@ -0,0 +1,131 @@
+package configdoc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Canonicalize returns the canonical form of a configuration definition.
+Two definitions that differ only in key order, whitespace, or the order of rows within tables
+have the same canonical form.
+
+Input
+  - configDefinition: The JSON returned by SzConfig.Export.
+
+Output
+  - The canonical JSON.
+*/
+func Canonicalize(configDefinition string) (string, error) {
+	document, err := Parse(configDefinition)
+	if err != nil {
+		return "", err
+	}
+
+	return document.Canonical()
+}
+
+/*
+Function Hash returns the content hash of a configuration definition.
+Definitions with the same canonical form have the same hash.
+
+Input
+  - configDefinition: The JSON returned by SzConfig.Export.
+
+Output
+  - The hexadecimal SHA-256 hash of the canonical form.
+*/
+func Hash(configDefinition string) (string, error) {
+	document, err := Parse(configDefinition)
+	if err != nil {
+		return "", err
+	}
+
+	return document.Hash()
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Canonical returns the canonical JSON of the document.
+Object keys are sorted, whitespace is removed, and the rows of each table are sorted,
+because row order within a table carries no meaning (order is expressed by columns such as EXEC_ORDER).
+Arrays nested inside rows or non-table members keep their order.
+*/
+func (document *Document) Canonical() (string, error) {
+	members := make(map[string]any, len(document.Objects)+len(document.Tables))
+
+	for name, rawMember := range document.Objects {
+		value, err := decodeValue(rawMember)
+		if err != nil {
+			return "", fmt.Errorf("configdoc cannot canonicalize %s: %w", name, err)
+		}
+
+		members[name] = value
+	}
+
+	for name, rows := range document.Tables {
+		canonicalRows := make([]json.RawMessage, 0, len(rows))
+
+		for _, row := range rows {
+			canonicalRow, err := json.Marshal(row)
+			if err != nil {
+				return "", fmt.Errorf("configdoc cannot canonicalize %s: %w", name, err)
+			}
+
+			canonicalRows = append(canonicalRows, canonicalRow)
+		}
+
+		slices.SortFunc(canonicalRows, func(a, b json.RawMessage) int { return bytes.Compare(a, b) })
+		members[name] = canonicalRows
+	}
+
+	result, err := json.Marshal(map[string]any{rootKey: members})
+	if err != nil {
+		return "", fmt.Errorf("configdoc cannot canonicalize config definition: %w", err)
+	}
+
+	return string(result), nil
+}
+
+/*
+Method Hash returns the hexadecimal SHA-256 hash of the document's canonical JSON.
+*/
+func (document *Document) Hash() (string, error) {
+	canonical, err := document.Canonical()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(canonical))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func decodeValue(rawValue json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawValue))
+	decoder.UseNumber()
+
+	var result any
+
+	err := decoder.Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("configdoc cannot decode value: %w", err)
+	}
+
+	return result, nil
+}
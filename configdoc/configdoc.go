@@ -0,0 +1,359 @@
+package configdoc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Type Row map is one row of a configuration table. Numbers are stored as json.Number.
+type Row map[string]any
+
+/*
+Type Document struct is a parsed configuration definition.
+*/
+type Document struct {
+	// Objects holds the members of "G2_CONFIG" that are not tables.
+	Objects map[string]json.RawMessage
+
+	// Tables holds the members of "G2_CONFIG" that are tables, keyed by table name.
+	Tables map[string][]Row
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Table names.
+const (
+	TableAttribute        = "CFG_ATTR"
+	TableComparisonReturn = "CFG_CFRTN"
+	TableDataSource       = "CFG_DSRC"
+	TableFeatureBOM       = "CFG_FBOM"
+	TableFeatureClass     = "CFG_FCLASS"
+	TableFeatureElement   = "CFG_FELEM"
+	TableFeatureType      = "CFG_FTYPE"
+	TableGenericThreshold = "CFG_GENERIC_THRESHOLD"
+	TableGenericPlan      = "CFG_GPLAN"
+)
+
+const rootKey = "G2_CONFIG"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+/*
+Feature element codes that attributes may map to without a CFG_FELEM row.
+Senzing supplies them for every feature type (e.g. ADDR_TYPE maps to USAGE_TYPE).
+*/
+var ImplicitFeatureElements = []string{"USAGE_TYPE", "USED_FROM_DT", "USED_THRU_DT"}
+
+/*
+Columns that identify a row within a table.
+Tables that are not listed are identified by all of their columns.
+*/
+var TableKeys = map[string][]string{
+	"CFG_ATTR":              {"ATTR_CODE"},
+	"CFG_CFRTN":             {"CFRTN_ID"},
+	"CFG_CFUNC":             {"CFUNC_CODE"},
+	"CFG_DFUNC":             {"DFUNC_CODE"},
+	"CFG_DSRC":              {"DSRC_CODE"},
+	"CFG_EFUNC":             {"EFUNC_CODE"},
+	"CFG_ERFRAG":            {"ERFRAG_CODE"},
+	"CFG_ERRULE":            {"ERRULE_CODE"},
+	"CFG_FCLASS":            {"FCLASS_CODE"},
+	"CFG_FELEM":             {"FELEM_CODE"},
+	"CFG_FTYPE":             {"FTYPE_CODE"},
+	"CFG_GENERIC_THRESHOLD": {"GPLAN_ID", "BEHAVIOR", "FTYPE_ID"},
+	"CFG_GPLAN":             {"GPLAN_CODE"},
+	"CFG_RCLASS":            {"RCLASS_CODE"},
+	"CFG_RTYPE":             {"RTYPE_CODE"},
+	"CFG_SFUNC":             {"SFUNC_CODE"},
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function FromResponse returns the tables of a parsed configuration definition.
+
+Input
+  - configExport: The configuration definition, e.g. from response.SzConfigExport.
+
+Output
+  - The document.
+*/
+func FromResponse(configExport *typedef.SzConfigExportResponse) (*Document, error) {
+	configDefinition, err := json.Marshal(configExport)
+	if err != nil {
+		return nil, fmt.Errorf("configdoc cannot marshal config export response: %w", err)
+	}
+
+	return Parse(string(configDefinition))
+}
+
+/*
+Function Parse parses a configuration definition.
+
+Input
+  - configDefinition: The JSON returned by SzConfig.Export.
+
+Output
+  - The parsed document.
+*/
+func Parse(configDefinition string) (*Document, error) {
+	root := map[string]json.RawMessage{}
+
+	err := json.Unmarshal([]byte(configDefinition), &root)
+	if err != nil {
+		return nil, fmt.Errorf("configdoc cannot unmarshal config definition: %w", err)
+	}
+
+	members := map[string]json.RawMessage{}
+
+	if rawConfig, isPresent := root[rootKey]; isPresent {
+		err = json.Unmarshal(rawConfig, &members)
+		if err != nil {
+			return nil, fmt.Errorf("configdoc cannot unmarshal %s: %w", rootKey, err)
+		}
+	}
+
+	result := &Document{
+		Objects: map[string]json.RawMessage{},
+		Tables:  map[string][]Row{},
+	}
+
+	for name, rawMember := range members {
+		if !bytes.HasPrefix(bytes.TrimSpace(rawMember), []byte("[")) {
+			result.Objects[name] = rawMember
+
+			continue
+		}
+
+		rows, err := decodeRows(rawMember)
+		if err != nil {
+			return nil, fmt.Errorf("configdoc cannot unmarshal %s: %w", name, err)
+		}
+
+		result.Tables[name] = rows
+	}
+
+	return result, nil
+}
+
+/*
+Function RowKey returns the identity of a row within a table.
+For example, a CFG_GENERIC_THRESHOLD row has a key like "GPLAN_ID=1,BEHAVIOR=NAME,FTYPE_ID=0".
+*/
+func RowKey(table string, row Row) string {
+	columns, isKeyed := TableKeys[table]
+	if !isKeyed {
+		columns = make([]string, 0, len(row))
+		for column := range row {
+			columns = append(columns, column)
+		}
+
+		slices.Sort(columns)
+	}
+
+	parts := make([]string, 0, len(columns))
+	for _, column := range columns {
+		parts = append(parts, column+"="+formatValue(row[column]))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Clone returns a deep copy of the document.
+*/
+func (document *Document) Clone() *Document {
+	result := &Document{
+		Objects: make(map[string]json.RawMessage, len(document.Objects)),
+		Tables:  make(map[string][]Row, len(document.Tables)),
+	}
+
+	for name, rawMember := range document.Objects {
+		result.Objects[name] = slices.Clone(rawMember)
+	}
+
+	for name, rows := range document.Tables {
+		clonedRows := make([]Row, len(rows))
+		for index, row := range rows {
+			clonedRows[index] = row.Clone()
+		}
+
+		result.Tables[name] = clonedRows
+	}
+
+	return result
+}
+
+/*
+Method Find returns the index of the first row in a table whose column has the requested value.
+
+Input
+  - table: The table name, e.g. TableFeatureType.
+  - column: The column name, e.g. "FTYPE_CODE".
+  - value: The value to find. Numbers may be given as any Go integer type.
+
+Output
+  - The row index, or -1 if not found.
+*/
+func (document *Document) Find(table string, column string, value any) int {
+	wanted := fmt.Sprint(value)
+
+	for index, row := range document.Tables[table] {
+		if cell, isPresent := row[column]; isPresent && fmt.Sprint(cell) == wanted {
+			return index
+		}
+	}
+
+	return -1
+}
+
+/*
+Method JSON serializes the document as a configuration definition.
+Keys are written in sorted order.
+*/
+func (document *Document) JSON() (string, error) {
+	members := make(map[string]any, len(document.Objects)+len(document.Tables))
+
+	for name, rawMember := range document.Objects {
+		members[name] = rawMember
+	}
+
+	for name, rows := range document.Tables {
+		if rows == nil {
+			rows = []Row{}
+		}
+
+		members[name] = rows
+	}
+
+	result, err := json.Marshal(map[string]any{rootKey: members})
+	if err != nil {
+		return "", fmt.Errorf("configdoc cannot marshal config definition: %w", err)
+	}
+
+	return string(result), nil
+}
+
+/*
+Method Response parses the serialized document with response.SzConfigExport.
+*/
+func (document *Document) Response() (*typedef.SzConfigExportResponse, error) {
+	configDefinition, err := document.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := response.SzConfigExport(context.Background(), configDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("configdoc cannot parse config definition: %w", err)
+	}
+
+	return result, nil
+}
+
+/*
+Method TableNames returns the names of the tables in sorted order.
+*/
+func (document *Document) TableNames() []string {
+	result := make([]string, 0, len(document.Tables))
+	for name := range document.Tables {
+		result = append(result, name)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+/*
+Method Clone returns a copy of the row.
+*/
+func (row Row) Clone() Row {
+	result := make(Row, len(row))
+	for column, value := range row {
+		result[column] = value
+	}
+
+	return result
+}
+
+/*
+Method Int returns the integer value of a column.
+*/
+func (row Row) Int(column string) (int64, bool) {
+	switch value := row[column].(type) {
+	case json.Number:
+		result, err := value.Int64()
+
+		return result, err == nil
+	case int:
+		return int64(value), true
+	case int64:
+		return value, true
+	case float64:
+		return int64(value), value == float64(int64(value))
+	default:
+		return 0, false
+	}
+}
+
+/*
+Method String returns the string value of a column. Non-string values, including null, return "".
+*/
+func (row Row) String(column string) string {
+	if value, isString := row[column].(string); isString {
+		return value
+	}
+
+	return ""
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func decodeRows(rawMember json.RawMessage) ([]Row, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawMember))
+	decoder.UseNumber()
+
+	result := []Row{}
+
+	err := decoder.Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("configdoc cannot decode table: %w", err)
+	}
+
+	return result, nil
+}
+
+func formatValue(value any) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return typedValue
+	default:
+		return fmt.Sprint(typedValue)
+	}
+}
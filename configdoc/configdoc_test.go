@@ -0,0 +1,163 @@
+package configdoc_test
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func readConfigDefinition(t *testing.T) string {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", "SzConfigExportResponse.jsonl"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+	require.True(t, scanner.Scan())
+
+	return scanner.Text()
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParse(test *testing.T) {
+	test.Parallel()
+
+	document, err := configdoc.Parse(readConfigDefinition(test))
+	require.NoError(test, err)
+	assert.Len(test, document.Tables[configdoc.TableDataSource], 2)
+	assert.Contains(test, document.Objects, "CONFIG_BASE_VERSION")
+	assert.Contains(test, document.TableNames(), configdoc.TableAttribute)
+
+	index := document.Find(configdoc.TableFeatureType, "FTYPE_CODE", "NAME")
+	require.GreaterOrEqual(test, index, 0)
+
+	ftypeID, isInt := document.Tables[configdoc.TableFeatureType][index].Int("FTYPE_ID")
+	assert.True(test, isInt)
+	assert.Equal(test, int64(1), ftypeID)
+	assert.Equal(test, -1, document.Find(configdoc.TableFeatureType, "FTYPE_CODE", "NO_SUCH_FTYPE"))
+}
+
+func TestFromResponse(test *testing.T) {
+	test.Parallel()
+
+	configExport, err := response.SzConfigExport(test.Context(), readConfigDefinition(test))
+	require.NoError(test, err)
+
+	document, err := configdoc.FromResponse(configExport)
+	require.NoError(test, err)
+	assert.Len(test, document.Tables[configdoc.TableDataSource], 2)
+	assert.Contains(test, document.Objects, "CONFIG_BASE_VERSION")
+
+	roundTrip, err := document.Response()
+	require.NoError(test, err)
+
+	roundTripDocument, err := configdoc.FromResponse(roundTrip)
+	require.NoError(test, err)
+	assert.Equal(test, document, roundTripDocument)
+}
+
+func TestParse_Bad(test *testing.T) {
+	test.Parallel()
+
+	_, err := configdoc.Parse(`{"G2_CONFIG": [`)
+	require.Error(test, err)
+
+	_, err = configdoc.Parse(`{"G2_CONFIG": {"CFG_DSRC": [1]}}`)
+	require.Error(test, err)
+}
+
+func TestDocument_JSON_RoundTrip(test *testing.T) {
+	test.Parallel()
+
+	original := readConfigDefinition(test)
+	document, err := configdoc.Parse(original)
+	require.NoError(test, err)
+
+	serialized, err := document.JSON()
+	require.NoError(test, err)
+	assert.JSONEq(test, original, serialized)
+}
+
+func TestDocument_Clone(test *testing.T) {
+	test.Parallel()
+
+	document, err := configdoc.Parse(readConfigDefinition(test))
+	require.NoError(test, err)
+
+	clone := document.Clone()
+	clone.Tables[configdoc.TableDataSource][0]["DSRC_CODE"] = "CHANGED"
+	assert.Equal(test, "TEST", document.Tables[configdoc.TableDataSource][0].String("DSRC_CODE"))
+}
+
+func TestRowKey(test *testing.T) {
+	test.Parallel()
+
+	row := configdoc.Row{"BEHAVIOR": "NAME", "CANDIDATE_CAP": 10, "FTYPE_ID": 0, "GPLAN_ID": 1}
+	assert.Equal(test, "GPLAN_ID=1,BEHAVIOR=NAME,FTYPE_ID=0", configdoc.RowKey(configdoc.TableGenericThreshold, row))
+	assert.Equal(test, "A=null,B=x", configdoc.RowKey("CFG_UNKEYED", configdoc.Row{"B": "x", "A": nil}))
+}
+
+func TestCanonicalize(test *testing.T) {
+	test.Parallel()
+
+	first := `{"G2_CONFIG": {"CFG_DSRC": [{"DSRC_ID": 1, "DSRC_CODE": "A"}, {"DSRC_ID": 2, "DSRC_CODE": "B"}],
+		"CONFIG_BASE_VERSION": {"VERSION": "4.0.0", "BUILD_NUMBER": "1"}}}`
+	second := `{"G2_CONFIG":{"CONFIG_BASE_VERSION":{"BUILD_NUMBER":"1","VERSION":"4.0.0"},` +
+		`"CFG_DSRC":[{"DSRC_CODE":"B","DSRC_ID":2},{"DSRC_CODE":"A","DSRC_ID":1}]}}`
+
+	canonical, err := configdoc.Canonicalize(first)
+	require.NoError(test, err)
+	assert.Equal(test, `{"G2_CONFIG":{"CFG_DSRC":[{"DSRC_CODE":"A","DSRC_ID":1},{"DSRC_CODE":"B","DSRC_ID":2}],`+
+		`"CONFIG_BASE_VERSION":{"BUILD_NUMBER":"1","VERSION":"4.0.0"}}}`, canonical)
+
+	firstHash, err := configdoc.Hash(first)
+	require.NoError(test, err)
+
+	secondHash, err := configdoc.Hash(second)
+	require.NoError(test, err)
+	assert.Equal(test, firstHash, secondHash)
+	assert.Len(test, firstHash, 64)
+
+	_, err = configdoc.Hash(`{"G2_CONFIG": [`)
+	require.Error(test, err)
+}
+
+func TestDocument_Hash(test *testing.T) {
+	test.Parallel()
+
+	document, err := configdoc.Parse(readConfigDefinition(test))
+	require.NoError(test, err)
+
+	original, err := document.Hash()
+	require.NoError(test, err)
+
+	clone := document.Clone()
+	rows := clone.Tables[configdoc.TableAttribute]
+	rows[0], rows[len(rows)-1] = rows[len(rows)-1], rows[0]
+	reordered, err := clone.Hash()
+	require.NoError(test, err)
+	assert.Equal(test, original, reordered)
+
+	clone.Tables[configdoc.TableDataSource][0]["DSRC_DESC"] = "Changed"
+	changed, err := clone.Hash()
+	require.NoError(test, err)
+	assert.NotEqual(test, original, changed)
+}
@@ -0,0 +1,17 @@
+/*
+Package configdoc is a generic, table-oriented view of a Senzing configuration definition.
+
+A configuration definition is the JSON returned by SzConfig.Export, parsed by response.SzConfigExport
+into a typedef.SzConfigExportResponse.
+Its "G2_CONFIG" member holds tables such as CFG_DSRC, CFG_FTYPE, and CFG_ATTR.
+The typed response has a struct per table, so a [Document] built from it with [FromResponse]
+keeps each table as a list of [Row] values instead.
+Tools can then match, compare, and edit rows of any table by its key columns (see [TableKeys])
+without code for every column of every table, and turn the result back into a typed response with
+[Document.Response].
+Members of "G2_CONFIG" that are not tables (e.g. CONFIG_BASE_VERSION) are preserved unchanged.
+
+[Canonicalize] and [Hash] ignore key order, whitespace, and the order of rows within tables,
+so two exports of the same configuration compare equal.
+*/
+package configdoc
@@ -0,0 +1,712 @@
+package configedit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Attribute struct is a CFG_ATTR row, which maps a record attribute to a feature element.
+*/
+type Attribute struct {
+	Class          string // ATTR_CLASS, e.g. "IDENTIFIER".
+	Code           string // ATTR_CODE, the key used in records, e.g. "LOYALTY_NUMBER".
+	DefaultValue   string // DEFAULT_VALUE, or "" for none.
+	FeatureElement string // FELEM_CODE, e.g. "ID_NUM". Must be empty if FeatureType is empty.
+	FeatureType    string // FTYPE_CODE, e.g. "LOYALTY". Empty for attributes that are not features.
+	Internal       bool   // INTERNAL.
+	Required       string // FELEM_REQ: "Yes", "No", "Any", or "Desired". Defaults to "No".
+}
+
+/*
+Type BOMElement struct is one element in the bill of materials (CFG_FBOM) of a feature type.
+*/
+type BOMElement struct {
+	Code         string // FELEM_CODE.
+	Derived      bool   // DERIVED.
+	DisplayLevel int64  // DISPLAY_LEVEL. Zero hides the element.
+}
+
+/*
+Type FeatureElement struct is a CFG_FELEM row.
+*/
+type FeatureElement struct {
+	Code        string // FELEM_CODE.
+	DataType    string // DATA_TYPE, e.g. "string". Defaults to "string".
+	Description string // FELEM_DESC. Defaults to Code.
+}
+
+/*
+Type FeatureType struct is a CFG_FTYPE row and its CFG_FBOM rows.
+*/
+type FeatureType struct {
+	Anonymize         bool         // ANONYMIZE.
+	Class             string       // FCLASS_CODE, e.g. "ISSUED_ID".
+	Code              string       // FTYPE_CODE.
+	Derived           bool         // DERIVED.
+	Description       string       // FTYPE_DESC. Defaults to Code.
+	Elements          []BOMElement // CFG_FBOM rows, in execution order.
+	Exclusive         bool         // FTYPE_EXCL.
+	Frequency         string       // FTYPE_FREQ, e.g. "F1". Defaults to "FM".
+	PersistHistory    bool         // PERSIST_HISTORY.
+	ShowInMatchKey    bool         // SHOW_IN_MATCH_KEY.
+	Stable            bool         // FTYPE_STAB.
+	UsedForCandidates bool         // USED_FOR_CAND.
+}
+
+/*
+Type GenericThreshold struct is a CFG_GENERIC_THRESHOLD row.
+*/
+type GenericThreshold struct {
+	Behavior     string // BEHAVIOR, e.g. "F1".
+	CandidateCap int64  // CANDIDATE_CAP.
+	FeatureType  string // FTYPE_CODE, or "" for every feature type with the behavior.
+	Plan         string // GPLAN_CODE, e.g. "INGEST".
+	ScoringCap   int64  // SCORING_CAP, or -1 for no cap.
+	SendToRedo   bool   // SEND_TO_REDO.
+}
+
+/*
+Type SzConfigEditor struct edits a typedef.SzConfigExportResponse.
+The rows of its tables are edited through their configdoc view.
+
+Every operation checks referential integrity before it changes the definition,
+so a failed operation leaves the definition unchanged.
+*/
+type SzConfigEditor struct {
+	document *configdoc.Document
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// IDs of rows added by the editor start here, above the IDs of rows supplied by Senzing.
+const FirstUserID = 1000
+
+const (
+	columnAttributeCode    = "ATTR_CODE"
+	columnElementCode      = "FELEM_CODE"
+	columnElementID        = "FELEM_ID"
+	columnFeatureClassCode = "FCLASS_CODE"
+	columnFeatureTypeCode  = "FTYPE_CODE"
+	columnFeatureTypeID    = "FTYPE_ID"
+	columnPlanCode         = "GPLAN_CODE"
+	no                     = "No"
+	yes                    = "Yes"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Editing errors.
+var (
+	ErrDuplicate  = errors.New("config item already exists")
+	ErrInvalid    = errors.New("config item is invalid")
+	ErrNotFound   = errors.New("config item does not exist")
+	ErrReferenced = errors.New("config item is still referenced")
+)
+
+var requiredValues = []string{"Any", "Desired", no, yes}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewSzConfigEditor creates an editor for a configuration definition.
+
+Input
+  - configExport: The configuration definition, e.g. from response.SzConfigExport. Must not be nil.
+
+Output
+  - An editor working on a copy of the definition.
+*/
+func NewSzConfigEditor(configExport *typedef.SzConfigExportResponse) (*SzConfigEditor, error) {
+	if configExport == nil {
+		return nil, szerror.Wrap(
+			fmt.Errorf("%w: configedit cannot edit a nil config definition", ErrInvalid),
+			szerror.SzBadInputError,
+			szerror.SzError,
+		)
+	}
+
+	document, err := configdoc.FromResponse(configExport)
+	if err != nil {
+		return nil, fmt.Errorf("configedit cannot read config definition: %w", err)
+	}
+
+	return &SzConfigEditor{document: document}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method AddAttribute adds a CFG_ATTR row.
+
+The feature type must exist, and the feature element must be in the feature type's bill of materials
+(or be one of configdoc.ImplicitFeatureElements).
+
+Input
+  - attribute: The attribute to add.
+
+Output
+  - The new ATTR_ID.
+*/
+func (editor *SzConfigEditor) AddAttribute(attribute Attribute) (int64, error) {
+	attribute.Code = normalizeCode(attribute.Code)
+	attribute.FeatureElement = normalizeCode(attribute.FeatureElement)
+	attribute.FeatureType = normalizeCode(attribute.FeatureType)
+
+	if attribute.Required == "" {
+		attribute.Required = no
+	}
+
+	err := editor.checkAttribute(attribute)
+	if err != nil {
+		return 0, err
+	}
+
+	attributeID := editor.nextID(configdoc.TableAttribute, "ATTR_ID")
+	editor.document.Tables[configdoc.TableAttribute] = append(editor.document.Tables[configdoc.TableAttribute],
+		configdoc.Row{
+			"ATTR_CLASS":          strings.ToUpper(attribute.Class),
+			columnAttributeCode:   attribute.Code,
+			"ATTR_ID":             attributeID,
+			"DEFAULT_VALUE":       nullable(attribute.DefaultValue),
+			columnElementCode:     nullable(attribute.FeatureElement),
+			"FELEM_REQ":           attribute.Required,
+			columnFeatureTypeCode: nullable(attribute.FeatureType),
+			"INTERNAL":            yesNo(attribute.Internal),
+		})
+
+	return attributeID, nil
+}
+
+/*
+Method AddFeatureElement adds a CFG_FELEM row.
+
+Input
+  - featureElement: The feature element to add.
+
+Output
+  - The new FELEM_ID.
+*/
+func (editor *SzConfigEditor) AddFeatureElement(featureElement FeatureElement) (int64, error) {
+	featureElement.Code = normalizeCode(featureElement.Code)
+	if featureElement.Code == "" {
+		return 0, fmt.Errorf("%w: feature element code is empty", ErrInvalid)
+	}
+
+	if editor.document.Find(configdoc.TableFeatureElement, columnElementCode, featureElement.Code) >= 0 ||
+		slices.Contains(configdoc.ImplicitFeatureElements, featureElement.Code) {
+		return 0, fmt.Errorf("%w: feature element %s", ErrDuplicate, featureElement.Code)
+	}
+
+	elementID := editor.nextID(configdoc.TableFeatureElement, columnElementID)
+	editor.document.Tables[configdoc.TableFeatureElement] = append(
+		editor.document.Tables[configdoc.TableFeatureElement],
+		configdoc.Row{
+			"DATA_TYPE":       defaultString(strings.ToLower(featureElement.DataType), "string"),
+			columnElementCode: featureElement.Code,
+			"FELEM_DESC":      defaultString(featureElement.Description, featureElement.Code),
+			columnElementID:   elementID,
+		})
+
+	return elementID, nil
+}
+
+/*
+Method AddFeatureType adds a CFG_FTYPE row and its CFG_FBOM rows.
+
+The feature class and every element must exist.
+
+Input
+  - featureType: The feature type to add.
+
+Output
+  - The new FTYPE_ID.
+*/
+func (editor *SzConfigEditor) AddFeatureType(featureType FeatureType) (int64, error) {
+	featureType.Code = normalizeCode(featureType.Code)
+
+	classID, elementIDs, err := editor.checkFeatureType(featureType)
+	if err != nil {
+		return 0, err
+	}
+
+	featureTypeID := editor.nextID(configdoc.TableFeatureType, columnFeatureTypeID)
+	editor.document.Tables[configdoc.TableFeatureType] = append(editor.document.Tables[configdoc.TableFeatureType],
+		configdoc.Row{
+			"ANONYMIZE":           yesNo(featureType.Anonymize),
+			"DERIVED":             yesNo(featureType.Derived),
+			"FCLASS_ID":           classID,
+			columnFeatureTypeCode: featureType.Code,
+			"FTYPE_DESC":          defaultString(featureType.Description, featureType.Code),
+			"FTYPE_EXCL":          yesNo(featureType.Exclusive),
+			"FTYPE_FREQ":          defaultString(strings.ToUpper(featureType.Frequency), "FM"),
+			columnFeatureTypeID:   featureTypeID,
+			"FTYPE_STAB":          yesNo(featureType.Stable),
+			"PERSIST_HISTORY":     yesNo(featureType.PersistHistory),
+			"RTYPE_ID":            int64(0),
+			"SHOW_IN_MATCH_KEY":   yesNo(featureType.ShowInMatchKey),
+			"USED_FOR_CAND":       yesNo(featureType.UsedForCandidates),
+			"VERSION":             int64(1),
+		})
+
+	for index, element := range featureType.Elements {
+		editor.document.Tables[configdoc.TableFeatureBOM] = append(editor.document.Tables[configdoc.TableFeatureBOM],
+			configdoc.Row{
+				"DERIVED":           yesNo(element.Derived),
+				"DISPLAY_DELIM":     nil,
+				"DISPLAY_LEVEL":     element.DisplayLevel,
+				"EXEC_ORDER":        int64(index + 1),
+				columnElementID:     elementIDs[index],
+				columnFeatureTypeID: featureTypeID,
+			})
+	}
+
+	return featureTypeID, nil
+}
+
+/*
+Method AddGenericThreshold adds a CFG_GENERIC_THRESHOLD row.
+
+The generic plan and the feature type (if given) must exist.
+
+Input
+  - threshold: The threshold to add.
+*/
+func (editor *SzConfigEditor) AddGenericThreshold(threshold GenericThreshold) error {
+	threshold.Behavior = normalizeCode(threshold.Behavior)
+	if threshold.Behavior == "" {
+		return fmt.Errorf("%w: generic threshold behavior is empty", ErrInvalid)
+	}
+
+	row, err := editor.thresholdRow(threshold)
+	if err != nil {
+		return err
+	}
+
+	if editor.findThreshold(row) >= 0 {
+		return fmt.Errorf("%w: generic threshold %s", ErrDuplicate,
+			configdoc.RowKey(configdoc.TableGenericThreshold, row))
+	}
+
+	row["CANDIDATE_CAP"] = threshold.CandidateCap
+	row["SCORING_CAP"] = threshold.ScoringCap
+	row["SEND_TO_REDO"] = yesNo(threshold.SendToRedo)
+	editor.document.Tables[configdoc.TableGenericThreshold] = append(
+		editor.document.Tables[configdoc.TableGenericThreshold],
+		row,
+	)
+
+	return nil
+}
+
+/*
+Method Export returns the edited configuration definition.
+*/
+func (editor *SzConfigEditor) Export() (*typedef.SzConfigExportResponse, error) {
+	result, err := editor.document.Response()
+	if err != nil {
+		return nil, fmt.Errorf("configedit cannot export config definition: %w", err)
+	}
+
+	return result, nil
+}
+
+/*
+Method Load serializes the edited configuration definition and loads it into a configuration.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: The config manager used to create the configuration.
+
+Output
+  - The configuration created by SzConfigManager.CreateConfigFromString.
+*/
+func (editor *SzConfigEditor) Load(
+	ctx context.Context,
+	szConfigManager senzing.SzConfigManager,
+) (senzing.SzConfig, error) {
+	configDefinition, err := editor.document.JSON()
+	if err != nil {
+		return nil, fmt.Errorf("configedit cannot serialize config definition: %w", err)
+	}
+
+	result, err := szConfigManager.CreateConfigFromString(ctx, configDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("configedit cannot create config from edited definition: %w", err)
+	}
+
+	return result, nil
+}
+
+/*
+Method RemoveAttribute removes a CFG_ATTR row.
+
+Input
+  - attributeCode: The ATTR_CODE to remove.
+*/
+func (editor *SzConfigEditor) RemoveAttribute(attributeCode string) error {
+	attributeCode = normalizeCode(attributeCode)
+
+	index := editor.document.Find(configdoc.TableAttribute, columnAttributeCode, attributeCode)
+	if index < 0 {
+		return fmt.Errorf("%w: attribute %s", ErrNotFound, attributeCode)
+	}
+
+	editor.document.Tables[configdoc.TableAttribute] = slices.Delete(
+		editor.document.Tables[configdoc.TableAttribute], index, index+1)
+
+	return nil
+}
+
+/*
+Method RemoveFeatureElement removes a CFG_FELEM row.
+The element must not be used by any attribute, bill of materials, or function call.
+
+Input
+  - featureElementCode: The FELEM_CODE to remove.
+*/
+func (editor *SzConfigEditor) RemoveFeatureElement(featureElementCode string) error {
+	featureElementCode = normalizeCode(featureElementCode)
+
+	index := editor.document.Find(configdoc.TableFeatureElement, columnElementCode, featureElementCode)
+	if index < 0 {
+		return fmt.Errorf("%w: feature element %s", ErrNotFound, featureElementCode)
+	}
+
+	elementID, _ := editor.document.Tables[configdoc.TableFeatureElement][index].Int(columnElementID)
+
+	references := editor.references(configdoc.TableFeatureElement, []string{columnElementID}, elementID)
+	if editor.document.Find(configdoc.TableAttribute, columnElementCode, featureElementCode) >= 0 {
+		references = append(references, configdoc.TableAttribute)
+	}
+
+	if len(references) > 0 {
+		slices.Sort(references)
+
+		return fmt.Errorf("%w: feature element %s is used by %s", ErrReferenced, featureElementCode,
+			strings.Join(references, ", "))
+	}
+
+	editor.document.Tables[configdoc.TableFeatureElement] = slices.Delete(
+		editor.document.Tables[configdoc.TableFeatureElement], index, index+1)
+
+	return nil
+}
+
+/*
+Method RemoveFeatureType removes a CFG_FTYPE row and its CFG_FBOM rows.
+The feature type must not be used by any attribute, threshold, or function call.
+
+Input
+  - featureTypeCode: The FTYPE_CODE to remove.
+*/
+func (editor *SzConfigEditor) RemoveFeatureType(featureTypeCode string) error {
+	featureTypeCode = normalizeCode(featureTypeCode)
+
+	index := editor.document.Find(configdoc.TableFeatureType, columnFeatureTypeCode, featureTypeCode)
+	if index < 0 {
+		return fmt.Errorf("%w: feature type %s", ErrNotFound, featureTypeCode)
+	}
+
+	featureTypeID, _ := editor.document.Tables[configdoc.TableFeatureType][index].Int(columnFeatureTypeID)
+
+	references := editor.references(
+		configdoc.TableFeatureBOM,
+		[]string{columnFeatureTypeID, "EFEAT_FTYPE_ID"},
+		featureTypeID,
+	)
+	if editor.document.Find(configdoc.TableAttribute, columnFeatureTypeCode, featureTypeCode) >= 0 {
+		references = append(references, configdoc.TableAttribute)
+	}
+
+	if len(references) > 0 {
+		slices.Sort(references)
+
+		return fmt.Errorf("%w: feature type %s is used by %s", ErrReferenced, featureTypeCode,
+			strings.Join(references, ", "))
+	}
+
+	editor.document.Tables[configdoc.TableFeatureType] = slices.Delete(
+		editor.document.Tables[configdoc.TableFeatureType], index, index+1)
+	editor.document.Tables[configdoc.TableFeatureBOM] = slices.DeleteFunc(
+		editor.document.Tables[configdoc.TableFeatureBOM],
+		func(row configdoc.Row) bool {
+			rowFeatureTypeID, _ := row.Int(columnFeatureTypeID)
+
+			return rowFeatureTypeID == featureTypeID
+		})
+
+	return nil
+}
+
+/*
+Method RemoveGenericThreshold removes a CFG_GENERIC_THRESHOLD row.
+
+Input
+  - plan: The GPLAN_CODE, e.g. "INGEST".
+  - behavior: The BEHAVIOR, e.g. "F1".
+  - featureTypeCode: The FTYPE_CODE, or "" for the row that applies to every feature type.
+*/
+func (editor *SzConfigEditor) RemoveGenericThreshold(plan string, behavior string, featureTypeCode string) error {
+	//exhaustruct:ignore
+	row, err := editor.thresholdRow(GenericThreshold{
+		Behavior:    normalizeCode(behavior),
+		FeatureType: featureTypeCode,
+		Plan:        plan,
+	})
+	if err != nil {
+		return err
+	}
+
+	index := editor.findThreshold(row)
+	if index < 0 {
+		return fmt.Errorf("%w: generic threshold %s", ErrNotFound,
+			configdoc.RowKey(configdoc.TableGenericThreshold, row))
+	}
+
+	editor.document.Tables[configdoc.TableGenericThreshold] = slices.Delete(
+		editor.document.Tables[configdoc.TableGenericThreshold], index, index+1)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (editor *SzConfigEditor) checkAttribute(attribute Attribute) error {
+	if attribute.Code == "" {
+		return fmt.Errorf("%w: attribute code is empty", ErrInvalid)
+	}
+
+	if !slices.Contains(requiredValues, attribute.Required) {
+		return fmt.Errorf("%w: attribute %s has FELEM_REQ %q", ErrInvalid, attribute.Code, attribute.Required)
+	}
+
+	if editor.document.Find(configdoc.TableAttribute, columnAttributeCode, attribute.Code) >= 0 {
+		return fmt.Errorf("%w: attribute %s", ErrDuplicate, attribute.Code)
+	}
+
+	if attribute.FeatureType == "" {
+		if attribute.FeatureElement != "" {
+			return fmt.Errorf("%w: attribute %s has a feature element but no feature type", ErrInvalid, attribute.Code)
+		}
+
+		return nil
+	}
+
+	featureTypeID, err := editor.featureTypeID(attribute.FeatureType)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(configdoc.ImplicitFeatureElements, attribute.FeatureElement) {
+		return nil
+	}
+
+	elementID, err := editor.featureElementID(attribute.FeatureElement)
+	if err != nil {
+		return err
+	}
+
+	isInBOM := slices.ContainsFunc(editor.document.Tables[configdoc.TableFeatureBOM], func(row configdoc.Row) bool {
+		rowFeatureTypeID, _ := row.Int(columnFeatureTypeID)
+		rowElementID, _ := row.Int(columnElementID)
+
+		return rowFeatureTypeID == featureTypeID && rowElementID == elementID
+	})
+	if !isInBOM {
+		return fmt.Errorf("%w: feature element %s is not in feature type %s", ErrNotFound,
+			attribute.FeatureElement, attribute.FeatureType)
+	}
+
+	return nil
+}
+
+func (editor *SzConfigEditor) checkFeatureType(featureType FeatureType) (int64, []int64, error) {
+	if featureType.Code == "" {
+		return 0, nil, fmt.Errorf("%w: feature type code is empty", ErrInvalid)
+	}
+
+	if len(featureType.Elements) == 0 {
+		return 0, nil, fmt.Errorf("%w: feature type %s has no elements", ErrInvalid, featureType.Code)
+	}
+
+	if editor.document.Find(configdoc.TableFeatureType, columnFeatureTypeCode, featureType.Code) >= 0 {
+		return 0, nil, fmt.Errorf("%w: feature type %s", ErrDuplicate, featureType.Code)
+	}
+
+	classCode := normalizeCode(featureType.Class)
+
+	classIndex := editor.document.Find(configdoc.TableFeatureClass, columnFeatureClassCode, classCode)
+	if classIndex < 0 {
+		return 0, nil, fmt.Errorf("%w: feature class %q", ErrNotFound, classCode)
+	}
+
+	classID, _ := editor.document.Tables[configdoc.TableFeatureClass][classIndex].Int("FCLASS_ID")
+	elementIDs := make([]int64, 0, len(featureType.Elements))
+
+	for _, element := range featureType.Elements {
+		elementID, err := editor.featureElementID(normalizeCode(element.Code))
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if slices.Contains(elementIDs, elementID) {
+			return 0, nil, fmt.Errorf("%w: feature element %s in feature type %s", ErrDuplicate,
+				normalizeCode(element.Code), featureType.Code)
+		}
+
+		elementIDs = append(elementIDs, elementID)
+	}
+
+	return classID, elementIDs, nil
+}
+
+func (editor *SzConfigEditor) featureElementID(featureElementCode string) (int64, error) {
+	index := editor.document.Find(configdoc.TableFeatureElement, columnElementCode, featureElementCode)
+	if index < 0 {
+		return 0, fmt.Errorf("%w: feature element %q", ErrNotFound, featureElementCode)
+	}
+
+	result, _ := editor.document.Tables[configdoc.TableFeatureElement][index].Int(columnElementID)
+
+	return result, nil
+}
+
+func (editor *SzConfigEditor) featureTypeID(featureTypeCode string) (int64, error) {
+	index := editor.document.Find(configdoc.TableFeatureType, columnFeatureTypeCode, featureTypeCode)
+	if index < 0 {
+		return 0, fmt.Errorf("%w: feature type %q", ErrNotFound, featureTypeCode)
+	}
+
+	result, _ := editor.document.Tables[configdoc.TableFeatureType][index].Int(columnFeatureTypeID)
+
+	return result, nil
+}
+
+func (editor *SzConfigEditor) findThreshold(row configdoc.Row) int {
+	key := configdoc.RowKey(configdoc.TableGenericThreshold, row)
+
+	return slices.IndexFunc(editor.document.Tables[configdoc.TableGenericThreshold], func(candidate configdoc.Row) bool {
+		return configdoc.RowKey(configdoc.TableGenericThreshold, candidate) == key
+	})
+}
+
+func (editor *SzConfigEditor) nextID(table string, column string) int64 {
+	result := int64(FirstUserID)
+
+	for _, row := range editor.document.Tables[table] {
+		if rowID, isInt := row.Int(column); isInt && rowID >= result {
+			result = rowID + 1
+		}
+	}
+
+	return result
+}
+
+// Tables, other than CFG_FTYPE, CFG_FELEM, and excludedTable, with a row whose columns include rowID.
+func (editor *SzConfigEditor) references(excludedTable string, columns []string, rowID int64) []string {
+	result := []string{}
+
+	for _, table := range editor.document.TableNames() {
+		if table == excludedTable || table == configdoc.TableFeatureType || table == configdoc.TableFeatureElement {
+			continue
+		}
+
+		isReferenced := slices.ContainsFunc(editor.document.Tables[table], func(row configdoc.Row) bool {
+			for _, column := range columns {
+				if value, isInt := row.Int(column); isInt && value == rowID {
+					return true
+				}
+			}
+
+			return false
+		})
+		if isReferenced {
+			result = append(result, table)
+		}
+	}
+
+	return result
+}
+
+func (editor *SzConfigEditor) thresholdRow(threshold GenericThreshold) (configdoc.Row, error) {
+	planCode := normalizeCode(threshold.Plan)
+
+	planIndex := editor.document.Find(configdoc.TableGenericPlan, columnPlanCode, planCode)
+	if planIndex < 0 {
+		return nil, fmt.Errorf("%w: generic plan %q", ErrNotFound, planCode)
+	}
+
+	planID, _ := editor.document.Tables[configdoc.TableGenericPlan][planIndex].Int("GPLAN_ID")
+
+	featureTypeID := int64(0)
+
+	if threshold.FeatureType != "" {
+		var err error
+
+		featureTypeID, err = editor.featureTypeID(normalizeCode(threshold.FeatureType))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return configdoc.Row{
+		"BEHAVIOR":          threshold.Behavior,
+		columnFeatureTypeID: featureTypeID,
+		"GPLAN_ID":          planID,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func defaultString(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func nullable(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+func yesNo(value bool) string {
+	if value {
+		return yes
+	}
+
+	return no
+}
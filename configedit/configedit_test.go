@@ -0,0 +1,268 @@
+package configedit_test
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/configedit"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockConfig struct {
+	senzing.SzConfig
+
+	configDefinition string
+}
+
+type mockConfigManager struct {
+	senzing.SzConfigManager
+}
+
+func (configManager *mockConfigManager) CreateConfigFromString(
+	_ context.Context,
+	configDefinition string,
+) (senzing.SzConfig, error) {
+	return &mockConfig{configDefinition: configDefinition}, nil //exhaustruct:ignore
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newEditor(t *testing.T) *configedit.SzConfigEditor {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", "SzConfigExportResponse.jsonl"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+	require.True(t, scanner.Scan())
+
+	configExport, err := response.SzConfigExport(t.Context(), scanner.Text())
+	require.NoError(t, err)
+
+	result, err := configedit.NewSzConfigEditor(configExport)
+	require.NoError(t, err)
+
+	return result
+}
+
+func loyaltyFeatureType() configedit.FeatureType {
+	return configedit.FeatureType{ //exhaustruct:ignore
+		Class:          "ISSUED_ID",
+		Code:           "loyalty",
+		Elements:       []configedit.BOMElement{{Code: "ID_NUM", Derived: false, DisplayLevel: 1}},
+		Frequency:      "F1",
+		ShowInMatchKey: true,
+	}
+}
+
+func loyaltyAttribute() configedit.Attribute {
+	return configedit.Attribute{ //exhaustruct:ignore
+		Class:          "IDENTIFIER",
+		Code:           "LOYALTY_NUMBER",
+		FeatureElement: "ID_NUM",
+		FeatureType:    "LOYALTY",
+		Required:       "Yes",
+	}
+}
+
+func export(t *testing.T, editor *configedit.SzConfigEditor) *configdoc.Document {
+	t.Helper()
+
+	configExport, err := editor.Export()
+	require.NoError(t, err)
+
+	result, err := configdoc.FromResponse(configExport)
+	require.NoError(t, err)
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestSzConfigEditor_AddFeatureTypeAndAttribute(test *testing.T) {
+	test.Parallel()
+
+	editor := newEditor(test)
+
+	featureTypeID, err := editor.AddFeatureType(loyaltyFeatureType())
+	require.NoError(test, err)
+	assert.Equal(test, int64(configedit.FirstUserID), featureTypeID)
+
+	attributeID, err := editor.AddAttribute(loyaltyAttribute())
+	require.NoError(test, err)
+	assert.Equal(test, int64(2818), attributeID)
+
+	document := export(test, editor)
+	index := document.Find(configdoc.TableFeatureType, "FTYPE_CODE", "LOYALTY")
+	require.GreaterOrEqual(test, index, 0)
+	assert.Equal(test, "F1", document.Tables[configdoc.TableFeatureType][index].String("FTYPE_FREQ"))
+	assert.GreaterOrEqual(test, document.Find(configdoc.TableFeatureBOM, "FTYPE_ID", featureTypeID), 0)
+	assert.GreaterOrEqual(test, document.Find(configdoc.TableAttribute, "ATTR_CODE", "LOYALTY_NUMBER"), 0)
+}
+
+func TestSzConfigEditor_AddAttribute_Checks(test *testing.T) {
+	test.Parallel()
+
+	editor := newEditor(test)
+
+	_, err := editor.AddAttribute(loyaltyAttribute())
+	require.ErrorIs(test, err, configedit.ErrNotFound)
+
+	attribute := loyaltyAttribute()
+	attribute.FeatureType = "SSN"
+	attribute.FeatureElement = "GIVEN_NAME"
+	_, err = editor.AddAttribute(attribute)
+	require.ErrorIs(test, err, configedit.ErrNotFound)
+
+	attribute.Code = "SSN_NUMBER"
+	attribute.FeatureElement = "ID_NUM"
+	_, err = editor.AddAttribute(attribute)
+	require.ErrorIs(test, err, configedit.ErrDuplicate)
+
+	attribute.Code = "SSN_TYPE"
+	attribute.FeatureElement = "USAGE_TYPE"
+	attribute.Required = "Sometimes"
+	_, err = editor.AddAttribute(attribute)
+	require.ErrorIs(test, err, configedit.ErrInvalid)
+
+	attribute.Required = ""
+	_, err = editor.AddAttribute(attribute)
+	require.NoError(test, err)
+}
+
+func TestSzConfigEditor_AddFeatureType_Checks(test *testing.T) {
+	test.Parallel()
+
+	editor := newEditor(test)
+
+	featureType := loyaltyFeatureType()
+	featureType.Class = "NO_SUCH_CLASS"
+	_, err := editor.AddFeatureType(featureType)
+	require.ErrorIs(test, err, configedit.ErrNotFound)
+
+	featureType = loyaltyFeatureType()
+	featureType.Elements = append(featureType.Elements, configedit.BOMElement{Code: "NO_SUCH_FELEM"}) //exhaustruct:ignore
+	_, err = editor.AddFeatureType(featureType)
+	require.ErrorIs(test, err, configedit.ErrNotFound)
+
+	featureType = loyaltyFeatureType()
+	featureType.Code = "SSN"
+	_, err = editor.AddFeatureType(featureType)
+	require.ErrorIs(test, err, configedit.ErrDuplicate)
+}
+
+func TestSzConfigEditor_RemoveFeatureType(test *testing.T) {
+	test.Parallel()
+
+	editor := newEditor(test)
+
+	featureTypeID, err := editor.AddFeatureType(loyaltyFeatureType())
+	require.NoError(test, err)
+	_, err = editor.AddAttribute(loyaltyAttribute())
+	require.NoError(test, err)
+
+	err = editor.RemoveFeatureType("LOYALTY")
+	require.ErrorIs(test, err, configedit.ErrReferenced)
+	require.ErrorContains(test, err, configdoc.TableAttribute)
+
+	require.NoError(test, editor.RemoveAttribute("LOYALTY_NUMBER"))
+	require.NoError(test, editor.RemoveFeatureType("LOYALTY"))
+	require.ErrorIs(test, editor.RemoveFeatureType("LOYALTY"), configedit.ErrNotFound)
+
+	document := export(test, editor)
+	assert.Equal(test, -1, document.Find(configdoc.TableFeatureBOM, "FTYPE_ID", featureTypeID))
+
+	err = editor.RemoveFeatureType("NAME")
+	require.ErrorIs(test, err, configedit.ErrReferenced)
+	require.ErrorContains(test, err, "CFG_CFCALL")
+}
+
+func TestSzConfigEditor_FeatureElement(test *testing.T) {
+	test.Parallel()
+
+	editor := newEditor(test)
+
+	elementID, err := editor.AddFeatureElement(configedit.FeatureElement{Code: "tier"}) //exhaustruct:ignore
+	require.NoError(test, err)
+	assert.Equal(test, int64(configedit.FirstUserID), elementID)
+
+	_, err = editor.AddFeatureElement(configedit.FeatureElement{Code: "TIER"}) //exhaustruct:ignore
+	require.ErrorIs(test, err, configedit.ErrDuplicate)
+
+	_, err = editor.AddFeatureElement(configedit.FeatureElement{Code: "USAGE_TYPE"}) //exhaustruct:ignore
+	require.ErrorIs(test, err, configedit.ErrDuplicate)
+
+	require.ErrorIs(test, editor.RemoveFeatureElement("ID_NUM"), configedit.ErrReferenced)
+	require.NoError(test, editor.RemoveFeatureElement("TIER"))
+	require.ErrorIs(test, editor.RemoveFeatureElement("TIER"), configedit.ErrNotFound)
+}
+
+func TestSzConfigEditor_GenericThreshold(test *testing.T) {
+	test.Parallel()
+
+	editor := newEditor(test)
+
+	threshold := configedit.GenericThreshold{
+		Behavior:     "F1",
+		CandidateCap: 10,
+		FeatureType:  "SSN",
+		Plan:         "INGEST",
+		ScoringCap:   20,
+		SendToRedo:   true,
+	}
+	require.NoError(test, editor.AddGenericThreshold(threshold))
+	require.ErrorIs(test, editor.AddGenericThreshold(threshold), configedit.ErrDuplicate)
+	require.ErrorIs(test, editor.RemoveFeatureType("SSN"), configedit.ErrReferenced)
+
+	threshold.Plan = "NO_SUCH_PLAN"
+	require.ErrorIs(test, editor.AddGenericThreshold(threshold), configedit.ErrNotFound)
+
+	require.NoError(test, editor.RemoveGenericThreshold("INGEST", "F1", "SSN"))
+	require.ErrorIs(test, editor.RemoveGenericThreshold("INGEST", "F1", "SSN"), configedit.ErrNotFound)
+	require.NoError(test, editor.RemoveGenericThreshold("INGEST", "NAME", ""))
+}
+
+func TestSzConfigEditor_Load(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	editor := newEditor(test)
+	_, err := editor.AddFeatureType(loyaltyFeatureType())
+	require.NoError(test, err)
+
+	szConfig, err := editor.Load(ctx, &mockConfigManager{}) //exhaustruct:ignore
+	require.NoError(test, err)
+
+	config, isMock := szConfig.(*mockConfig)
+	require.True(test, isMock)
+	assert.Contains(test, config.configDefinition, `"FTYPE_CODE":"LOYALTY"`)
+}
+
+func TestNewSzConfigEditor_Nil(test *testing.T) {
+	test.Parallel()
+
+	_, err := configedit.NewSzConfigEditor(nil)
+	require.ErrorIs(test, err, configedit.ErrInvalid)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
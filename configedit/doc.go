@@ -0,0 +1,24 @@
+/*
+Package configedit edits Senzing configuration definitions beyond data sources.
+
+senzing.SzConfig can only register and unregister data sources.
+[SzConfigEditor] adds and removes attributes (CFG_ATTR), feature types (CFG_FTYPE and CFG_FBOM),
+feature elements (CFG_FELEM), and generic thresholds (CFG_GENERIC_THRESHOLD)
+in a typedef.SzConfigExportResponse, the JSON returned by SzConfig.Export as parsed by response.SzConfigExport.
+Rows are edited through the generic configdoc view of the response, so columns the editor does not set
+are preserved.
+
+Each operation checks referential integrity first: a feature type cannot be removed while an attribute,
+threshold, or function call uses it, an attribute cannot map to a feature element outside its feature type,
+and so on.
+The edited definition is loaded with SzConfigManager.CreateConfigFromString, where Senzing validates it again.
+
+Example:
+
+	configDefinition, _ := szConfig.Export(ctx)
+	configExport, _ := response.SzConfigExport(ctx, configDefinition)
+	editor, _ := configedit.NewSzConfigEditor(configExport)
+	_, _ = editor.AddFeatureType(configedit.FeatureType{Class: "ISSUED_ID", Code: "LOYALTY", ...})
+	editedConfig, _ := editor.Load(ctx, szConfigManager)
+*/
+package configedit
@@ -0,0 +1,506 @@
+package confighistory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Type ChangeType string classifies a [Change].
+type ChangeType string
+
+/*
+Type Change struct is a difference in one row of one table.
+*/
+type Change struct {
+	Fields []FieldChange // For ChangeModified, the columns that differ.
+	Key    string        // The row identity, e.g. "DSRC_CODE=CUSTOMERS".
+	Table  string        // The table, e.g. "CFG_DSRC".
+	Type   ChangeType
+}
+
+/*
+Type Diff struct is the semantic difference between two configurations.
+*/
+type Diff struct {
+	Changes      []Change
+	FromConfigID int64
+	ToConfigID   int64
+}
+
+/*
+Type Entry struct is one configuration in the configuration registry.
+*/
+type Entry struct {
+	Comments   string `json:"CONFIG_COMMENTS"`
+	ConfigID   int64  `json:"CONFIG_ID"`
+	CreateDate string `json:"SYS_CREATE_DT"`
+}
+
+/*
+Type FieldChange struct is a difference in one column.
+*/
+type FieldChange struct {
+	Column string
+	From   any
+	To     any
+}
+
+/*
+Type RollbackOptions struct configures [Rollback].
+*/
+type RollbackOptions struct {
+	// AllowDataSourceRemoval permits a rollback to a configuration that lacks data sources of the current default.
+	// Records may already be loaded for those data sources, so this is refused by default.
+	AllowDataSourceRemoval bool
+
+	// ExpectedCurrentConfigID, if not zero, must equal the current default configuration ID.
+	ExpectedCurrentConfigID int64
+}
+
+type configRegistry struct {
+	Configs []Entry `json:"CONFIGS"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Change types.
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeModified ChangeType = "modified"
+	ChangeRemoved  ChangeType = "removed"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Tables compared when no tables are requested.
+var DefaultTables = []string{
+	configdoc.TableDataSource,
+	configdoc.TableFeatureType,
+	configdoc.TableFeatureElement,
+	configdoc.TableAttribute,
+	configdoc.TableGenericThreshold,
+	configdoc.TableComparisonReturn,
+}
+
+// Rollback errors.
+var (
+	ErrAlreadyDefault     = errors.New("config ID is already the default")
+	ErrCurrentMismatch    = errors.New("current default config ID is not the expected config ID")
+	ErrDataSourcesRemoved = errors.New("rollback would remove data sources")
+	ErrUnknownConfigID    = errors.New("config ID is not in the config registry")
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Compare loads two registered configurations and compares them.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: The config manager holding both configurations.
+  - fromConfigID: The older configuration.
+  - toConfigID: The newer configuration.
+  - tables: The tables to compare. If none are given, DefaultTables are compared.
+
+Output
+  - The differences from fromConfigID to toConfigID.
+*/
+func Compare(
+	ctx context.Context,
+	szConfigManager senzing.SzConfigManager,
+	fromConfigID int64,
+	toConfigID int64,
+	tables ...string,
+) (*Diff, error) {
+	fromDocument, err := Load(ctx, szConfigManager, fromConfigID)
+	if err != nil {
+		return nil, err
+	}
+
+	toDocument, err := Load(ctx, szConfigManager, toConfigID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Diff{
+		Changes:      DiffDocuments(fromDocument, toDocument, tables...),
+		FromConfigID: fromConfigID,
+		ToConfigID:   toConfigID,
+	}, nil
+}
+
+/*
+Function DiffDocuments compares two parsed configurations.
+
+Input
+  - from: The older configuration.
+  - to: The newer configuration.
+  - tables: The tables to compare. If none are given, DefaultTables are compared.
+
+Output
+  - The changes, ordered by table (in the requested order) and then by row key.
+*/
+func DiffDocuments(from *configdoc.Document, to *configdoc.Document, tables ...string) []Change {
+	if len(tables) == 0 {
+		tables = DefaultTables
+	}
+
+	result := []Change{}
+	for _, table := range tables {
+		result = append(result, diffTable(table, from.Tables[table], to.Tables[table])...)
+	}
+
+	return result
+}
+
+/*
+Function DiffResponses compares two configurations parsed by response.SzConfigExport.
+
+Input
+  - from: The older configuration.
+  - to: The newer configuration.
+  - tables: The tables to compare. If none are given, DefaultTables are compared.
+
+Output
+  - The changes, ordered by table (in the requested order) and then by row key.
+*/
+func DiffResponses(
+	from *typedef.SzConfigExportResponse,
+	to *typedef.SzConfigExportResponse,
+	tables ...string,
+) ([]Change, error) {
+	fromDocument, err := configdoc.FromResponse(from)
+	if err != nil {
+		return nil, fmt.Errorf("confighistory cannot read older config: %w", err)
+	}
+
+	toDocument, err := configdoc.FromResponse(to)
+	if err != nil {
+		return nil, fmt.Errorf("confighistory cannot read newer config: %w", err)
+	}
+
+	return DiffDocuments(fromDocument, toDocument, tables...), nil
+}
+
+/*
+Function History returns the configuration registry.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: The config manager to query.
+
+Output
+  - The registered configurations, in registry order.
+*/
+func History(ctx context.Context, szConfigManager senzing.SzConfigManager) ([]Entry, error) {
+	registryJSON, err := szConfigManager.GetConfigRegistry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("confighistory cannot get config registry: %w", err)
+	}
+
+	registry := &configRegistry{} //exhaustruct:ignore
+
+	err = json.Unmarshal([]byte(registryJSON), registry)
+	if err != nil {
+		return nil, fmt.Errorf("confighistory cannot unmarshal %s: %w", registryJSON, err)
+	}
+
+	return registry.Configs, nil
+}
+
+/*
+Function Load exports a registered configuration and parses it with response.SzConfigExport.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: The config manager holding the configuration.
+  - configID: The configuration to load.
+
+Output
+  - The parsed configuration.
+*/
+func Load(ctx context.Context, szConfigManager senzing.SzConfigManager, configID int64) (*configdoc.Document, error) {
+	configDefinition, err := export(ctx, szConfigManager, configID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parse(ctx, configDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("confighistory cannot parse config ID %d: %w", configID, err)
+	}
+
+	return result, nil
+}
+
+/*
+Function Rollback makes an earlier configuration the default.
+
+Before replacing the default, Rollback checks that the target is registered, that it is not already the default,
+that the current default is the expected one (if given), and that no data sources would be removed
+(unless allowed). The default is replaced with SzConfigManager.ReplaceDefaultConfigID, so a concurrent change
+fails with szerror.ErrSzReplaceConflict instead of being overwritten.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: The config manager to update.
+  - toConfigID: The configuration to make the default.
+  - options: Safety check options.
+
+Output
+  - The differences from the current default to toConfigID, for review.
+*/
+func Rollback(
+	ctx context.Context,
+	szConfigManager senzing.SzConfigManager,
+	toConfigID int64,
+	options RollbackOptions,
+) (*Diff, error) {
+	currentConfigID, err := checkRollback(ctx, szConfigManager, toConfigID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := Compare(ctx, szConfigManager, currentConfigID, toConfigID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := diff.Filter(configdoc.TableDataSource, ChangeRemoved)
+	if len(removed) > 0 && !options.AllowDataSourceRemoval {
+		keys := make([]string, 0, len(removed))
+		for _, change := range removed {
+			keys = append(keys, change.Key)
+		}
+
+		return diff, fmt.Errorf("%w: %s", ErrDataSourcesRemoved, strings.Join(keys, "; "))
+	}
+
+	err = szConfigManager.ReplaceDefaultConfigID(ctx, currentConfigID, toConfigID)
+	if err != nil {
+		return diff, fmt.Errorf("confighistory cannot replace default config ID %d: %w", currentConfigID, err)
+	}
+
+	return diff, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Filter returns the changes to one table, optionally of one type.
+
+Input
+  - table: The table name.
+  - changeTypes: The change types to keep. If none are given, all types are kept.
+*/
+func (diff *Diff) Filter(table string, changeTypes ...ChangeType) []Change {
+	result := []Change{}
+
+	for _, change := range diff.Changes {
+		if change.Table == table && (len(changeTypes) == 0 || slices.Contains(changeTypes, change.Type)) {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+/*
+Method String returns a human-readable listing of the changes.
+*/
+func (diff *Diff) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Config %d -> %d: %d change(s)\n", diff.FromConfigID, diff.ToConfigID, len(diff.Changes))
+
+	for _, change := range diff.Changes {
+		builder.WriteString(change.String())
+	}
+
+	return builder.String()
+}
+
+/*
+Method String returns a human-readable description of the change.
+*/
+func (change Change) String() string {
+	switch change.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s %s\n", change.Table, change.Key)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s %s\n", change.Table, change.Key)
+	case ChangeModified:
+		var builder strings.Builder
+
+		fmt.Fprintf(&builder, "~ %s %s\n", change.Table, change.Key)
+
+		for _, field := range change.Fields {
+			fmt.Fprintf(&builder, "    %s: %v -> %v\n", field.Column, field.From, field.To)
+		}
+
+		return builder.String()
+	default:
+		return fmt.Sprintf("? %s %s (%s)\n", change.Table, change.Key, change.Type)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func checkRollback(
+	ctx context.Context,
+	szConfigManager senzing.SzConfigManager,
+	toConfigID int64,
+	options RollbackOptions,
+) (int64, error) {
+	history, err := History(ctx, szConfigManager)
+	if err != nil {
+		return 0, err
+	}
+
+	isRegistered := slices.ContainsFunc(history, func(entry Entry) bool {
+		return entry.ConfigID == toConfigID
+	})
+	if !isRegistered {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownConfigID, toConfigID)
+	}
+
+	currentConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("confighistory cannot get default config ID: %w", err)
+	}
+
+	if options.ExpectedCurrentConfigID != 0 && options.ExpectedCurrentConfigID != currentConfigID {
+		return 0, fmt.Errorf(
+			"%w: expected %d, found %d",
+			ErrCurrentMismatch,
+			options.ExpectedCurrentConfigID,
+			currentConfigID,
+		)
+	}
+
+	if currentConfigID == toConfigID {
+		return 0, fmt.Errorf("%w: %d", ErrAlreadyDefault, toConfigID)
+	}
+
+	return currentConfigID, nil
+}
+
+func diffRows(from configdoc.Row, to configdoc.Row) []FieldChange {
+	columns := make([]string, 0, len(from)+len(to))
+	for column := range from {
+		columns = append(columns, column)
+	}
+
+	for column := range to {
+		if _, isPresent := from[column]; !isPresent {
+			columns = append(columns, column)
+		}
+	}
+
+	slices.Sort(columns)
+
+	result := []FieldChange{}
+
+	for _, column := range columns {
+		if fmt.Sprint(from[column]) != fmt.Sprint(to[column]) {
+			result = append(result, FieldChange{Column: column, From: from[column], To: to[column]})
+		}
+	}
+
+	return result
+}
+
+func diffTable(table string, fromRows []configdoc.Row, toRows []configdoc.Row) []Change {
+	fromByKey := indexRows(table, fromRows)
+	toByKey := indexRows(table, toRows)
+	keys := make([]string, 0, len(fromByKey)+len(toByKey))
+
+	for key := range fromByKey {
+		keys = append(keys, key)
+	}
+
+	for key := range toByKey {
+		if _, isPresent := fromByKey[key]; !isPresent {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	result := []Change{}
+
+	for _, key := range keys {
+		fromRow, isInFrom := fromByKey[key]
+		toRow, isInTo := toByKey[key]
+
+		switch {
+		case !isInFrom:
+			result = append(result, Change{Fields: nil, Key: key, Table: table, Type: ChangeAdded})
+		case !isInTo:
+			result = append(result, Change{Fields: nil, Key: key, Table: table, Type: ChangeRemoved})
+		default:
+			if fields := diffRows(fromRow, toRow); len(fields) > 0 {
+				result = append(result, Change{Fields: fields, Key: key, Table: table, Type: ChangeModified})
+			}
+		}
+	}
+
+	return result
+}
+
+// Returns the JSON of a registered configuration, as returned by SzConfig.Export.
+func export(ctx context.Context, szConfigManager senzing.SzConfigManager, configID int64) (string, error) {
+	szConfig, err := szConfigManager.CreateConfigFromConfigID(ctx, configID)
+	if err != nil {
+		return "", fmt.Errorf("confighistory cannot load config ID %d: %w", configID, err)
+	}
+
+	result, err := szConfig.Export(ctx)
+	if err != nil {
+		return "", fmt.Errorf("confighistory cannot export config ID %d: %w", configID, err)
+	}
+
+	return result, nil
+}
+
+func indexRows(table string, rows []configdoc.Row) map[string]configdoc.Row {
+	result := make(map[string]configdoc.Row, len(rows))
+	for _, row := range rows {
+		result[configdoc.RowKey(table, row)] = row
+	}
+
+	return result
+}
+
+// Parses a configuration definition with response.SzConfigExport, so that every configuration is read the same way.
+func parse(ctx context.Context, configDefinition string) (*configdoc.Document, error) {
+	configExport, err := response.SzConfigExport(ctx, configDefinition)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return configdoc.FromResponse(configExport) //nolint:wrapcheck
+}
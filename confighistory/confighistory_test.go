@@ -0,0 +1,321 @@
+package confighistory_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/confighistory"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	baseConfigID     int64 = 100
+	extendedConfigID int64 = 200
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockConfig struct {
+	senzing.SzConfig
+
+	configDefinition string
+}
+
+func (config *mockConfig) Export(_ context.Context) (string, error) {
+	return config.configDefinition, nil
+}
+
+type mockConfigManager struct {
+	senzing.SzConfigManager
+
+	configs         map[int64]string
+	defaultConfigID int64
+	loads           int
+	nextConfigID    int64
+}
+
+func (configManager *mockConfigManager) CreateConfigFromConfigID(
+	_ context.Context,
+	configID int64,
+) (senzing.SzConfig, error) {
+	configManager.loads++
+
+	return &mockConfig{configDefinition: configManager.configs[configID]}, nil //exhaustruct:ignore
+}
+
+func (configManager *mockConfigManager) GetConfigRegistry(_ context.Context) (string, error) {
+	configs := []confighistory.Entry{}
+	for configID := range configManager.configs {
+		configs = append(configs, confighistory.Entry{Comments: "test", ConfigID: configID, CreateDate: ""})
+	}
+
+	result, err := json.Marshal(map[string]any{"CONFIGS": configs})
+
+	return string(result), err
+}
+
+func (configManager *mockConfigManager) GetDefaultConfigID(_ context.Context) (int64, error) {
+	return configManager.defaultConfigID, nil
+}
+
+func (configManager *mockConfigManager) ReplaceDefaultConfigID(
+	_ context.Context,
+	_ int64,
+	newDefaultConfigID int64,
+) error {
+	configManager.defaultConfigID = newDefaultConfigID
+
+	return nil
+}
+
+func (configManager *mockConfigManager) SetDefaultConfig(
+	_ context.Context,
+	configDefinition string,
+	_ string,
+) (int64, error) {
+	configManager.nextConfigID++
+	configManager.configs[configManager.nextConfigID] = configDefinition
+	configManager.defaultConfigID = configManager.nextConfigID
+
+	return configManager.nextConfigID, nil
+}
+
+func (configManager *mockConfigManager) SetDefaultConfigID(_ context.Context, configID int64) error {
+	configManager.defaultConfigID = configID
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func readConfigDefinition(t *testing.T) string {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", "SzConfigExportResponse.jsonl"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+	require.True(t, scanner.Scan())
+
+	return scanner.Text()
+}
+
+// The extended configuration adds a data source and raises a threshold.
+func newMockConfigManager(t *testing.T) *mockConfigManager {
+	t.Helper()
+
+	base := readConfigDefinition(t)
+	document, err := configdoc.Parse(base)
+	require.NoError(t, err)
+
+	document.Tables[configdoc.TableDataSource] = append(document.Tables[configdoc.TableDataSource], configdoc.Row{
+		"DSRC_CODE":       "CUSTOMERS",
+		"DSRC_DESC":       "Customers",
+		"DSRC_ID":         json.Number("1001"),
+		"RETENTION_LEVEL": "Remember",
+	})
+	index := document.Find(configdoc.TableComparisonReturn, "CFRTN_ID", 1)
+	document.Tables[configdoc.TableComparisonReturn][index]["CLOSE_SCORE"] = json.Number("92")
+
+	extended, err := document.JSON()
+	require.NoError(t, err)
+
+	return &mockConfigManager{
+		configs:         map[int64]string{baseConfigID: base, extendedConfigID: extended},
+		defaultConfigID: extendedConfigID,
+		nextConfigID:    extendedConfigID,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestCompare(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	diff, err := confighistory.Compare(ctx, configManager, baseConfigID, extendedConfigID)
+	require.NoError(test, err)
+	require.Len(test, diff.Changes, 2)
+
+	added := diff.Filter(configdoc.TableDataSource, confighistory.ChangeAdded)
+	require.Len(test, added, 1)
+	assert.Equal(test, "DSRC_CODE=CUSTOMERS", added[0].Key)
+
+	modified := diff.Filter(configdoc.TableComparisonReturn)
+	require.Len(test, modified, 1)
+	assert.Equal(test, confighistory.ChangeModified, modified[0].Type)
+	assert.Equal(test, "CLOSE_SCORE", modified[0].Fields[0].Column)
+	assert.Contains(test, diff.String(), "CLOSE_SCORE: 90 -> 92")
+}
+
+func TestCompare_Identical(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	diff, err := confighistory.Compare(ctx, configManager, baseConfigID, baseConfigID)
+	require.NoError(test, err)
+	assert.Empty(test, diff.Changes)
+}
+
+func TestDiffResponses(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+
+	from, err := response.SzConfigExport(ctx, configManager.configs[baseConfigID])
+	require.NoError(test, err)
+
+	to, err := response.SzConfigExport(ctx, configManager.configs[extendedConfigID])
+	require.NoError(test, err)
+
+	changes, err := confighistory.DiffResponses(from, to, configdoc.TableDataSource)
+	require.NoError(test, err)
+	require.Len(test, changes, 1)
+	assert.Equal(test, confighistory.ChangeAdded, changes[0].Type)
+	assert.Equal(test, "DSRC_CODE=CUSTOMERS", changes[0].Key)
+}
+
+func TestHistory(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	history, err := confighistory.History(ctx, configManager)
+	require.NoError(test, err)
+	assert.Len(test, history, 2)
+}
+
+func TestRollback_RefusesDataSourceRemoval(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	diff, err := confighistory.Rollback(ctx, configManager, baseConfigID, confighistory.RollbackOptions{}) //exhaustruct:ignore
+	require.ErrorIs(test, err, confighistory.ErrDataSourcesRemoved)
+	assert.NotNil(test, diff)
+	assert.Equal(test, extendedConfigID, configManager.defaultConfigID)
+}
+
+func TestRollback(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	_, err := confighistory.Rollback(ctx, configManager, baseConfigID, confighistory.RollbackOptions{
+		AllowDataSourceRemoval:  true,
+		ExpectedCurrentConfigID: extendedConfigID,
+	})
+	require.NoError(test, err)
+	assert.Equal(test, baseConfigID, configManager.defaultConfigID)
+}
+
+func TestRollback_SafetyChecks(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+
+	_, err := confighistory.Rollback(ctx, configManager, 999, confighistory.RollbackOptions{}) //exhaustruct:ignore
+	require.ErrorIs(test, err, confighistory.ErrUnknownConfigID)
+
+	_, err = confighistory.Rollback(ctx, configManager, extendedConfigID, confighistory.RollbackOptions{}) //exhaustruct:ignore
+	require.ErrorIs(test, err, confighistory.ErrAlreadyDefault)
+
+	_, err = confighistory.Rollback(ctx, configManager, baseConfigID, confighistory.RollbackOptions{ //exhaustruct:ignore
+		ExpectedCurrentConfigID: baseConfigID,
+	})
+	require.ErrorIs(test, err, confighistory.ErrCurrentMismatch)
+}
+
+func TestSetDefaultConfig(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+
+	document, err := configdoc.Parse(configManager.configs[baseConfigID])
+	require.NoError(test, err)
+
+	rows := document.Tables[configdoc.TableAttribute]
+	rows[0], rows[len(rows)-1] = rows[len(rows)-1], rows[0]
+	reordered, err := document.JSON()
+	require.NoError(test, err)
+
+	cache := confighistory.NewHashCache()
+	configID, isNew, err := confighistory.SetDefaultConfig(ctx, configManager, reordered, "reordered", cache)
+	require.NoError(test, err)
+	assert.False(test, isNew)
+	assert.Equal(test, baseConfigID, configID)
+	assert.Equal(test, baseConfigID, configManager.defaultConfigID)
+	assert.Len(test, configManager.configs, 2)
+
+	document.Tables[configdoc.TableDataSource][0]["DSRC_DESC"] = "Changed"
+	changed, err := document.JSON()
+	require.NoError(test, err)
+
+	loads := configManager.loads
+	configID, isNew, err = confighistory.SetDefaultConfig(ctx, configManager, changed, "changed", cache)
+	require.NoError(test, err)
+	assert.True(test, isNew)
+	assert.Equal(test, configID, configManager.defaultConfigID)
+	assert.Len(test, configManager.configs, 3)
+	assert.Equal(test, loads, configManager.loads, "registered configurations are hashed once")
+
+	_, isNew, err = confighistory.SetDefaultConfig(ctx, configManager, changed, "changed", cache)
+	require.NoError(test, err)
+	assert.False(test, isNew)
+	assert.Equal(test, loads, configManager.loads)
+}
+
+func TestSetDefaultConfig_UnmodeledColumn(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+
+	document, err := configdoc.Parse(configManager.configs[baseConfigID])
+	require.NoError(test, err)
+
+	// Not a column of CFG_DSRC in typedef.SzConfigExportResponse.
+	document.Tables[configdoc.TableDataSource][0]["DSRC_UNMODELED"] = "changed"
+	changed, err := document.JSON()
+	require.NoError(test, err)
+
+	configID, isNew, err := confighistory.SetDefaultConfig(ctx, configManager, changed, "changed", nil)
+	require.NoError(test, err)
+	assert.True(test, isNew)
+	assert.NotEqual(test, baseConfigID, configID)
+	assert.Equal(test, changed, configManager.configs[configID])
+}
+
+func TestFindByHash_NotFound(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	_, isFound, err := confighistory.FindByHash(ctx, configManager, "0000", nil)
+	require.NoError(test, err)
+	assert.False(test, isFound)
+}
@@ -0,0 +1,13 @@
+/*
+Package confighistory compares registered Senzing configurations and rolls back the default configuration.
+
+Configurations are loaded with SzConfigManager.CreateConfigFromConfigID and SzConfig.Export,
+parsed with response.SzConfigExport ([DiffResponses] compares responses that are already parsed),
+then compared table by table (data sources, feature types, attributes, thresholds, ...).
+Rows are matched by their identifying columns (see configdoc.TableKeys),
+so a change to a single column is reported as a modification rather than a removal and an addition.
+
+[SetDefaultConfig] registers a configuration only if no registered configuration has the same content hash.
+Pass the same [HashCache] to each call so that every registered configuration is loaded and hashed only once.
+*/
+package confighistory
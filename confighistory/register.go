@@ -0,0 +1,204 @@
+package confighistory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type HashCache struct remembers the content hash of each registered configuration,
+which does not change once registered. Use one HashCache per repository.
+Create one with [NewHashCache].
+*/
+type HashCache struct {
+	hashes map[int64]string
+	mutex  sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewHashCache creates an empty HashCache.
+*/
+func NewHashCache() *HashCache {
+	return &HashCache{hashes: map[int64]string{}, mutex: sync.Mutex{}}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function FindByHash returns the registered configuration with the given content hash.
+The default configuration is checked first, then the rest of the registry from newest to oldest.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: The config manager to search.
+  - hash: A hash returned by configdoc.Hash.
+  - cache: Hashes of configurations already loaded. If nil, every configuration is loaded and hashed.
+
+Output
+  - The matching configuration ID.
+  - True if a matching configuration was found.
+*/
+func FindByHash(
+	ctx context.Context,
+	szConfigManager senzing.SzConfigManager,
+	hash string,
+	cache *HashCache,
+) (int64, bool, error) {
+	history, err := History(ctx, szConfigManager)
+	if err != nil {
+		return 0, false, err
+	}
+
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("confighistory cannot get default config ID: %w", err)
+	}
+
+	configIDs := make([]int64, 0, len(history)+1)
+	if defaultConfigID != 0 {
+		configIDs = append(configIDs, defaultConfigID)
+	}
+
+	for _, entry := range slices.Backward(history) {
+		if entry.ConfigID != defaultConfigID {
+			configIDs = append(configIDs, entry.ConfigID)
+		}
+	}
+
+	for _, configID := range configIDs {
+		configHash, err := cache.hash(ctx, szConfigManager, configID)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if configHash == hash {
+			return configID, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+/*
+Function SetDefaultConfig registers a configuration and makes it the default,
+unless an identical configuration is already registered.
+
+Configurations are compared by the content hash of their exported JSON (see configdoc.Hash),
+so definitions that differ only in key order, whitespace, or row order are treated as identical.
+Every member and column counts, including those typedef.SzConfigExportResponse does not model.
+If an identical configuration is registered, it is made the default instead of registering a duplicate.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: The config manager to update.
+  - configDefinition: The Senzing configuration JSON document.
+  - configComment: A comment describing the configuration. Unused if an identical configuration is registered.
+  - cache: Hashes of configurations already loaded. May be nil.
+
+Output
+  - The identifier of the default configuration.
+  - True if a new configuration was registered.
+*/
+func SetDefaultConfig(
+	ctx context.Context,
+	szConfigManager senzing.SzConfigManager,
+	configDefinition string,
+	configComment string,
+	cache *HashCache,
+) (int64, bool, error) {
+	hash, err := configdoc.Hash(configDefinition)
+	if err != nil {
+		return 0, false, fmt.Errorf("confighistory cannot hash config definition: %w", err)
+	}
+
+	configID, isFound, err := FindByHash(ctx, szConfigManager, hash, cache)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !isFound {
+		configID, err = szConfigManager.SetDefaultConfig(ctx, configDefinition, configComment)
+		if err != nil {
+			return 0, false, fmt.Errorf("confighistory cannot set default config: %w", err)
+		}
+
+		cache.add(configID, hash)
+
+		return configID, true, nil
+	}
+
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("confighistory cannot get default config ID: %w", err)
+	}
+
+	if defaultConfigID != configID {
+		err = szConfigManager.SetDefaultConfigID(ctx, configID)
+		if err != nil {
+			return 0, false, fmt.Errorf("confighistory cannot set default config ID %d: %w", configID, err)
+		}
+	}
+
+	return configID, false, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (cache *HashCache) add(configID int64, hash string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.hashes[configID] = hash
+}
+
+// Returns the hash of a registered configuration, loading it only if it is not cached.
+func (cache *HashCache) hash(
+	ctx context.Context,
+	szConfigManager senzing.SzConfigManager,
+	configID int64,
+) (string, error) {
+	if cache != nil {
+		cache.mutex.Lock()
+		hash, isPresent := cache.hashes[configID]
+		cache.mutex.Unlock()
+
+		if isPresent {
+			return hash, nil
+		}
+	}
+
+	configDefinition, err := export(ctx, szConfigManager, configID)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := configdoc.Hash(configDefinition)
+	if err != nil {
+		return "", fmt.Errorf("confighistory cannot hash config ID %d: %w", configID, err)
+	}
+
+	cache.add(configID, hash)
+
+	return hash, nil
+}
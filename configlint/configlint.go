@@ -0,0 +1,449 @@
+package configlint
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/datasource"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Type Severity string classifies a [Finding].
+type Severity string
+
+/*
+Type Finding struct is one problem in a configuration definition.
+*/
+type Finding struct {
+	Check    string   `json:"check"`            // The check that found the problem, e.g. CheckDanglingReference.
+	Column   string   `json:"column,omitempty"` // The offending column, if any.
+	Key      string   `json:"key,omitempty"`    // The row identity (see configdoc.RowKey), if any.
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity"`
+	Table    string   `json:"table"`
+}
+
+/*
+Type Report struct is the result of linting a configuration definition.
+*/
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+type reference struct {
+	column       string
+	target       string
+	targetColumn string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Severities, from most to least severe.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Checks.
+const (
+	CheckDanglingReference     = "dangling-reference"
+	CheckDataSourceCode        = "data-source-code"
+	CheckDuplicateCode         = "duplicate-code"
+	CheckDuplicateID           = "duplicate-id"
+	CheckElementNotInFeature   = "element-not-in-feature"
+	CheckFeatureWithoutElement = "feature-without-element"
+	CheckMissingSection        = "missing-section"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Tables that every configuration definition must have.
+var RequiredTables = []string{
+	configdoc.TableAttribute,
+	configdoc.TableDataSource,
+	configdoc.TableFeatureBOM,
+	configdoc.TableFeatureClass,
+	configdoc.TableFeatureElement,
+	configdoc.TableFeatureType,
+	configdoc.TableGenericThreshold,
+	configdoc.TableGenericPlan,
+}
+
+// Columns holding a numeric ID of a row in another table. IDs of zero or less mean "none" or "all".
+var idReferences = []reference{
+	{column: "CFCALL_ID", target: "CFG_CFCALL", targetColumn: "CFCALL_ID"},
+	{column: "CFUNC_ID", target: "CFG_CFUNC", targetColumn: "CFUNC_ID"},
+	{column: "DFCALL_ID", target: "CFG_DFCALL", targetColumn: "DFCALL_ID"},
+	{column: "DFUNC_ID", target: "CFG_DFUNC", targetColumn: "DFUNC_ID"},
+	{column: "EFCALL_ID", target: "CFG_EFCALL", targetColumn: "EFCALL_ID"},
+	{column: "EFEAT_FTYPE_ID", target: configdoc.TableFeatureType, targetColumn: "FTYPE_ID"},
+	{column: "EFUNC_ID", target: "CFG_EFUNC", targetColumn: "EFUNC_ID"},
+	{column: "FCLASS_ID", target: configdoc.TableFeatureClass, targetColumn: "FCLASS_ID"},
+	{column: "FELEM_ID", target: configdoc.TableFeatureElement, targetColumn: "FELEM_ID"},
+	{column: "FTYPE_ID", target: configdoc.TableFeatureType, targetColumn: "FTYPE_ID"},
+	{column: "GPLAN_ID", target: configdoc.TableGenericPlan, targetColumn: "GPLAN_ID"},
+	{column: "RCLASS_ID", target: "CFG_RCLASS", targetColumn: "RCLASS_ID"},
+	{column: "RTYPE_ID", target: "CFG_RTYPE", targetColumn: "RTYPE_ID"},
+	{column: "SFUNC_ID", target: "CFG_SFUNC", targetColumn: "SFUNC_ID"},
+}
+
+// Columns that must be unique within their table.
+var idColumns = map[string]string{
+	configdoc.TableAttribute:      "ATTR_ID",
+	configdoc.TableDataSource:     "DSRC_ID",
+	configdoc.TableFeatureClass:   "FCLASS_ID",
+	configdoc.TableFeatureElement: "FELEM_ID",
+	configdoc.TableFeatureType:    "FTYPE_ID",
+	configdoc.TableGenericPlan:    "GPLAN_ID",
+}
+
+var severityRanks = map[Severity]int{
+	SeverityError:   3,
+	SeverityWarning: 2,
+	SeverityInfo:    1,
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Lint checks a configuration definition.
+
+Input
+  - configDefinition: The JSON returned by SzConfig.Export.
+
+Output
+  - The findings. An error is returned only if the definition cannot be parsed.
+*/
+func Lint(configDefinition string) (*Report, error) {
+	document, err := configdoc.Parse(configDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("configlint cannot parse config definition: %w", err)
+	}
+
+	return LintDocument(document), nil
+}
+
+/*
+Function LintDocument checks a parsed configuration definition.
+
+Input
+  - document: The configuration to check.
+
+Output
+  - The findings, ordered by table, then by check, then by key.
+*/
+func LintDocument(document *configdoc.Document) *Report {
+	findings := []Finding{}
+	findings = append(findings, checkMissingSections(document)...)
+	findings = append(findings, checkDuplicates(document)...)
+	findings = append(findings, checkReferences(document)...)
+	findings = append(findings, checkAttributes(document)...)
+	findings = append(findings, checkFeatureTypes(document)...)
+	findings = append(findings, checkDataSourceCodes(document)...)
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(a.Table, b.Table), cmp.Compare(a.Check, b.Check), cmp.Compare(a.Key, b.Key))
+	})
+
+	return &Report{Findings: findings}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method AtLeast returns the findings at or above a severity.
+
+Input
+  - minimum: The least severe severity to return.
+*/
+func (report *Report) AtLeast(minimum Severity) []Finding {
+	result := []Finding{}
+
+	for _, finding := range report.Findings {
+		if severityRanks[finding.Severity] >= severityRanks[minimum] {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+/*
+Method HasErrors returns true if any finding has SeverityError.
+*/
+func (report *Report) HasErrors() bool {
+	return len(report.AtLeast(SeverityError)) > 0
+}
+
+/*
+Method JSON returns the report as JSON, for use by other tools.
+*/
+func (report *Report) JSON() (string, error) {
+	result, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("configlint cannot marshal report: %w", err)
+	}
+
+	return string(result), nil
+}
+
+/*
+Method String returns one line per finding.
+*/
+func (report *Report) String() string {
+	var result strings.Builder
+
+	for _, finding := range report.Findings {
+		result.WriteString(finding.String())
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+/*
+Method String returns the finding as "severity table[key].column: message (check)".
+*/
+func (finding Finding) String() string {
+	location := finding.Table
+	if finding.Key != "" {
+		location += "[" + finding.Key + "]"
+	}
+
+	if finding.Column != "" {
+		location += "." + finding.Column
+	}
+
+	return fmt.Sprintf("%s %s: %s (%s)", finding.Severity, location, finding.Message, finding.Check)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func checkAttributes(document *configdoc.Document) []Finding {
+	result := []Finding{}
+	features := featureElements(document)
+
+	for _, row := range document.Tables[configdoc.TableAttribute] {
+		featureTypeCode := row.String("FTYPE_CODE")
+		elementCode := row.String("FELEM_CODE")
+
+		if featureTypeCode == "" {
+			continue
+		}
+
+		elements, isFeatureType := features[featureTypeCode]
+		if !isFeatureType {
+			result = append(result, newFinding(CheckDanglingReference, SeverityError, configdoc.TableAttribute, row,
+				"FTYPE_CODE", fmt.Sprintf("feature type %s does not exist", featureTypeCode)))
+
+			continue
+		}
+
+		if slices.Contains(configdoc.ImplicitFeatureElements, elementCode) {
+			continue
+		}
+
+		if document.Find(configdoc.TableFeatureElement, "FELEM_CODE", elementCode) < 0 {
+			result = append(result, newFinding(CheckDanglingReference, SeverityError, configdoc.TableAttribute, row,
+				"FELEM_CODE", fmt.Sprintf("feature element %q does not exist", elementCode)))
+
+			continue
+		}
+
+		if !slices.Contains(elements, elementCode) {
+			result = append(result, newFinding(CheckElementNotInFeature, SeverityWarning, configdoc.TableAttribute, row,
+				"FELEM_CODE", fmt.Sprintf("feature element %s is not in feature type %s", elementCode, featureTypeCode)))
+		}
+	}
+
+	return result
+}
+
+func checkDataSourceCodes(document *configdoc.Document) []Finding {
+	result := []Finding{}
+
+	for _, row := range document.Tables[configdoc.TableDataSource] {
+		code := row.String("DSRC_CODE")
+
+		normalized, err := datasource.Parse(code)
+		if err != nil {
+			result = append(result, newFinding(CheckDataSourceCode, SeverityError, configdoc.TableDataSource, row,
+				"DSRC_CODE", fmt.Sprintf("data source code %q is invalid", code)))
+		} else if normalized.String() != code {
+			result = append(result, newFinding(CheckDataSourceCode, SeverityError, configdoc.TableDataSource, row,
+				"DSRC_CODE", fmt.Sprintf("data source code %q is not normalized; expected %q", code, normalized)))
+		}
+	}
+
+	return result
+}
+
+func checkDuplicates(document *configdoc.Document) []Finding {
+	result := []Finding{}
+
+	for _, table := range document.TableNames() {
+		if _, isKeyed := configdoc.TableKeys[table]; isKeyed {
+			seen := map[string]bool{}
+
+			for _, row := range document.Tables[table] {
+				key := configdoc.RowKey(table, row)
+				if seen[key] {
+					result = append(result, newFinding(CheckDuplicateCode, SeverityError, table, row, "",
+						"row key is not unique"))
+				}
+
+				seen[key] = true
+			}
+		}
+
+		if column, hasID := idColumns[table]; hasID {
+			seen := map[int64]bool{}
+
+			for _, row := range document.Tables[table] {
+				rowID, isInt := row.Int(column)
+				if isInt && seen[rowID] {
+					result = append(result, newFinding(CheckDuplicateID, SeverityError, table, row, column,
+						fmt.Sprintf("%s %d is not unique", column, rowID)))
+				}
+
+				seen[rowID] = true
+			}
+		}
+	}
+
+	return result
+}
+
+func checkFeatureTypes(document *configdoc.Document) []Finding {
+	result := []Finding{}
+	features := featureElements(document)
+
+	for _, row := range document.Tables[configdoc.TableFeatureType] {
+		if len(features[row.String("FTYPE_CODE")]) == 0 {
+			result = append(result, newFinding(CheckFeatureWithoutElement, SeverityWarning, configdoc.TableFeatureType,
+				row, "", "feature type has no elements in "+configdoc.TableFeatureBOM))
+		}
+	}
+
+	return result
+}
+
+func checkMissingSections(document *configdoc.Document) []Finding {
+	result := []Finding{}
+
+	for _, table := range RequiredTables {
+		if _, isPresent := document.Tables[table]; !isPresent {
+			//exhaustruct:ignore
+			result = append(result, Finding{
+				Check:    CheckMissingSection,
+				Message:  "required table is missing",
+				Severity: SeverityError,
+				Table:    table,
+			})
+		}
+	}
+
+	return result
+}
+
+func checkReferences(document *configdoc.Document) []Finding {
+	result := []Finding{}
+	targets := map[string]map[int64]bool{}
+
+	for _, ref := range idReferences {
+		if _, isIndexed := targets[ref.target]; isIndexed {
+			continue
+		}
+
+		ids := map[int64]bool{}
+
+		for _, row := range document.Tables[ref.target] {
+			if rowID, isInt := row.Int(ref.targetColumn); isInt {
+				ids[rowID] = true
+			}
+		}
+
+		targets[ref.target] = ids
+	}
+
+	for _, table := range document.TableNames() {
+		for _, row := range document.Tables[table] {
+			for _, ref := range idReferences {
+				if table == ref.target && ref.column == ref.targetColumn {
+					continue
+				}
+
+				value, isInt := row.Int(ref.column)
+				if !isInt || value <= 0 || targets[ref.target][value] {
+					continue
+				}
+
+				result = append(result, newFinding(CheckDanglingReference, SeverityError, table, row, ref.column,
+					fmt.Sprintf("%s %d does not exist in %s", ref.targetColumn, value, ref.target)))
+			}
+		}
+	}
+
+	return result
+}
+
+// Feature type code to the codes of its elements in CFG_FBOM.
+func featureElements(document *configdoc.Document) map[string][]string {
+	featureTypeCodes := map[int64]string{}
+	result := map[string][]string{}
+
+	for _, row := range document.Tables[configdoc.TableFeatureType] {
+		if featureTypeID, isInt := row.Int("FTYPE_ID"); isInt {
+			featureTypeCodes[featureTypeID] = row.String("FTYPE_CODE")
+			result[row.String("FTYPE_CODE")] = []string{}
+		}
+	}
+
+	elementCodes := map[int64]string{}
+
+	for _, row := range document.Tables[configdoc.TableFeatureElement] {
+		if elementID, isInt := row.Int("FELEM_ID"); isInt {
+			elementCodes[elementID] = row.String("FELEM_CODE")
+		}
+	}
+
+	for _, row := range document.Tables[configdoc.TableFeatureBOM] {
+		featureTypeID, _ := row.Int("FTYPE_ID")
+		elementID, _ := row.Int("FELEM_ID")
+
+		if featureTypeCode, isPresent := featureTypeCodes[featureTypeID]; isPresent {
+			result[featureTypeCode] = append(result[featureTypeCode], elementCodes[elementID])
+		}
+	}
+
+	return result
+}
+
+func newFinding(check string, severity Severity, table string, row configdoc.Row, column, message string) Finding {
+	return Finding{
+		Check:    check,
+		Column:   column,
+		Key:      configdoc.RowKey(table, row),
+		Message:  message,
+		Severity: severity,
+		Table:    table,
+	}
+}
@@ -0,0 +1,198 @@
+package configlint_test
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/configdoc"
+	"github.com/senzing-garage/sz-sdk-go/configlint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func readConfigDefinitions(t *testing.T) []string {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", "SzConfigExportResponse.jsonl"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	result := []string{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return result
+}
+
+func readDocument(t *testing.T) *configdoc.Document {
+	t.Helper()
+
+	result, err := configdoc.Parse(readConfigDefinitions(t)[0])
+	require.NoError(t, err)
+
+	return result
+}
+
+func findChecks(findings []configlint.Finding, check string) []configlint.Finding {
+	result := []configlint.Finding{}
+
+	for _, finding := range findings {
+		if finding.Check == check {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLint_Fixtures(test *testing.T) {
+	test.Parallel()
+
+	configDefinitions := readConfigDefinitions(test)
+	require.Len(test, configDefinitions, 2)
+
+	report, err := configlint.Lint(configDefinitions[0])
+	require.NoError(test, err)
+	assert.Empty(test, report.Findings, report.String())
+
+	report, err = configlint.Lint(configDefinitions[1])
+	require.NoError(test, err)
+	assert.True(test, report.HasErrors())
+	assert.Len(test, findChecks(report.Findings, configlint.CheckMissingSection), len(configlint.RequiredTables))
+}
+
+func TestLint_Bad(test *testing.T) {
+	test.Parallel()
+
+	_, err := configlint.Lint("{")
+	require.Error(test, err)
+}
+
+func TestLintDocument_DanglingReferences(test *testing.T) {
+	test.Parallel()
+
+	document := readDocument(test)
+	index := document.Find(configdoc.TableFeatureElement, "FELEM_CODE", "ID_NUM")
+	document.Tables[configdoc.TableFeatureElement] = append(document.Tables[configdoc.TableFeatureElement][:index],
+		document.Tables[configdoc.TableFeatureElement][index+1:]...)
+	index = document.Find(configdoc.TableAttribute, "ATTR_CODE", "SSN_NUMBER")
+	document.Tables[configdoc.TableAttribute][index]["FTYPE_CODE"] = "NO_SUCH_FTYPE"
+
+	report := configlint.LintDocument(document)
+	require.True(test, report.HasErrors())
+
+	assert.Len(test, report.Findings, len(findChecks(report.Findings, configlint.CheckDanglingReference)))
+	assert.Contains(test, report.String(), "CFG_FBOM[")
+	assert.Contains(test, report.String(),
+		"CFG_ATTR[ATTR_CODE=SSN_NUMBER].FTYPE_CODE: feature type NO_SUCH_FTYPE does not exist")
+}
+
+func TestLintDocument_Duplicates(test *testing.T) {
+	test.Parallel()
+
+	document := readDocument(test)
+	duplicate := document.Tables[configdoc.TableDataSource][0].Clone()
+	duplicate["DSRC_ID"] = json.Number("999")
+	document.Tables[configdoc.TableDataSource] = append(document.Tables[configdoc.TableDataSource], duplicate)
+
+	duplicateID := document.Tables[configdoc.TableFeatureType][1].Clone()
+	duplicateID["FTYPE_CODE"] = "ANOTHER"
+	document.Tables[configdoc.TableFeatureType] = append(document.Tables[configdoc.TableFeatureType], duplicateID)
+
+	report := configlint.LintDocument(document)
+	codes := findChecks(report.Findings, configlint.CheckDuplicateCode)
+	require.Len(test, codes, 1)
+	assert.Equal(test, "DSRC_CODE=TEST", codes[0].Key)
+
+	ids := findChecks(report.Findings, configlint.CheckDuplicateID)
+	require.Len(test, ids, 1)
+	assert.Equal(test, configdoc.TableFeatureType, ids[0].Table)
+}
+
+func TestLintDocument_Warnings(test *testing.T) {
+	test.Parallel()
+
+	document := readDocument(test)
+	index := document.Find(configdoc.TableAttribute, "ATTR_CODE", "SSN_NUMBER")
+	document.Tables[configdoc.TableAttribute][index]["FELEM_CODE"] = "GIVEN_NAME"
+	document.Tables[configdoc.TableDataSource][0]["DSRC_CODE"] = "my source"
+
+	report := configlint.LintDocument(document)
+	assert.Len(test, report.AtLeast(configlint.SeverityError), 1)
+	assert.Len(test, report.AtLeast(configlint.SeverityWarning), 2)
+	assert.Len(test, findChecks(report.Findings, configlint.CheckElementNotInFeature), 1)
+	assert.Len(test, findChecks(report.Findings, configlint.CheckDataSourceCode), 1)
+}
+
+func TestLintDocument_DataSourceCode(test *testing.T) {
+	test.Parallel()
+
+	document := readDocument(test)
+	document.Tables[configdoc.TableDataSource][0]["DSRC_CODE"] = "customers"
+
+	report := configlint.LintDocument(document)
+	findings := findChecks(report.Findings, configlint.CheckDataSourceCode)
+	require.Len(test, findings, 1)
+	assert.Equal(test, configlint.SeverityError, findings[0].Severity)
+	assert.Contains(test, findings[0].Message, `expected "CUSTOMERS"`)
+
+	document.Tables[configdoc.TableDataSource][0]["DSRC_CODE"] = "my source"
+	findings = findChecks(configlint.LintDocument(document).Findings, configlint.CheckDataSourceCode)
+	require.Len(test, findings, 1)
+	assert.Contains(test, findings[0].Message, "is invalid")
+}
+
+func TestLintDocument_Order(test *testing.T) {
+	test.Parallel()
+
+	document := readDocument(test)
+	document.Tables[configdoc.TableDataSource][0]["DSRC_CODE"] = "z source"
+	document.Tables[configdoc.TableDataSource][1]["DSRC_CODE"] = "a source"
+	duplicate := document.Tables[configdoc.TableDataSource][0].Clone()
+	duplicate["DSRC_ID"] = json.Number("999")
+	document.Tables[configdoc.TableDataSource] = append(document.Tables[configdoc.TableDataSource], duplicate)
+
+	// Found in the order duplicate-code, then data-source-code for z, z, and a.
+	findings := configlint.LintDocument(document).Findings
+	require.Len(test, findings, 4)
+	assert.True(test, slices.IsSortedFunc(findings, func(a, b configlint.Finding) int {
+		return cmp.Or(cmp.Compare(a.Table, b.Table), cmp.Compare(a.Check, b.Check), cmp.Compare(a.Key, b.Key))
+	}), findings)
+}
+
+func TestReport_JSON(test *testing.T) {
+	test.Parallel()
+
+	report, err := configlint.Lint(`{"G2_CONFIG":{}}`)
+	require.NoError(test, err)
+
+	reportJSON, err := report.JSON()
+	require.NoError(test, err)
+
+	decoded := configlint.Report{} //exhaustruct:ignore
+	require.NoError(test, json.Unmarshal([]byte(reportJSON), &decoded))
+	assert.Equal(test, report.Findings, decoded.Findings)
+	assert.Contains(test, reportJSON, `"severity":"error"`)
+}
@@ -0,0 +1,16 @@
+/*
+Package configlint checks Senzing configuration definitions before they are registered.
+
+[Lint] reports problems that SzConfigManager.RegisterConfig would accept but that break or confuse the engine later:
+missing required tables (e.g. CFG_ATTR), duplicate codes and IDs, references to feature types,
+feature elements, functions, and plans that do not exist, attributes that map to an element outside their
+feature type, and data source codes that are not valid datasource.Code values.
+
+Each [Finding] has a [Severity]. A [Report] can be printed for people or marshaled to JSON for other tools:
+
+	report, err := configlint.Lint(configDefinition)
+	if err == nil && report.HasErrors() {
+		fmt.Print(report)
+	}
+*/
+package configlint
@@ -0,0 +1,384 @@
+package configmigrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"gopkg.in/yaml.v3"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type DesiredState struct is the desired-state document.
+*/
+type DesiredState struct {
+	// DataSources lists the data source codes that should exist.
+	DataSources []string `json:"dataSources" yaml:"dataSources"`
+
+	// Prune unregisters data sources that are not listed in DataSources, except SystemDataSources.
+	Prune bool `json:"prune" yaml:"prune"`
+}
+
+/*
+Type Plan struct lists the changes needed to reach the desired state.
+*/
+type Plan struct {
+	Add          []string // Data source codes to register.
+	BaseConfigID int64    // The default configuration ID the plan was computed against.
+	Remove       []string // Data source codes to unregister.
+}
+
+/*
+Type Result struct describes a completed migration.
+*/
+type Result struct {
+	Attempts    int   // Number of attempts, including retries after replace conflicts.
+	NewConfigID int64 // The new default configuration ID. Zero if nothing was registered.
+	Plan        Plan  // The plan that was applied (or, in dry-run mode, would have been applied).
+}
+
+/*
+Type Migrator struct applies a [DesiredState] using an SzConfigManager.
+*/
+type Migrator struct {
+	// ConfigManager is used to read and replace the default configuration.
+	ConfigManager senzing.SzConfigManager
+
+	// Comment, if set, generates the configuration comment. Nil means DefaultComment.
+	Comment func(plan Plan) string
+
+	// DryRun computes and prints the plan without changing the repository.
+	DryRun bool
+
+	// MaxRetries is the number of times to retry after ErrSzReplaceConflict. Zero means DefaultMaxRetries.
+	MaxRetries int
+
+	// Output receives the plan in dry-run mode. Nil means io.Discard.
+	Output io.Writer
+}
+
+type dataSourceRegistry struct {
+	DataSources []struct {
+		DsrcCode string `json:"DSRC_CODE"`
+	} `json:"DATA_SOURCES"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultMaxRetries is used when Migrator.MaxRetries is zero.
+const DefaultMaxRetries = 5
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrRetriesExhausted is returned when every attempt ended in a replace conflict.
+var ErrRetriesExhausted = errors.New("configmigrate retries exhausted")
+
+// SystemDataSources are the data sources of the default configuration. Prune never unregisters them.
+var SystemDataSources = []string{"SEARCH", "TEST"}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function DefaultComment returns the configuration comment for a plan.
+
+Input
+  - plan: The plan being applied.
+
+Output
+  - A comment such as "configmigrate: add [CUSTOMERS WATCHLIST]; remove []".
+*/
+func DefaultComment(plan Plan) string {
+	return fmt.Sprintf("configmigrate: add %v; remove %v", plan.Add, plan.Remove)
+}
+
+/*
+Function ParseDesiredState parses a YAML or JSON desired-state document.
+Data source codes are trimmed and converted to upper case.
+
+Input
+  - document: The YAML or JSON document.
+
+Output
+  - The desired state.
+*/
+func ParseDesiredState(document []byte) (*DesiredState, error) {
+	result := &DesiredState{} //exhaustruct:ignore
+
+	err := yaml.Unmarshal(document, result)
+	if err != nil {
+		return nil, fmt.Errorf("configmigrate cannot parse desired state: %w", err)
+	}
+
+	for index, dataSourceCode := range result.DataSources {
+		result.DataSources[index] = normalize(dataSourceCode)
+	}
+
+	return result, nil
+}
+
+/*
+Function Diff computes the plan that turns the existing data sources into the desired ones.
+
+Input
+  - desired: The desired state.
+  - existing: The data source codes that currently exist.
+
+Output
+  - The plan. Add and Remove are sorted. BaseConfigID is not set.
+*/
+func Diff(desired DesiredState, existing []string) Plan {
+	result := Plan{Add: []string{}, BaseConfigID: 0, Remove: []string{}}
+
+	existingSet := make(map[string]bool, len(existing))
+	for _, dataSourceCode := range existing {
+		existingSet[normalize(dataSourceCode)] = true
+	}
+
+	desiredSet := make(map[string]bool, len(desired.DataSources))
+	for _, dataSourceCode := range desired.DataSources {
+		dataSourceCode = normalize(dataSourceCode)
+		if !existingSet[dataSourceCode] && !desiredSet[dataSourceCode] {
+			result.Add = append(result.Add, dataSourceCode)
+		}
+
+		desiredSet[dataSourceCode] = true
+	}
+
+	if desired.Prune {
+		for dataSourceCode := range existingSet {
+			if !desiredSet[dataSourceCode] && !slices.Contains(SystemDataSources, dataSourceCode) {
+				result.Remove = append(result.Remove, dataSourceCode)
+			}
+		}
+	}
+
+	slices.Sort(result.Add)
+	slices.Sort(result.Remove)
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method IsEmpty returns true if the plan makes no changes.
+*/
+func (plan Plan) IsEmpty() bool {
+	return len(plan.Add) == 0 && len(plan.Remove) == 0
+}
+
+/*
+Method String returns a human-readable plan.
+*/
+func (plan Plan) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Plan against config ID %d:\n", plan.BaseConfigID)
+
+	if plan.IsEmpty() {
+		builder.WriteString("  no changes\n")
+	}
+
+	for _, dataSourceCode := range plan.Add {
+		fmt.Fprintf(&builder, "  + %s\n", dataSourceCode)
+	}
+
+	for _, dataSourceCode := range plan.Remove {
+		fmt.Fprintf(&builder, "  - %s\n", dataSourceCode)
+	}
+
+	return builder.String()
+}
+
+/*
+Method Migrate applies the desired state.
+
+Input
+  - ctx: A context to control lifecycle.
+  - desired: The desired state.
+
+Output
+  - The result of the migration.
+*/
+func (migrator *Migrator) Migrate(ctx context.Context, desired DesiredState) (Result, error) {
+	result := Result{Attempts: 0, NewConfigID: 0, Plan: Plan{}} //exhaustruct:ignore
+	maxRetries := migrator.MaxRetries
+
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
+
+	for result.Attempts <= maxRetries {
+		result.Attempts++
+
+		plan, newConfigID, err := migrator.attempt(ctx, desired)
+		result.Plan = plan
+		result.NewConfigID = newConfigID
+
+		if errors.Is(err, szerror.ErrSzReplaceConflict) {
+			continue
+		}
+
+		return result, err
+	}
+
+	return result, fmt.Errorf("%w after %d attempts", ErrRetriesExhausted, result.Attempts)
+}
+
+/*
+Method Plan computes the plan against the current default configuration without changing anything.
+
+Input
+  - ctx: A context to control lifecycle.
+  - desired: The desired state.
+
+Output
+  - The plan.
+*/
+func (migrator *Migrator) Plan(ctx context.Context, desired DesiredState) (Plan, error) {
+	_, plan, err := migrator.load(ctx, desired)
+
+	return plan, err
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (migrator *Migrator) attempt(ctx context.Context, desired DesiredState) (Plan, int64, error) {
+	szConfig, plan, err := migrator.load(ctx, desired)
+	if err != nil {
+		return plan, 0, err
+	}
+
+	if migrator.DryRun {
+		output := migrator.Output
+		if output == nil {
+			output = io.Discard
+		}
+
+		_, err = io.WriteString(output, plan.String())
+		if err != nil {
+			return plan, 0, fmt.Errorf("configmigrate cannot write plan: %w", err)
+		}
+
+		return plan, 0, nil
+	}
+
+	if plan.IsEmpty() {
+		return plan, 0, nil
+	}
+
+	newConfigID, err := migrator.apply(ctx, szConfig, plan)
+
+	return plan, newConfigID, err
+}
+
+func (migrator *Migrator) apply(ctx context.Context, szConfig senzing.SzConfig, plan Plan) (int64, error) {
+	for _, dataSourceCode := range plan.Add {
+		_, err := szConfig.RegisterDataSource(ctx, dataSourceCode)
+		if err != nil {
+			return 0, fmt.Errorf("configmigrate cannot register data source %s: %w", dataSourceCode, err)
+		}
+	}
+
+	for _, dataSourceCode := range plan.Remove {
+		_, err := szConfig.UnregisterDataSource(ctx, dataSourceCode)
+		if err != nil {
+			return 0, fmt.Errorf("configmigrate cannot unregister data source %s: %w", dataSourceCode, err)
+		}
+	}
+
+	configDefinition, err := szConfig.Export(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("configmigrate cannot export config: %w", err)
+	}
+
+	comment := DefaultComment
+	if migrator.Comment != nil {
+		comment = migrator.Comment
+	}
+
+	newConfigID, err := migrator.ConfigManager.RegisterConfig(ctx, configDefinition, comment(plan))
+	if err != nil {
+		return 0, fmt.Errorf("configmigrate cannot register config: %w", err)
+	}
+
+	err = migrator.ConfigManager.ReplaceDefaultConfigID(ctx, plan.BaseConfigID, newConfigID)
+	if err != nil {
+		return newConfigID, fmt.Errorf("configmigrate cannot replace default config ID %d: %w", plan.BaseConfigID, err)
+	}
+
+	return newConfigID, nil
+}
+
+func (migrator *Migrator) load(ctx context.Context, desired DesiredState) (senzing.SzConfig, Plan, error) {
+	var plan Plan
+
+	baseConfigID, err := migrator.ConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return nil, plan, fmt.Errorf("configmigrate cannot get default config ID: %w", err)
+	}
+
+	szConfig, err := migrator.ConfigManager.CreateConfigFromConfigID(ctx, baseConfigID)
+	if err != nil {
+		return nil, plan, fmt.Errorf("configmigrate cannot load config ID %d: %w", baseConfigID, err)
+	}
+
+	existing, err := dataSourceCodes(ctx, szConfig)
+	if err != nil {
+		return nil, plan, err
+	}
+
+	plan = Diff(desired, existing)
+	plan.BaseConfigID = baseConfigID
+
+	return szConfig, plan, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func dataSourceCodes(ctx context.Context, szConfig senzing.SzConfig) ([]string, error) {
+	registryJSON, err := szConfig.GetDataSourceRegistry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("configmigrate cannot get data source registry: %w", err)
+	}
+
+	registry := &dataSourceRegistry{} //exhaustruct:ignore
+
+	err = json.Unmarshal([]byte(registryJSON), registry)
+	if err != nil {
+		return nil, fmt.Errorf("configmigrate cannot unmarshal %s: %w", registryJSON, err)
+	}
+
+	result := make([]string, 0, len(registry.DataSources))
+	for _, dataSource := range registry.DataSources {
+		result = append(result, dataSource.DsrcCode)
+	}
+
+	return result, nil
+}
+
+func normalize(dataSourceCode string) string {
+	return strings.ToUpper(strings.TrimSpace(dataSourceCode))
+}
@@ -0,0 +1,267 @@
+package configmigrate_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/configmigrate"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockConfig struct {
+	senzing.SzConfig
+
+	dataSources []string
+}
+
+func (config *mockConfig) Export(_ context.Context) (string, error) {
+	result, err := json.Marshal(config.dataSources)
+
+	return string(result), err
+}
+
+func (config *mockConfig) GetDataSourceRegistry(_ context.Context) (string, error) {
+	registry := []map[string]any{}
+	for index, dataSourceCode := range config.dataSources {
+		registry = append(registry, map[string]any{"DSRC_CODE": dataSourceCode, "DSRC_ID": index + 1})
+	}
+
+	result, err := json.Marshal(map[string]any{"DATA_SOURCES": registry})
+
+	return string(result), err
+}
+
+func (config *mockConfig) RegisterDataSource(_ context.Context, dataSourceCode string) (string, error) {
+	config.dataSources = append(config.dataSources, dataSourceCode)
+
+	return "{}", nil
+}
+
+func (config *mockConfig) UnregisterDataSource(_ context.Context, dataSourceCode string) (string, error) {
+	config.dataSources = slices.DeleteFunc(config.dataSources, func(code string) bool {
+		return code == dataSourceCode
+	})
+
+	return "{}", nil
+}
+
+type mockConfigManager struct {
+	senzing.SzConfigManager
+
+	comments        map[int64]string
+	configs         map[int64][]string
+	conflicts       int
+	defaultConfigID int64
+}
+
+func newMockConfigManager(dataSources ...string) *mockConfigManager {
+	return &mockConfigManager{
+		comments:        map[int64]string{},
+		configs:         map[int64][]string{1: dataSources},
+		defaultConfigID: 1,
+	} //exhaustruct:ignore
+}
+
+func (configManager *mockConfigManager) CreateConfigFromConfigID(
+	_ context.Context,
+	configID int64,
+) (senzing.SzConfig, error) {
+	return &mockConfig{dataSources: slices.Clone(configManager.configs[configID])}, nil //exhaustruct:ignore
+}
+
+func (configManager *mockConfigManager) GetDefaultConfigID(_ context.Context) (int64, error) {
+	return configManager.defaultConfigID, nil
+}
+
+func (configManager *mockConfigManager) RegisterConfig(
+	_ context.Context,
+	configDefinition string,
+	configComment string,
+) (int64, error) {
+	dataSources := []string{}
+
+	err := json.Unmarshal([]byte(configDefinition), &dataSources)
+	if err != nil {
+		return 0, err
+	}
+
+	configID := int64(len(configManager.configs) + 1)
+	configManager.configs[configID] = dataSources
+	configManager.comments[configID] = configComment
+
+	return configID, nil
+}
+
+func (configManager *mockConfigManager) ReplaceDefaultConfigID(
+	_ context.Context,
+	currentDefaultConfigID int64,
+	newDefaultConfigID int64,
+) error {
+	if configManager.conflicts > 0 {
+		configManager.conflicts--
+		configManager.defaultConfigID = currentDefaultConfigID
+
+		return szerror.New(7245, "Current configuration ID does not match specified data")
+	}
+
+	configManager.defaultConfigID = newDefaultConfigID
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParseDesiredState_YAML(test *testing.T) {
+	test.Parallel()
+
+	document := "dataSources:\n  - customers\n  - ' WATCHLIST '\nprune: true\n"
+	actual, err := configmigrate.ParseDesiredState([]byte(document))
+	require.NoError(test, err)
+	assert.Equal(test, []string{"CUSTOMERS", "WATCHLIST"}, actual.DataSources)
+	assert.True(test, actual.Prune)
+}
+
+func TestParseDesiredState_JSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := configmigrate.ParseDesiredState([]byte(`{"dataSources": ["REFERENCE"]}`))
+	require.NoError(test, err)
+	assert.Equal(test, []string{"REFERENCE"}, actual.DataSources)
+	assert.False(test, actual.Prune)
+}
+
+func TestParseDesiredState_Bad(test *testing.T) {
+	test.Parallel()
+
+	_, err := configmigrate.ParseDesiredState([]byte(`{"dataSources": [`))
+	require.Error(test, err)
+}
+
+func TestDiff(test *testing.T) {
+	test.Parallel()
+
+	desired := configmigrate.DesiredState{DataSources: []string{"WATCHLIST", "CUSTOMERS", "TEST"}, Prune: false}
+	actual := configmigrate.Diff(desired, []string{"TEST", "SEARCH"})
+	assert.Equal(test, []string{"CUSTOMERS", "WATCHLIST"}, actual.Add)
+	assert.Empty(test, actual.Remove)
+
+	desired.Prune = true
+	actual = configmigrate.Diff(desired, []string{"TEST", "SEARCH", "LEGACY"})
+	assert.Equal(test, []string{"LEGACY"}, actual.Remove)
+}
+
+func TestDiff_PruneSystemDataSources(test *testing.T) {
+	test.Parallel()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", "SzConfigExportResponse.jsonl"))
+	require.NoError(test, err)
+
+	defer func() {
+		require.NoError(test, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+	require.True(test, scanner.Scan())
+
+	configDefinition := struct {
+		G2Config struct {
+			CfgDsrc []struct {
+				DsrcCode string `json:"DSRC_CODE"`
+			} `json:"CFG_DSRC"`
+		} `json:"G2_CONFIG"`
+	}{}
+	require.NoError(test, json.Unmarshal(scanner.Bytes(), &configDefinition))
+
+	existing := []string{}
+	for _, dataSource := range configDefinition.G2Config.CfgDsrc {
+		existing = append(existing, dataSource.DsrcCode)
+	}
+
+	require.NotEmpty(test, existing)
+
+	actual := configmigrate.Diff(configmigrate.DesiredState{DataSources: []string{"CUSTOMERS"}, Prune: true}, existing)
+	assert.Equal(test, []string{"CUSTOMERS"}, actual.Add)
+	assert.Empty(test, actual.Remove)
+}
+
+func TestMigrator_Migrate(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager("TEST", "SEARCH", "LEGACY")
+	migrator := &configmigrate.Migrator{ConfigManager: configManager} //exhaustruct:ignore
+	desired := configmigrate.DesiredState{DataSources: []string{"CUSTOMERS", "TEST"}, Prune: true}
+
+	result, err := migrator.Migrate(ctx, desired)
+	require.NoError(test, err)
+	assert.Equal(test, 1, result.Attempts)
+	assert.Equal(test, result.NewConfigID, configManager.defaultConfigID)
+	assert.ElementsMatch(test, []string{"TEST", "SEARCH", "CUSTOMERS"}, configManager.configs[result.NewConfigID])
+	assert.Equal(test, "configmigrate: add [CUSTOMERS]; remove [LEGACY]", configManager.comments[result.NewConfigID])
+
+	result, err = migrator.Migrate(ctx, desired)
+	require.NoError(test, err)
+	assert.True(test, result.Plan.IsEmpty())
+	assert.Zero(test, result.NewConfigID)
+}
+
+func TestMigrator_Migrate_RetryOnConflict(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager("TEST")
+	configManager.conflicts = 2
+	migrator := &configmigrate.Migrator{ConfigManager: configManager} //exhaustruct:ignore
+
+	result, err := migrator.Migrate(ctx, configmigrate.DesiredState{DataSources: []string{"CUSTOMERS"}, Prune: false})
+	require.NoError(test, err)
+	assert.Equal(test, 3, result.Attempts)
+	assert.Equal(test, result.NewConfigID, configManager.defaultConfigID)
+}
+
+func TestMigrator_Migrate_RetriesExhausted(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager("TEST")
+	configManager.conflicts = 10
+	migrator := &configmigrate.Migrator{ConfigManager: configManager, MaxRetries: 1} //exhaustruct:ignore
+
+	_, err := migrator.Migrate(ctx, configmigrate.DesiredState{DataSources: []string{"CUSTOMERS"}, Prune: false})
+	require.ErrorIs(test, err, configmigrate.ErrRetriesExhausted)
+	assert.Equal(test, int64(1), configManager.defaultConfigID)
+}
+
+func TestMigrator_Migrate_DryRun(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	output := &bytes.Buffer{}
+	configManager := newMockConfigManager("TEST", "SEARCH", "LEGACY")
+	migrator := &configmigrate.Migrator{ConfigManager: configManager, DryRun: true, Output: output} //exhaustruct:ignore
+
+	result, err := migrator.Migrate(ctx, configmigrate.DesiredState{DataSources: []string{"CUSTOMERS"}, Prune: true})
+	require.NoError(test, err)
+	assert.Zero(test, result.NewConfigID)
+	assert.Len(test, configManager.configs, 1)
+	assert.Contains(test, output.String(), "+ CUSTOMERS")
+	assert.Contains(test, output.String(), "- LEGACY")
+	assert.NotContains(test, output.String(), "- SEARCH")
+	assert.NotContains(test, output.String(), "- TEST")
+}
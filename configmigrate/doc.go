@@ -0,0 +1,17 @@
+/*
+Package configmigrate applies a declarative list of data sources to the Senzing configuration.
+
+A desired-state document (YAML or JSON) lists the data sources that should exist:
+
+	dataSources:
+	  - CUSTOMERS
+	  - WATCHLIST
+	prune: false
+
+A [Migrator] compares the document with the data sources of the current default configuration,
+registers the missing data sources (and, with prune, unregisters the unlisted ones other than
+the system data sources TEST and SEARCH),
+registers the resulting configuration, and makes it the default with SzConfigManager.ReplaceDefaultConfigID.
+If another process changes the default configuration at the same time, the migration is recomputed and retried.
+*/
+package configmigrate
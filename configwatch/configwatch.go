@@ -0,0 +1,361 @@
+package configwatch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/middleware"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Type EventType int identifies the kind of [Event].
+type EventType int
+
+/*
+Type Event struct describes something the [Watcher] observed or did.
+*/
+type Event struct {
+	ActiveConfigID  int64     // The engine's active configuration ID when the event occurred.
+	DefaultConfigID int64     // The repository's default configuration ID when the event occurred.
+	Err             error     // The error, for EventError.
+	Time            time.Time // When the event occurred.
+	Type            EventType
+}
+
+/*
+Type WatcherConfig struct configures a [Watcher].
+*/
+type WatcherConfig struct {
+	// ConfigManager is used to read the default configuration ID.
+	ConfigManager senzing.SzConfigManager
+
+	// Engine is used to read the active configuration ID.
+	Engine senzing.SzEngine
+
+	// Factory is reinitialized when the configuration IDs differ.
+	Factory senzing.SzAbstractFactory
+
+	// OnEvent, if set, is called for each event. It is called synchronously and should not block.
+	OnEvent func(event Event)
+
+	// PollInterval is the time between checks in Run. Zero means DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+/*
+Type Watcher struct detects configuration drift and reinitializes Senzing.
+*/
+type Watcher struct {
+	config   WatcherConfig
+	checking sync.Mutex
+	gate     gate
+	notify   chan struct{}
+}
+
+// Lets calls proceed concurrently, except while reinitializing. Unlike sync.RWMutex, waiting stops when ctx is done.
+type gate struct {
+	drained  chan struct{} // Closed when the last in-flight call leaves while reinitializing.
+	inFlight int
+	mutex    sync.Mutex
+	reopened chan struct{} // Closed when reinitialization ends. Nil when not reinitializing.
+}
+
+type reinitializingKey struct{}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Event types.
+const (
+	EventDriftDetected EventType = iota // The active and default configuration IDs differ.
+	EventReinitialized                  // Reinitialize succeeded; ActiveConfigID is the new configuration ID.
+	EventError                          // A check or reinitialization failed.
+)
+
+// DefaultPollInterval is used when WatcherConfig.PollInterval is zero.
+const DefaultPollInterval = time.Minute
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewWatcher creates a Watcher.
+
+Input
+  - config: The Senzing objects to watch and reinitialize, the poll interval, and the event callback.
+
+Output
+  - A Watcher. Call Run to poll, or Check to check once.
+*/
+func NewWatcher(config WatcherConfig) *Watcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	return &Watcher{
+		config:   config,
+		checking: sync.Mutex{},
+		gate:     gate{drained: nil, inFlight: 0, mutex: sync.Mutex{}, reopened: nil},
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Check compares the active and default configuration IDs once
+and reinitializes if they differ.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - True if Senzing was reinitialized.
+*/
+func (watcher *Watcher) Check(ctx context.Context) (bool, error) {
+	watcher.checking.Lock()
+	defer watcher.checking.Unlock()
+
+	activeConfigID, defaultConfigID, err := watcher.configIDs(ctx)
+	if err != nil {
+		return false, watcher.fail(err, activeConfigID, defaultConfigID)
+	}
+
+	if activeConfigID == defaultConfigID {
+		return false, nil
+	}
+
+	watcher.emit(Event{
+		ActiveConfigID:  activeConfigID,
+		DefaultConfigID: defaultConfigID,
+		Err:             nil,
+		Time:            time.Now(),
+		Type:            EventDriftDetected,
+	})
+
+	err = watcher.reinitialize(ctx, defaultConfigID)
+	if err != nil {
+		return false, watcher.fail(err, activeConfigID, defaultConfigID)
+	}
+
+	watcher.emit(Event{
+		ActiveConfigID:  defaultConfigID,
+		DefaultConfigID: defaultConfigID,
+		Err:             nil,
+		Time:            time.Now(),
+		Type:            EventReinitialized,
+	})
+
+	return true, nil
+}
+
+/*
+Method Interceptor returns an interceptor that lets calls proceed concurrently,
+except while the watcher is reinitializing.
+Reinitialization waits for in-flight calls to finish, and new calls wait for reinitialization to finish,
+or until their ctx is done.
+
+The engines of WatcherConfig.Factory may be wrapped with this interceptor: calls made with the ctx
+passed to SzAbstractFactory.Reinitialize, or a ctx derived from it, are not held.
+A factory that calls its engines with another ctx while reinitializing waits until the ctx given to Check is done.
+*/
+func (watcher *Watcher) Interceptor() middleware.Interceptor {
+	return func(ctx context.Context, call middleware.Call) (any, error) {
+		if ctx.Value(reinitializingKey{}) != nil {
+			return call.Invoke(ctx)
+		}
+
+		err := watcher.gate.enter(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		defer watcher.gate.leave()
+
+		return call.Invoke(ctx)
+	}
+}
+
+/*
+Method Notify asks a running watcher to check immediately,
+e.g. after this process has changed the default configuration.
+It does not block.
+*/
+func (watcher *Watcher) Notify() {
+	select {
+	case watcher.notify <- struct{}{}:
+	default:
+	}
+}
+
+/*
+Method Run checks on every poll interval and on every Notify until ctx is done.
+Errors are reported as EventError events; Run only returns when ctx is done.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (watcher *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(watcher.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = watcher.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("configwatch.Run stopped: %w", ctx.Err())
+		case <-ticker.C:
+		case <-watcher.notify:
+		}
+	}
+}
+
+/*
+Method String returns the name of the event type.
+*/
+func (eventType EventType) String() string {
+	switch eventType {
+	case EventDriftDetected:
+		return "drift-detected"
+	case EventReinitialized:
+		return "reinitialized"
+	case EventError:
+		return "error"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(eventType))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (watcher *Watcher) configIDs(ctx context.Context) (int64, int64, error) {
+	activeConfigID, err := watcher.config.Engine.GetActiveConfigID(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("configwatch cannot get active config ID: %w", err)
+	}
+
+	defaultConfigID, err := watcher.config.ConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return activeConfigID, 0, fmt.Errorf("configwatch cannot get default config ID: %w", err)
+	}
+
+	return activeConfigID, defaultConfigID, nil
+}
+
+func (watcher *Watcher) emit(event Event) {
+	if watcher.config.OnEvent != nil {
+		watcher.config.OnEvent(event)
+	}
+}
+
+func (watcher *Watcher) fail(err error, activeConfigID int64, defaultConfigID int64) error {
+	watcher.emit(Event{
+		ActiveConfigID:  activeConfigID,
+		DefaultConfigID: defaultConfigID,
+		Err:             err,
+		Time:            time.Now(),
+		Type:            EventError,
+	})
+
+	return err
+}
+
+func (watcher *Watcher) reinitialize(ctx context.Context, configID int64) error {
+	err := watcher.gate.close(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer watcher.gate.open()
+
+	err = watcher.config.Factory.Reinitialize(context.WithValue(ctx, reinitializingKey{}, true), configID)
+	if err != nil {
+		return fmt.Errorf("configwatch cannot reinitialize with config ID %d: %w", configID, err)
+	}
+
+	return nil
+}
+
+// Waits until all in-flight calls leave, and holds new calls until open.
+func (gate *gate) close(ctx context.Context) error {
+	gate.mutex.Lock()
+	gate.reopened = make(chan struct{})
+
+	var drained chan struct{}
+
+	if gate.inFlight > 0 {
+		gate.drained = make(chan struct{})
+		drained = gate.drained
+	}
+
+	gate.mutex.Unlock()
+
+	if drained == nil {
+		return nil
+	}
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		gate.open()
+
+		return fmt.Errorf("configwatch cannot wait for in-flight calls: %w", ctx.Err())
+	}
+}
+
+func (gate *gate) enter(ctx context.Context) error {
+	for {
+		gate.mutex.Lock()
+
+		reopened := gate.reopened
+		if reopened == nil {
+			gate.inFlight++
+			gate.mutex.Unlock()
+
+			return nil
+		}
+
+		gate.mutex.Unlock()
+
+		select {
+		case <-reopened:
+		case <-ctx.Done():
+			return fmt.Errorf("configwatch cannot wait for reinitialization: %w", ctx.Err())
+		}
+	}
+}
+
+func (gate *gate) leave() {
+	gate.mutex.Lock()
+	defer gate.mutex.Unlock()
+
+	gate.inFlight--
+
+	if gate.inFlight == 0 && gate.drained != nil {
+		close(gate.drained)
+		gate.drained = nil
+	}
+}
+
+func (gate *gate) open() {
+	gate.mutex.Lock()
+	defer gate.mutex.Unlock()
+
+	close(gate.reopened)
+	gate.reopened = nil
+	gate.drained = nil
+}
@@ -0,0 +1,258 @@
+package configwatch_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/configwatch"
+	"github.com/senzing-garage/sz-sdk-go/middleware"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	callDelay = 50 * time.Millisecond
+	waitFor   = 2 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type repository struct {
+	activeConfigID  atomic.Int64
+	defaultConfigID atomic.Int64
+	inFlight        atomic.Int64
+	reinitInFlight  atomic.Int64
+	reinitCount     atomic.Int64
+}
+
+type mockEngine struct {
+	senzing.SzEngine
+
+	repository *repository
+}
+
+func (engine *mockEngine) AddRecord(_ context.Context, _, _, _ string, _ int64) (string, error) {
+	engine.repository.inFlight.Add(1)
+	defer engine.repository.inFlight.Add(-1)
+
+	time.Sleep(callDelay)
+
+	return "{}", nil
+}
+
+func (engine *mockEngine) GetActiveConfigID(_ context.Context) (int64, error) {
+	return engine.repository.activeConfigID.Load(), nil
+}
+
+type mockConfigManager struct {
+	senzing.SzConfigManager
+
+	repository *repository
+}
+
+func (configManager *mockConfigManager) GetDefaultConfigID(_ context.Context) (int64, error) {
+	return configManager.repository.defaultConfigID.Load(), nil
+}
+
+type mockFactory struct {
+	senzing.SzAbstractFactory
+
+	onReinitialize func(ctx context.Context)
+	repository     *repository
+}
+
+func (factory *mockFactory) Reinitialize(ctx context.Context, configID int64) error {
+	if factory.onReinitialize != nil {
+		factory.onReinitialize(ctx)
+	}
+
+	factory.repository.reinitInFlight.Store(factory.repository.inFlight.Load())
+	factory.repository.reinitCount.Add(1)
+	factory.repository.activeConfigID.Store(configID)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newWatcher(repo *repository, onEvent func(configwatch.Event)) *configwatch.Watcher {
+	return newWatcherWithFactory(repo, &mockFactory{repository: repo}, onEvent) //exhaustruct:ignore
+}
+
+func newWatcherWithFactory(
+	repo *repository,
+	factory *mockFactory,
+	onEvent func(configwatch.Event),
+) *configwatch.Watcher {
+	return configwatch.NewWatcher(configwatch.WatcherConfig{
+		ConfigManager: &mockConfigManager{repository: repo}, //exhaustruct:ignore
+		Engine:        &mockEngine{repository: repo},        //exhaustruct:ignore
+		Factory:       factory,
+		OnEvent:       onEvent,
+		PollInterval:  time.Hour,
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestWatcher_Check_NoDrift(test *testing.T) {
+	test.Parallel()
+
+	repo := &repository{} //exhaustruct:ignore
+	repo.activeConfigID.Store(1)
+	repo.defaultConfigID.Store(1)
+
+	watcher := newWatcher(repo, nil)
+	reinitialized, err := watcher.Check(context.TODO())
+	require.NoError(test, err)
+	assert.False(test, reinitialized)
+	assert.Equal(test, int64(0), repo.reinitCount.Load())
+}
+
+func TestWatcher_Check_Drift(test *testing.T) {
+	test.Parallel()
+
+	repo := &repository{} //exhaustruct:ignore
+	repo.activeConfigID.Store(1)
+	repo.defaultConfigID.Store(2)
+
+	events := []configwatch.EventType{}
+	watcher := newWatcher(repo, func(event configwatch.Event) {
+		events = append(events, event.Type)
+	})
+	reinitialized, err := watcher.Check(context.TODO())
+	require.NoError(test, err)
+	assert.True(test, reinitialized)
+	assert.Equal(test, int64(2), repo.activeConfigID.Load())
+	assert.Equal(test, []configwatch.EventType{configwatch.EventDriftDetected, configwatch.EventReinitialized}, events)
+}
+
+func TestWatcher_DrainsInFlightCalls(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	repo := &repository{} //exhaustruct:ignore
+	repo.activeConfigID.Store(1)
+	repo.defaultConfigID.Store(2)
+
+	watcher := newWatcher(repo, nil)
+	engine := middleware.NewEngine(&mockEngine{repository: repo}, watcher.Interceptor()) //exhaustruct:ignore
+
+	var waitGroup sync.WaitGroup
+
+	for range 3 {
+		waitGroup.Go(func() {
+			_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+			assert.NoError(test, err)
+		})
+	}
+
+	require.Eventually(test, func() bool {
+		return repo.inFlight.Load() > 0
+	}, waitFor, time.Millisecond)
+
+	_, err := watcher.Check(ctx)
+	require.NoError(test, err)
+	waitGroup.Wait()
+	assert.Equal(test, int64(0), repo.reinitInFlight.Load())
+}
+
+func TestWatcher_ReentrantReinitialize(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	repo := &repository{} //exhaustruct:ignore
+	repo.activeConfigID.Store(1)
+	repo.defaultConfigID.Store(2)
+
+	var engine senzing.SzEngine
+
+	factory := &mockFactory{ //exhaustruct:ignore
+		onReinitialize: func(ctx context.Context) {
+			_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+			assert.NoError(test, err)
+		},
+		repository: repo,
+	}
+	watcher := newWatcherWithFactory(repo, factory, nil)
+	engine = middleware.NewEngine(&mockEngine{repository: repo}, watcher.Interceptor()) //exhaustruct:ignore
+
+	reinitialized, err := watcher.Check(ctx)
+	require.NoError(test, err)
+	assert.True(test, reinitialized)
+}
+
+func TestWatcher_WaitRespectsContext(test *testing.T) {
+	test.Parallel()
+
+	repo := &repository{} //exhaustruct:ignore
+	repo.activeConfigID.Store(1)
+	repo.defaultConfigID.Store(2)
+
+	isReinitializing := make(chan struct{})
+	release := make(chan struct{})
+	factory := &mockFactory{ //exhaustruct:ignore
+		onReinitialize: func(_ context.Context) {
+			close(isReinitializing)
+			<-release
+		},
+		repository: repo,
+	}
+	watcher := newWatcherWithFactory(repo, factory, nil)
+	engine := middleware.NewEngine(&mockEngine{repository: repo}, watcher.Interceptor()) //exhaustruct:ignore
+
+	checked := make(chan error)
+
+	go func() {
+		_, err := watcher.Check(context.TODO())
+		checked <- err
+	}()
+
+	<-isReinitializing
+
+	ctx, cancel := context.WithTimeout(context.TODO(), callDelay)
+	defer cancel()
+
+	_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(test, <-checked)
+
+	_, err = engine.AddRecord(context.TODO(), "TEST", "1", "{}", senzing.SzNoFlags)
+	require.NoError(test, err)
+}
+
+func TestWatcher_RunAndNotify(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	repo := &repository{} //exhaustruct:ignore
+	repo.activeConfigID.Store(1)
+	repo.defaultConfigID.Store(1)
+
+	watcher := newWatcher(repo, nil)
+	done := make(chan error)
+
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+
+	repo.defaultConfigID.Store(3)
+	watcher.Notify()
+	require.Eventually(test, func() bool {
+		return repo.activeConfigID.Load() == 3
+	}, waitFor, time.Millisecond)
+	cancel()
+	require.ErrorIs(test, <-done, context.Canceled)
+}
@@ -0,0 +1,11 @@
+/*
+Package configwatch reinitializes Senzing when the default configuration changes.
+
+A [Watcher] compares SzEngine.GetActiveConfigID with SzConfigManager.GetDefaultConfigID,
+either on a polling interval or when notified in-process, and calls SzAbstractFactory.Reinitialize
+when they differ.
+Calls routed through the watcher's interceptor are drained before reinitialization
+and held until it completes, or until their context is done. Calls the factory makes
+with the context it was given while reinitializing are not held.
+*/
+package configwatch
@@ -0,0 +1,384 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/finder"
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Checkpoint struct is the state of a crawl. It can be saved as JSON and passed to [Resume].
+*/
+type Checkpoint struct {
+	Calls    int64      `json:"calls"`    // FindNetwork calls whose results were all sent to the sink.
+	Edges    [][2]int64 `json:"edges"`    // Edges sent to the sink, as smaller and larger entity ID.
+	Entities []int64    `json:"entities"` // Entities sent to the sink.
+	Expanded []int64    `json:"expanded"` // Entities already passed to FindNetwork.
+	Frontier []int64    `json:"frontier"` // Entities waiting to be passed to FindNetwork, in order.
+}
+
+/*
+Type Config struct configures a [Crawler].
+*/
+type Config struct {
+	BatchSize           int    // Entities expanded per FindNetwork call. Default: DefaultBatchSize.
+	BuildOutDegrees     *int64 // Default (nil): finder.DefaultBuildOutDegrees. Can point to 0 for no build-out.
+	BuildOutMaxEntities int64  // Default: finder.DefaultBuildOutMaxEntities.
+	Flags               *int64 // Default (nil): senzing.SzFindNetworkDefaultFlags. Can point to senzing.SzNoFlags.
+	MaxCalls            int64  // Maximum FindNetwork calls, including calls before a resume. Zero for no limit.
+	MaxDegrees          *int64 // Default (nil): finder.DefaultMaxDegrees. Can point to 0 for direct relations only.
+	MaxEntities         int64  // Maximum entities sent to the sink. Zero for no limit.
+
+	// OnCheckpoint, if not nil, is called after each FindNetwork call. An error stops the crawl.
+	OnCheckpoint func(ctx context.Context, checkpoint Checkpoint) error
+}
+
+/*
+Type Crawler struct expands an entity network with repeated bounded FindNetwork calls.
+It is not safe for concurrent use.
+*/
+type Crawler struct {
+	calls    int64
+	config   Config
+	edges    map[[2]int64]bool
+	entities map[int64]bool
+	frontier []int64
+	queued   map[int64]bool // Entities in the frontier or already expanded.
+	sink     Sink
+	szEngine senzing.SzEngine
+}
+
+/*
+Type Sink interface receives entities and edges as they are discovered.
+Each entity is sent once, before any of its edges. Each edge is sent once, after both of its entities.
+*/
+type Sink interface {
+	Edge(ctx context.Context, edge graph.Edge) error
+	Node(ctx context.Context, node graph.Node) error
+}
+
+type graphSink struct {
+	graph *graph.Graph
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultBatchSize is the number of entities expanded per call when Config.BatchSize is not set.
+const DefaultBatchSize = 10
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function New creates a Crawler that starts from seed entities.
+
+Input
+  - szEngine: The engine to call.
+  - sink: Receives discovered entities and edges.
+  - config: Limits and FindNetwork parameters.
+  - seeds: The entities to start from. They are sent to the sink when a response includes them.
+
+Output
+  - A Crawler.
+*/
+func New(szEngine senzing.SzEngine, sink Sink, config Config, seeds ...int64) *Crawler {
+	result := newCrawler(szEngine, sink, config)
+	result.enqueue(seeds...)
+
+	return result
+}
+
+/*
+Function Resume creates a Crawler that continues from a checkpoint.
+Entities and edges in the checkpoint are not sent to the sink again.
+
+Input
+  - szEngine: The engine to call.
+  - sink: Receives discovered entities and edges.
+  - config: Limits and FindNetwork parameters.
+  - checkpoint: A checkpoint from Crawler.Checkpoint or Config.OnCheckpoint.
+
+Output
+  - A Crawler.
+*/
+func Resume(szEngine senzing.SzEngine, sink Sink, config Config, checkpoint Checkpoint) *Crawler {
+	result := newCrawler(szEngine, sink, config)
+	result.calls = checkpoint.Calls
+	result.enqueue(checkpoint.Frontier...)
+
+	for _, entityID := range checkpoint.Expanded {
+		result.queued[entityID] = true
+	}
+
+	for _, entityID := range checkpoint.Entities {
+		result.entities[entityID] = true
+	}
+
+	for _, edge := range checkpoint.Edges {
+		result.edges[edge] = true
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function GraphSink returns a Sink that adds entities and edges to a graph.
+*/
+func GraphSink(entityGraph *graph.Graph) Sink {
+	return &graphSink{graph: entityGraph}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Checkpoint returns the current state of the crawl.
+*/
+func (crawler *Crawler) Checkpoint() Checkpoint {
+	edges := slices.SortedFunc(maps.Keys(crawler.edges), func(a, b [2]int64) int {
+		return slices.Compare(a[:], b[:])
+	})
+
+	isInFrontier := make(map[int64]bool, len(crawler.frontier))
+	for _, entityID := range crawler.frontier {
+		isInFrontier[entityID] = true
+	}
+
+	expanded := []int64{}
+
+	for entityID := range crawler.queued {
+		if !isInFrontier[entityID] {
+			expanded = append(expanded, entityID)
+		}
+	}
+
+	slices.Sort(expanded)
+
+	return Checkpoint{
+		Calls:    crawler.calls,
+		Edges:    edges,
+		Entities: slices.Sorted(maps.Keys(crawler.entities)),
+		Expanded: expanded,
+		Frontier: slices.Clone(crawler.frontier),
+	}
+}
+
+/*
+Method Done returns true if there are no entities left to expand.
+*/
+func (crawler *Crawler) Done() bool {
+	return len(crawler.frontier) == 0
+}
+
+/*
+Method Run crawls until there are no entities left to expand or Config.MaxCalls is reached.
+When Config.MaxEntities is reached, no new entities are sent to the sink,
+but the remaining entities are still expanded to find edges among known entities.
+
+If the engine or the sink fails, the entities of that call stay in the frontier and Run can be called again.
+Entities and edges already sent are not sent again.
+
+Input
+  - ctx: A context to control lifecycle. Cancellation is checked before each call.
+
+Output
+  - An error from the engine, the sink, Config.OnCheckpoint, or ctx.
+*/
+func (crawler *Crawler) Run(ctx context.Context) error {
+	for !crawler.Done() {
+		if crawler.config.MaxCalls > 0 && crawler.calls >= crawler.config.MaxCalls {
+			return nil
+		}
+
+		err := ctx.Err()
+		if err != nil {
+			return fmt.Errorf("crawler stopped: %w", err)
+		}
+
+		err = crawler.expand(ctx)
+		if err != nil {
+			return err
+		}
+
+		if crawler.config.OnCheckpoint != nil {
+			err = crawler.config.OnCheckpoint(ctx, crawler.Checkpoint())
+			if err != nil {
+				return fmt.Errorf("crawler checkpoint failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (crawler *Crawler) enqueue(entityIDs ...int64) {
+	for _, entityID := range entityIDs {
+		if !crawler.queued[entityID] {
+			crawler.queued[entityID] = true
+			crawler.frontier = append(crawler.frontier, entityID)
+		}
+	}
+}
+
+// Expands the first batch of the frontier with one FindNetwork call.
+func (crawler *Crawler) expand(ctx context.Context) error {
+	batch := crawler.frontier[:min(crawler.config.BatchSize, len(crawler.frontier))]
+
+	among := make([]finder.Endpoint, 0, len(batch))
+	for _, entityID := range batch {
+		among = append(among, finder.Entity(entityID))
+	}
+
+	responseJSON, err := finder.FindNetwork(ctx, crawler.szEngine,
+		finder.Among(among...),
+		finder.MaxDegrees(*crawler.config.MaxDegrees),
+		finder.BuildOut(*crawler.config.BuildOutDegrees, crawler.config.BuildOutMaxEntities),
+		finder.WithFlags(*crawler.config.Flags),
+	)
+	if err != nil {
+		return fmt.Errorf("crawler cannot expand %v: %w", batch, err)
+	}
+
+	networkResponse, err := response.SzEngineFindNetworkByEntityID(ctx, responseJSON)
+	if err != nil {
+		return fmt.Errorf("crawler cannot parse network of %v: %w", batch, err)
+	}
+
+	discovered, err := graph.FromResponses(networkResponse)
+	if err != nil {
+		return fmt.Errorf("crawler cannot expand %v: %w", batch, err)
+	}
+
+	for _, node := range discovered.Nodes() {
+		err = crawler.sendNode(ctx, node)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range discovered.Edges() {
+		err = crawler.sendEdge(ctx, edge)
+		if err != nil {
+			return err
+		}
+	}
+
+	// New entities were appended, so the batch is still at the start.
+	crawler.frontier = crawler.frontier[len(batch):]
+
+	// Counted only now, so a call that is retried after a failure is counted once.
+	crawler.calls++
+
+	return nil
+}
+
+func (crawler *Crawler) isFull() bool {
+	return crawler.config.MaxEntities > 0 && int64(len(crawler.entities)) >= crawler.config.MaxEntities
+}
+
+func (crawler *Crawler) sendEdge(ctx context.Context, edge graph.Edge) error {
+	key := [2]int64{edge.FromEntityID, edge.ToEntityID}
+	if crawler.edges[key] || !crawler.entities[edge.FromEntityID] || !crawler.entities[edge.ToEntityID] {
+		return nil
+	}
+
+	err := crawler.sink.Edge(ctx, edge)
+	if err != nil {
+		return fmt.Errorf("crawler sink failed for edge %d-%d: %w", edge.FromEntityID, edge.ToEntityID, err)
+	}
+
+	crawler.edges[key] = true
+
+	return nil
+}
+
+// Sends a new entity and adds it to the frontier, unless MaxEntities is reached.
+func (crawler *Crawler) sendNode(ctx context.Context, node graph.Node) error {
+	if crawler.entities[node.EntityID] || crawler.isFull() {
+		return nil
+	}
+
+	err := crawler.sink.Node(ctx, node)
+	if err != nil {
+		return fmt.Errorf("crawler sink failed for entity %d: %w", node.EntityID, err)
+	}
+
+	crawler.entities[node.EntityID] = true
+	crawler.enqueue(node.EntityID)
+
+	return nil
+}
+
+func (sink *graphSink) Edge(_ context.Context, edge graph.Edge) error {
+	sink.graph.AddEdge(edge)
+
+	return nil
+}
+
+func (sink *graphSink) Node(_ context.Context, node graph.Node) error {
+	sink.graph.AddNode(node)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newCrawler(szEngine senzing.SzEngine, sink Sink, config Config) *Crawler {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+
+	if config.BuildOutMaxEntities == 0 {
+		config.BuildOutMaxEntities = finder.DefaultBuildOutMaxEntities
+	}
+
+	// Copied, so that later changes by the caller do not affect the crawl.
+	config.BuildOutDegrees = valueOr(config.BuildOutDegrees, finder.DefaultBuildOutDegrees)
+	config.Flags = valueOr(config.Flags, senzing.SzFindNetworkDefaultFlags)
+	config.MaxDegrees = valueOr(config.MaxDegrees, finder.DefaultMaxDegrees)
+
+	return &Crawler{
+		calls:    0,
+		config:   config,
+		edges:    map[[2]int64]bool{},
+		entities: map[int64]bool{},
+		frontier: []int64{},
+		queued:   map[int64]bool{},
+		sink:     sink,
+		szEngine: szEngine,
+	}
+}
+
+// Returns a pointer to a copy of *value, or to defaultValue if value is nil.
+func valueOr(value *int64, defaultValue int64) *int64 {
+	result := defaultValue
+	if value != nil {
+		result = *value
+	}
+
+	return &result
+}
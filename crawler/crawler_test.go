@@ -0,0 +1,292 @@
+package crawler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/crawler"
+	"github.com/senzing-garage/sz-sdk-go/finder"
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/params"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+// A chain 1-2-3-4-5-6 and a separate pair 7-8.
+var testNetwork = map[int64][]int64{
+	1: {2},
+	2: {1, 3},
+	3: {2, 4},
+	4: {3, 5},
+	5: {4, 6},
+	6: {5},
+	7: {8},
+	8: {7},
+}
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+// Returns the requested entities and their direct neighbors, ignoring the degree limits.
+type mockEngine struct {
+	senzing.SzEngine
+
+	buildOutDegrees []int64
+	calls           [][]int64
+	failing         bool
+	flags           []int64
+	maxDegrees      []int64
+}
+
+func (engine *mockEngine) FindNetworkByEntityID(
+	_ context.Context,
+	entityIDs string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	_ int64,
+	flags int64,
+) (string, error) {
+	if engine.failing {
+		return "", errTest
+	}
+
+	among, err := params.ParseEntityIDs(entityIDs)
+	if err != nil {
+		return "", err
+	}
+
+	engine.calls = append(engine.calls, among)
+	engine.flags = append(engine.flags, flags)
+	engine.maxDegrees = append(engine.maxDegrees, maxDegrees)
+	engine.buildOutDegrees = append(engine.buildOutDegrees, buildOutDegrees)
+
+	entities := []string{}
+	links := []string{}
+
+	for _, entityID := range among {
+		entities = append(entities, entityJSON(entityID))
+
+		for _, neighbor := range testNetwork[entityID] {
+			entities = append(entities, entityJSON(neighbor))
+			links = append(links, fmt.Sprintf(
+				`{"MATCH_KEY":"+NAME","MATCH_LEVEL_CODE":"POSSIBLY_RELATED","MIN_ENTITY_ID":%d,"MAX_ENTITY_ID":%d}`,
+				min(entityID, neighbor), max(entityID, neighbor)))
+		}
+	}
+
+	return `{"ENTITIES":[` + strings.Join(entities, ",") + `],"ENTITY_NETWORK_LINKS":[` +
+		strings.Join(links, ",") + `]}`, nil
+}
+
+type recordingSink struct {
+	edges   []graph.Edge
+	failing bool
+	nodes   []graph.Node
+}
+
+func (sink *recordingSink) Edge(_ context.Context, edge graph.Edge) error {
+	sink.edges = append(sink.edges, edge)
+
+	return nil
+}
+
+func (sink *recordingSink) Node(_ context.Context, node graph.Node) error {
+	if sink.failing {
+		return errTest
+	}
+
+	sink.nodes = append(sink.nodes, node)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func entityJSON(entityID int64) string {
+	return fmt.Sprintf(`{"RESOLVED_ENTITY":{"ENTITY_ID":%d,"ENTITY_NAME":"Entity %d"}}`, entityID, entityID)
+}
+
+func nodeIDs(nodes []graph.Node) []int64 {
+	result := []int64{}
+	for _, node := range nodes {
+		result = append(result, node.EntityID)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestCrawler_Run(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	engine := &mockEngine{} //exhaustruct:ignore
+	entityGraph := graph.New()
+	crawl := crawler.New(engine, crawler.GraphSink(entityGraph), crawler.Config{BatchSize: 2}, 1) //exhaustruct:ignore
+	require.NoError(test, crawl.Run(ctx))
+	assert.True(test, crawl.Done())
+
+	assert.Len(test, entityGraph.Nodes(), 6)
+	assert.Len(test, entityGraph.Edges(), 5)
+
+	node, _ := entityGraph.Node(1)
+	assert.Equal(test, "Entity 1", node.Name)
+
+	// Each entity is expanded once.
+	assert.Equal(test, [][]int64{{1}, {2}, {3}, {4}, {5}, {6}}, engine.calls)
+	assert.Equal(test, int64(6), crawl.Checkpoint().Calls)
+}
+
+func TestCrawler_Run_Batches(test *testing.T) {
+	test.Parallel()
+	engine := &mockEngine{}                                                //exhaustruct:ignore
+	sink := &recordingSink{}                                               //exhaustruct:ignore
+	crawl := crawler.New(engine, sink, crawler.Config{BatchSize: 2}, 1, 7) //exhaustruct:ignore
+	require.NoError(test, crawl.Run(test.Context()))
+	assert.Equal(test, [][]int64{{1, 7}, {2, 8}, {3}, {4}, {5}, {6}}, engine.calls)
+	assert.ElementsMatch(test, []int64{1, 2, 3, 4, 5, 6, 7, 8}, nodeIDs(sink.nodes))
+	assert.Len(test, sink.edges, 6)
+}
+
+func TestCrawler_Run_MaxCalls(test *testing.T) {
+	test.Parallel()
+	engine := &mockEngine{}                                            //exhaustruct:ignore
+	sink := &recordingSink{}                                           //exhaustruct:ignore
+	crawl := crawler.New(engine, sink, crawler.Config{MaxCalls: 2}, 1) //exhaustruct:ignore
+	require.NoError(test, crawl.Run(test.Context()))
+	assert.False(test, crawl.Done())
+	assert.Len(test, engine.calls, 2)
+	assert.Equal(test, []int64{1, 2, 3}, nodeIDs(sink.nodes))
+}
+
+func TestCrawler_Run_MaxEntities(test *testing.T) {
+	test.Parallel()
+	engine := &mockEngine{}                                               //exhaustruct:ignore
+	sink := &recordingSink{}                                              //exhaustruct:ignore
+	crawl := crawler.New(engine, sink, crawler.Config{MaxEntities: 4}, 1) //exhaustruct:ignore
+	require.NoError(test, crawl.Run(test.Context()))
+	assert.True(test, crawl.Done())
+	assert.Equal(test, []int64{1, 2, 3, 4}, nodeIDs(sink.nodes))
+
+	// Edges to entities over the budget are not sent.
+	assert.Len(test, sink.edges, 3)
+}
+
+func TestCrawler_Run_Errors(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+
+	engine := &mockEngine{failing: true}                                //exhaustruct:ignore
+	crawl := crawler.New(engine, &recordingSink{}, crawler.Config{}, 1) //exhaustruct:ignore
+	require.ErrorIs(test, crawl.Run(ctx), errTest)
+	assert.Equal(test, []int64{1}, crawl.Checkpoint().Frontier)
+
+	// The crawl continues once the engine recovers.
+	engine.failing = false
+	require.NoError(test, crawl.Run(ctx))
+	assert.True(test, crawl.Done())
+
+	sink := &recordingSink{failing: true}                         //exhaustruct:ignore
+	crawl = crawler.New(&mockEngine{}, sink, crawler.Config{}, 1) //exhaustruct:ignore
+	require.ErrorIs(test, crawl.Run(ctx), errTest)
+	assert.Equal(test, []int64{1}, crawl.Checkpoint().Frontier)
+	assert.Equal(test, int64(0), crawl.Checkpoint().Calls, "a call whose results were not sent is not counted")
+
+	// The retried call is counted once.
+	sink.failing = false
+	require.NoError(test, crawl.Run(ctx))
+	assert.Equal(test, int64(6), crawl.Checkpoint().Calls)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	crawl = crawler.New(&mockEngine{}, &recordingSink{}, crawler.Config{}, 1) //exhaustruct:ignore
+	require.ErrorIs(test, crawl.Run(canceled), context.Canceled)
+
+	crawl = crawler.New(&mockEngine{}, &recordingSink{}, crawler.Config{ //exhaustruct:ignore
+		OnCheckpoint: func(context.Context, crawler.Checkpoint) error { return errTest },
+	}, 1)
+	require.ErrorIs(test, crawl.Run(ctx), errTest)
+}
+
+func TestResume(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	checkpoints := []crawler.Checkpoint{}
+	first := &recordingSink{}                                  //exhaustruct:ignore
+	crawl := crawler.New(&mockEngine{}, first, crawler.Config{ //exhaustruct:ignore
+		MaxCalls: 2,
+		OnCheckpoint: func(_ context.Context, checkpoint crawler.Checkpoint) error {
+			checkpoints = append(checkpoints, checkpoint)
+
+			return nil
+		},
+	}, 1)
+	require.NoError(test, crawl.Run(ctx))
+	require.Len(test, checkpoints, 2)
+
+	checkpoint := checkpoints[1]
+	assert.Equal(test, []int64{1, 2}, checkpoint.Expanded)
+	assert.Equal(test, []int64{3}, checkpoint.Frontier)
+
+	engine := &mockEngine{}                                              //exhaustruct:ignore
+	second := &recordingSink{}                                           //exhaustruct:ignore
+	crawl = crawler.Resume(engine, second, crawler.Config{}, checkpoint) //exhaustruct:ignore
+	require.NoError(test, crawl.Run(ctx))
+	assert.Equal(test, [][]int64{{3}, {4}, {5}, {6}}, engine.calls)
+
+	// Nothing is sent twice across the two runs.
+	assert.Equal(test, []int64{1, 2, 3}, nodeIDs(first.nodes))
+	assert.Equal(test, []int64{4, 5, 6}, nodeIDs(second.nodes))
+	assert.Len(test, append(first.edges, second.edges...), 5)
+}
+
+func TestCrawler_Flags(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+
+	engine := &mockEngine{}                                             //exhaustruct:ignore
+	crawl := crawler.New(engine, &recordingSink{}, crawler.Config{}, 7) //exhaustruct:ignore
+	require.NoError(test, crawl.Run(ctx))
+	assert.Equal(test, []int64{senzing.SzFindNetworkDefaultFlags, senzing.SzFindNetworkDefaultFlags}, engine.flags)
+
+	flags := senzing.SzNoFlags
+	engine = &mockEngine{}                                                          //exhaustruct:ignore
+	crawl = crawler.New(engine, &recordingSink{}, crawler.Config{Flags: &flags}, 7) //exhaustruct:ignore
+
+	// Changes after New do not affect the crawl.
+	flags = senzing.SzFindNetworkDefaultFlags
+	require.NoError(test, crawl.Run(ctx))
+	assert.Equal(test, []int64{senzing.SzNoFlags, senzing.SzNoFlags}, engine.flags)
+}
+
+func TestCrawler_Degrees(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+
+	engine := &mockEngine{}                                             //exhaustruct:ignore
+	crawl := crawler.New(engine, &recordingSink{}, crawler.Config{}, 7) //exhaustruct:ignore
+	require.NoError(test, crawl.Run(ctx))
+	assert.Equal(test, []int64{finder.DefaultMaxDegrees, finder.DefaultMaxDegrees}, engine.maxDegrees)
+	assert.Equal(test, []int64{finder.DefaultBuildOutDegrees, finder.DefaultBuildOutDegrees}, engine.buildOutDegrees)
+
+	// Zero is a valid value, not the default.
+	var zero int64
+
+	engine = &mockEngine{}                                              //exhaustruct:ignore
+	config := crawler.Config{BuildOutDegrees: &zero, MaxDegrees: &zero} //exhaustruct:ignore
+	require.NoError(test, crawler.New(engine, &recordingSink{}, config, 7).Run(ctx))
+	assert.Equal(test, []int64{0, 0}, engine.maxDegrees)
+	assert.Equal(test, []int64{0, 0}, engine.buildOutDegrees)
+}
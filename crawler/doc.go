@@ -0,0 +1,21 @@
+/*
+Package crawler expands an entity network beyond the limits of a single FindNetwork call.
+
+SzEngine.FindNetworkByEntityID is bounded by maxDegrees, buildOutDegrees, and buildOutMaxEntities.
+A [Crawler] calls it repeatedly, each time among a batch of entities not yet expanded,
+and sends each new entity and edge to a [Sink] once:
+
+	entityGraph := graph.New()
+	crawl := crawler.New(szEngine, crawler.GraphSink(entityGraph), crawler.Config{
+		MaxCalls:    50,
+		MaxEntities: 1000,
+		OnCheckpoint: func(ctx context.Context, checkpoint crawler.Checkpoint) error {
+			return saveCheckpoint(checkpoint)
+		},
+	}, 1, 100)
+	err := crawl.Run(ctx)
+
+The crawl stops when there is nothing left to expand or a budget is reached.
+A [Checkpoint] saved as JSON can be passed to [Resume] to continue later.
+*/
+package crawler
@@ -0,0 +1,141 @@
+package datasource
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Code string is a normalized data source code, as used in DSRC_CODE and in the DATA_SOURCE of a record.
+Create one with [Parse] or [MustParse].
+*/
+type Code string
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// MaxLength is the maximum length of a data source code.
+const MaxLength = 25
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidCode is returned for codes that cannot be normalized.
+var ErrInvalidCode = errors.New("invalid data source code")
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function MustParse is like Parse, but panics if the code is invalid.
+It is intended for codes that are constants in a program.
+
+Input
+  - code: The data source code.
+
+Output
+  - The normalized data source code.
+*/
+func MustParse(code string) Code {
+	result, err := Parse(code)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+/*
+Function Parse normalizes a data source code.
+Surrounding whitespace is removed and letters are upper-cased.
+The result must be 1 to MaxLength characters of A-Z, 0-9, '_', and '-'.
+
+Input
+  - code: The data source code, e.g. " customers ".
+
+Output
+  - The normalized data source code, e.g. "CUSTOMERS".
+    Errors match both ErrInvalidCode and szerror.ErrSzBadInput.
+*/
+func Parse(code string) (Code, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+
+	switch {
+	case normalized == "":
+		return "", invalidCode(code, "is empty")
+	case len(normalized) > MaxLength:
+		return "", invalidCode(code, fmt.Sprintf("is longer than %d characters", MaxLength))
+	}
+
+	for _, character := range normalized {
+		if !isAllowed(character) {
+			return "", invalidCode(code, fmt.Sprintf("contains %q; only A-Z, 0-9, '_', and '-' are allowed", character))
+		}
+	}
+
+	return Code(normalized), nil
+}
+
+/*
+Function ParseAll normalizes a list of data source codes.
+
+Input
+  - codes: The data source codes.
+
+Output
+  - The normalized data source codes, in the same order.
+*/
+func ParseAll(codes ...string) ([]Code, error) {
+	result := make([]Code, 0, len(codes))
+
+	for _, code := range codes {
+		parsed, err := Parse(code)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, parsed)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method String returns the data source code as a string, for use in SzEngine and SzConfig calls.
+*/
+func (code Code) String() string {
+	return string(code)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func invalidCode(code string, reason string) error {
+	return szerror.Wrap(
+		fmt.Errorf("%w: %q %s", ErrInvalidCode, code, reason),
+		szerror.SzBadInputError,
+		szerror.SzError,
+	)
+}
+
+func isAllowed(character rune) bool {
+	return (character >= 'A' && character <= 'Z') ||
+		(character >= '0' && character <= '9') ||
+		character == '_' ||
+		character == '-'
+}
@@ -0,0 +1,274 @@
+package datasource_test
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/datasource"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockConfig struct {
+	senzing.SzConfig
+
+	registryJSON string
+}
+
+func (config *mockConfig) GetDataSourceRegistry(_ context.Context) (string, error) {
+	return config.registryJSON, nil
+}
+
+type mockConfigManager struct {
+	senzing.SzConfigManager
+
+	loads        atomic.Int64
+	registryJSON atomic.Value
+}
+
+func (configManager *mockConfigManager) CreateConfigFromConfigID(
+	_ context.Context,
+	_ int64,
+) (senzing.SzConfig, error) {
+	configManager.loads.Add(1)
+
+	registryJSON, _ := configManager.registryJSON.Load().(string)
+
+	return &mockConfig{registryJSON: registryJSON}, nil //exhaustruct:ignore
+}
+
+func (configManager *mockConfigManager) GetDefaultConfigID(_ context.Context) (int64, error) {
+	return 1, nil
+}
+
+type mockEngine struct {
+	senzing.SzEngine
+
+	calls           atomic.Int64
+	lastDataSources []string
+}
+
+func (engine *mockEngine) AddRecord(
+	_ context.Context,
+	dataSourceCode string,
+	_ string,
+	_ string,
+	_ int64,
+) (string, error) {
+	engine.calls.Add(1)
+	engine.lastDataSources = []string{dataSourceCode}
+
+	return "", nil
+}
+
+func (engine *mockEngine) FindPathByRecordID(
+	_ context.Context,
+	startDataSourceCode string,
+	_ string,
+	endDataSourceCode string,
+	_ string,
+	_ int64,
+	_ string,
+	_ string,
+	_ int64,
+) (string, error) {
+	engine.calls.Add(1)
+	engine.lastDataSources = []string{startDataSourceCode, endDataSourceCode}
+
+	return "", nil
+}
+
+func (engine *mockEngine) GetVirtualEntityByRecordID(_ context.Context, _ string, _ int64) (string, error) {
+	engine.calls.Add(1)
+
+	return "", nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newMockConfigManager(t *testing.T) *mockConfigManager {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", "SzConfigGetDataSourceRegistryResponse.jsonl"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	require.True(t, scanner.Scan())
+
+	result := &mockConfigManager{} //exhaustruct:ignore
+	result.registryJSON.Store(scanner.Text())
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParse(test *testing.T) {
+	test.Parallel()
+
+	code, err := datasource.Parse("  customers_2024-a ")
+	require.NoError(test, err)
+	assert.Equal(test, datasource.Code("CUSTOMERS_2024-A"), code)
+	assert.Equal(test, "CUSTOMERS_2024-A", code.String())
+
+	for _, bad := range []string{"", "   ", "MY SOURCE", "CUSTOMERS!", "ABCDEFGHIJKLMNOPQRSTUVWXYZ"} {
+		_, err = datasource.Parse(bad)
+		require.ErrorIs(test, err, datasource.ErrInvalidCode, bad)
+		require.ErrorIs(test, err, szerror.ErrSzBadInput, bad)
+	}
+
+	codes, err := datasource.ParseAll("a", "b")
+	require.NoError(test, err)
+	assert.Equal(test, []datasource.Code{"A", "B"}, codes)
+
+	_, err = datasource.ParseAll("a", "b c")
+	require.ErrorIs(test, err, datasource.ErrInvalidCode)
+}
+
+func TestMustParse(test *testing.T) {
+	test.Parallel()
+
+	assert.Equal(test, datasource.Code("TEST"), datasource.MustParse("test"))
+	assert.Panics(test, func() { datasource.MustParse("bad code") })
+}
+
+func TestRegistry_Validate(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	registry := datasource.NewRegistry(datasource.RegistryConfig{ //exhaustruct:ignore
+		ConfigManager:  configManager,
+		ReloadInterval: 20 * time.Millisecond,
+	})
+
+	codes, err := registry.Validate(ctx, "test", "Search")
+	require.NoError(test, err)
+	assert.Equal(test, []datasource.Code{"TEST", "SEARCH"}, codes)
+
+	_, err = registry.Validate(ctx, "TEST")
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), configManager.loads.Load())
+
+	for range 10 {
+		_, err = registry.Validate(ctx, "CUSTOMERS")
+		require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+	}
+
+	assert.Equal(test, int64(1), configManager.loads.Load(), "misses within the reload interval do not reload")
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, err = registry.Validate(ctx, "CUSTOMERS")
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+	assert.Equal(test, int64(2), configManager.loads.Load(), "a miss after the reload interval reloads once")
+
+	time.Sleep(30 * time.Millisecond)
+	configManager.registryJSON.Store(`{"DATA_SOURCES": [{"DSRC_CODE": "CUSTOMERS", "DSRC_ID": 1001}]}`)
+	_, err = registry.Validate(ctx, "customers")
+	require.NoError(test, err)
+
+	all, err := registry.Codes(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, []datasource.Code{"CUSTOMERS"}, all)
+}
+
+func TestRegistry_ConcurrentLoad(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	registry := datasource.NewRegistry(datasource.RegistryConfig{ConfigManager: configManager}) //exhaustruct:ignore
+
+	var waitGroup sync.WaitGroup
+
+	for range 20 {
+		waitGroup.Go(func() {
+			_, err := registry.Validate(ctx, "TEST")
+			assert.NoError(test, err)
+		})
+	}
+
+	waitGroup.Wait()
+	assert.Equal(test, int64(1), configManager.loads.Load(), "concurrent callers share one load")
+}
+
+func TestRegistry_TTL(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	configManager := newMockConfigManager(test)
+	registry := datasource.NewRegistry(datasource.RegistryConfig{ //exhaustruct:ignore
+		ConfigManager: configManager,
+		TTL:           time.Millisecond,
+	})
+
+	_, err := registry.Codes(ctx)
+	require.NoError(test, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = registry.Codes(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), configManager.loads.Load())
+
+	registry.Invalidate()
+	_, err = registry.Codes(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), configManager.loads.Load())
+}
+
+func TestEngine(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	szEngine := &mockEngine{}                                     //exhaustruct:ignore
+	registry := datasource.NewRegistry(datasource.RegistryConfig{ //exhaustruct:ignore
+		ConfigManager: newMockConfigManager(test),
+	})
+	engine := datasource.NewEngine(szEngine, registry)
+
+	_, err := engine.AddRecord(ctx, " test", "1", "{}", senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"TEST"}, szEngine.lastDataSources)
+
+	_, err = engine.AddRecord(ctx, "TSET", "1", "{}", senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+
+	_, err = engine.FindPathByRecordID(ctx, "test", "1", "search", "2", 3, senzing.SzNoAvoidance,
+		`{"DATA_SOURCES": ["TEST"]}`, senzing.SzFindPathDefaultFlags)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"TEST", "SEARCH"}, szEngine.lastDataSources)
+
+	_, err = engine.FindPathByRecordID(ctx, "test", "1", "search", "2", 3,
+		`{"RECORDS": [{"DATA_SOURCE": "NOPE", "RECORD_ID": "3"}]}`, senzing.SzNoRequiredDatasources,
+		senzing.SzFindPathDefaultFlags)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+
+	_, err = engine.FindPathByRecordID(ctx, "test", "1", "search", "2", 3, senzing.SzNoAvoidance,
+		`{"DATA_SOURCES": "TEST"}`, senzing.SzFindPathDefaultFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = engine.GetVirtualEntityByRecordID(ctx, `{"RECORDS": [{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}]}`,
+		senzing.SzVirtualEntityDefaultFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), szEngine.calls.Load())
+}
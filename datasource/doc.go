@@ -0,0 +1,19 @@
+/*
+Package datasource validates and normalizes data source codes before they reach Senzing.
+
+SzConfig.RegisterDataSource, SzEngine.AddRecord, the record keys of SzEngine.FindNetworkByRecordID,
+the requiredDataSources of SzEngine.FindPathByEntityID, and many other methods take data source codes as
+free-form strings. A typo is only reported by the engine, as szerror.ErrSzUnknownDataSource.
+
+[Parse] normalizes a code into a [Code]: whitespace is trimmed, letters are upper-cased,
+and only A-Z, 0-9, '_', and '-' are allowed, up to [MaxLength] characters.
+
+A [Registry] caches the data source registry (SzConfig.GetDataSourceRegistry) and validates codes against it.
+[Engine] wraps a senzing.SzEngine and validates every data source code before calling it:
+
+	registry := datasource.NewRegistry(datasource.RegistryConfig{ConfigManager: szConfigManager, Engine: szEngine})
+	szEngine = datasource.NewEngine(szEngine, registry)
+	_, err := szEngine.AddRecord(ctx, "CUSTOMRES", "1001", record, senzing.SzWithoutInfo)
+	// errors.Is(err, szerror.ErrSzUnknownDataSource) == true, and the engine was not called.
+*/
+package datasource
@@ -0,0 +1,320 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type RegistryConfig struct configures a [Registry].
+*/
+type RegistryConfig struct {
+	// ConfigManager is used to load the configuration holding the data source registry.
+	ConfigManager senzing.SzConfigManager
+
+	// Engine, if set, selects the engine's active configuration.
+	// Otherwise the repository's default configuration is used.
+	Engine senzing.SzEngine
+
+	// ReloadInterval is the minimum time between reloads caused by unregistered codes,
+	// so a stream of bad codes does not load the configuration on every call. Zero means DefaultReloadInterval.
+	ReloadInterval time.Duration
+
+	// TTL is how long the registry is cached. Zero means DefaultTTL.
+	TTL time.Duration
+}
+
+/*
+Type Registry struct is a cached copy of the data source registry (SzConfig.GetDataSourceRegistry).
+It is safe for concurrent use.
+*/
+type Registry struct {
+	codes    []Code // Replaced, never modified, by a load.
+	config   RegistryConfig
+	loadedAt time.Time
+	loading  *registryLoad // The load in progress, if any.
+	mutex    sync.Mutex
+}
+
+type registryLoad struct {
+	done chan struct{}
+	err  error
+}
+
+type dataSourceRegistry struct {
+	DataSources []struct {
+		DataSourceCode string `json:"DSRC_CODE"`
+	} `json:"DATA_SOURCES"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultReloadInterval is used when RegistryConfig.ReloadInterval is zero.
+const DefaultReloadInterval = 5 * time.Second
+
+// DefaultTTL is used when RegistryConfig.TTL is zero.
+const DefaultTTL = time.Minute
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewRegistry creates a Registry. The data source registry is loaded on first use.
+
+Input
+  - config: The Senzing objects used to load the data source registry, and the cache lifetime.
+
+Output
+  - A Registry.
+*/
+func NewRegistry(config RegistryConfig) *Registry {
+	if config.ReloadInterval <= 0 {
+		config.ReloadInterval = DefaultReloadInterval
+	}
+
+	if config.TTL <= 0 {
+		config.TTL = DefaultTTL
+	}
+
+	return &Registry{
+		codes:    nil,
+		config:   config,
+		loadedAt: time.Time{},
+		loading:  nil,
+		mutex:    sync.Mutex{},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Codes returns the registered data source codes, loading them if the cache has expired.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The registered data source codes, sorted.
+*/
+func (registry *Registry) Codes(ctx context.Context) ([]Code, error) {
+	codes, _, err := registry.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(codes), nil
+}
+
+/*
+Method Invalidate discards the cached registry, so the next call reloads it.
+Call it after registering a data source.
+*/
+func (registry *Registry) Invalidate() {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.codes = nil
+}
+
+/*
+Method Validate normalizes data source codes and checks that they are registered.
+
+If a code is not in the cached registry, the registry is reloaded before the code is rejected,
+so data sources registered since the last load are found. Such reloads happen at most once
+per RegistryConfig.ReloadInterval.
+
+Input
+  - ctx: A context to control lifecycle.
+  - codes: The data source codes.
+
+Output
+  - The normalized data source codes, in the same order.
+    Unregistered codes give an error matching szerror.ErrSzUnknownDataSource.
+*/
+func (registry *Registry) Validate(ctx context.Context, codes ...string) ([]Code, error) {
+	result, err := ParseAll(codes...)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, loadedAt, err := registry.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range result {
+		if contains(registered, code) {
+			continue
+		}
+
+		if time.Since(loadedAt) >= registry.config.ReloadInterval {
+			seenLoadedAt := loadedAt
+
+			registered, loadedAt, err = registry.reload(ctx, func() bool {
+				return registry.loadedAt.Equal(seenLoadedAt)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if contains(registered, code) {
+				continue
+			}
+		}
+
+		return nil, szerror.Wrap(
+			fmt.Errorf("data source code %s is not registered", code),
+			szerror.SzUnknownDataSourceError,
+			szerror.SzBadInputError,
+			szerror.SzError,
+		)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (registry *Registry) configID(ctx context.Context) (int64, error) {
+	if registry.config.Engine != nil {
+		result, err := registry.config.Engine.GetActiveConfigID(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("datasource cannot get active config ID: %w", err)
+		}
+
+		return result, nil
+	}
+
+	result, err := registry.config.ConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("datasource cannot get default config ID: %w", err)
+	}
+
+	return result, nil
+}
+
+// Returns the cached codes, loading them if they are missing or expired.
+func (registry *Registry) current(ctx context.Context) ([]Code, time.Time, error) {
+	return registry.reload(ctx, func() bool {
+		return registry.codes == nil || time.Since(registry.loadedAt) >= registry.config.TTL
+	})
+}
+
+// Loads the codes from the configuration. It is called without holding the mutex.
+func (registry *Registry) load(ctx context.Context) ([]Code, error) {
+	configID, err := registry.configID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	szConfig, err := registry.config.ConfigManager.CreateConfigFromConfigID(ctx, configID)
+	if err != nil {
+		return nil, fmt.Errorf("datasource cannot load config ID %d: %w", configID, err)
+	}
+
+	registryJSON, err := szConfig.GetDataSourceRegistry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("datasource cannot get data source registry: %w", err)
+	}
+
+	parsed := &dataSourceRegistry{} //exhaustruct:ignore
+
+	err = json.Unmarshal([]byte(registryJSON), parsed)
+	if err != nil {
+		return nil, fmt.Errorf("datasource cannot unmarshal %s: %w", registryJSON, err)
+	}
+
+	codes := make([]Code, 0, len(parsed.DataSources))
+	for _, dataSource := range parsed.DataSources {
+		codes = append(codes, Code(dataSource.DataSourceCode))
+	}
+
+	slices.Sort(codes)
+
+	return codes, nil
+}
+
+/*
+Loads the codes if isStale, called with the mutex held, returns true.
+Returns the cached codes and when they were loaded.
+The mutex is not held during the load, and concurrent callers share one load.
+*/
+func (registry *Registry) reload(ctx context.Context, isStale func() bool) ([]Code, time.Time, error) {
+	registry.mutex.Lock()
+
+	if !isStale() {
+		defer registry.mutex.Unlock()
+
+		return registry.codes, registry.loadedAt, nil
+	}
+
+	pending := registry.loading
+	if pending == nil {
+		pending = &registryLoad{done: make(chan struct{}), err: nil}
+		registry.loading = pending
+		registry.mutex.Unlock()
+
+		codes, err := registry.load(ctx)
+
+		registry.mutex.Lock()
+		defer registry.mutex.Unlock()
+
+		pending.err = err
+		registry.loading = nil
+		close(pending.done)
+
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		registry.codes = codes
+		registry.loadedAt = time.Now()
+
+		return registry.codes, registry.loadedAt, nil
+	}
+
+	registry.mutex.Unlock()
+
+	select {
+	case <-pending.done:
+	case <-ctx.Done():
+		return nil, time.Time{}, fmt.Errorf("datasource cannot wait for the registry to load: %w", ctx.Err())
+	}
+
+	if pending.err != nil {
+		return nil, time.Time{}, pending.err
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	return registry.codes, registry.loadedAt, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func contains(codes []Code, code Code) bool {
+	_, isFound := slices.BinarySearch(codes, code)
+
+	return isFound
+}
@@ -0,0 +1,362 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Engine struct is a senzing.SzEngine that validates data source codes before calling the wrapped engine.
+
+Methods that take a data source code, record keys, or required data sources check them with a [Registry].
+Invalid codes fail without calling the wrapped engine. A single data source code is passed on normalized.
+Codes inside JSON lists of record keys or required data sources are only validated; the lists are passed on as given.
+Other methods are passed through unchanged.
+*/
+type Engine struct {
+	senzing.SzEngine
+
+	registry *Registry
+}
+
+type recordKeys struct {
+	Records []struct {
+		DataSource string `json:"DATA_SOURCE"`
+	} `json:"RECORDS"`
+}
+
+type requiredDataSources struct {
+	DataSources []string `json:"DATA_SOURCES"`
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewEngine wraps a senzing.SzEngine.
+
+Input
+  - szEngine: The engine to call.
+  - registry: The registry used to validate data source codes.
+
+Output
+  - An Engine.
+*/
+func NewEngine(szEngine senzing.SzEngine, registry *Registry) *Engine {
+	return &Engine{
+		SzEngine: szEngine,
+		registry: registry,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method AddRecord validates dataSourceCode and calls SzEngine.AddRecord.
+*/
+func (engine *Engine) AddRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	recordDefinition string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.AddRecord(ctx, codes[0].String(), recordID, recordDefinition, flags) //nolint:wrapcheck
+}
+
+/*
+Method DeleteRecord validates dataSourceCode and calls SzEngine.DeleteRecord.
+*/
+func (engine *Engine) DeleteRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.DeleteRecord(ctx, codes[0].String(), recordID, flags) //nolint:wrapcheck
+}
+
+/*
+Method FindInterestingEntitiesByRecordID validates dataSourceCode and calls SzEngine.FindInterestingEntitiesByRecordID.
+*/
+func (engine *Engine) FindInterestingEntitiesByRecordID(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.FindInterestingEntitiesByRecordID(ctx, codes[0].String(), recordID, flags) //nolint:wrapcheck
+}
+
+/*
+Method FindNetworkByRecordID validates the data sources in recordKeys and calls SzEngine.FindNetworkByRecordID.
+*/
+func (engine *Engine) FindNetworkByRecordID(
+	ctx context.Context,
+	recordKeys string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	err := engine.validateRecordKeys(ctx, recordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:wrapcheck
+	return engine.SzEngine.FindNetworkByRecordID(ctx, recordKeys, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags)
+}
+
+/*
+Method FindPathByEntityID validates requiredDataSources and calls SzEngine.FindPathByEntityID.
+*/
+func (engine *Engine) FindPathByEntityID(
+	ctx context.Context,
+	startEntityID int64,
+	endEntityID int64,
+	maxDegrees int64,
+	avoidEntityIDs string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	err := engine.validateRequiredDataSources(ctx, requiredDataSources)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.FindPathByEntityID( //nolint:wrapcheck
+		ctx,
+		startEntityID,
+		endEntityID,
+		maxDegrees,
+		avoidEntityIDs,
+		requiredDataSources,
+		flags,
+	)
+}
+
+/*
+Method FindPathByRecordID validates the data source codes, avoidRecordKeys, and requiredDataSources,
+and calls SzEngine.FindPathByRecordID.
+*/
+func (engine *Engine) FindPathByRecordID(
+	ctx context.Context,
+	startDataSourceCode string,
+	startRecordID string,
+	endDataSourceCode string,
+	endRecordID string,
+	maxDegrees int64,
+	avoidRecordKeys string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, startDataSourceCode, endDataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	err = engine.validateRecordKeys(ctx, avoidRecordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	err = engine.validateRequiredDataSources(ctx, requiredDataSources)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.FindPathByRecordID( //nolint:wrapcheck
+		ctx,
+		codes[0].String(),
+		startRecordID,
+		codes[1].String(),
+		endRecordID,
+		maxDegrees,
+		avoidRecordKeys,
+		requiredDataSources,
+		flags,
+	)
+}
+
+/*
+Method GetEntityByRecordID validates dataSourceCode and calls SzEngine.GetEntityByRecordID.
+*/
+func (engine *Engine) GetEntityByRecordID(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.GetEntityByRecordID(ctx, codes[0].String(), recordID, flags) //nolint:wrapcheck
+}
+
+/*
+Method GetRecord validates dataSourceCode and calls SzEngine.GetRecord.
+*/
+func (engine *Engine) GetRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.GetRecord(ctx, codes[0].String(), recordID, flags) //nolint:wrapcheck
+}
+
+/*
+Method GetVirtualEntityByRecordID validates the data sources in recordKeys
+and calls SzEngine.GetVirtualEntityByRecordID.
+*/
+func (engine *Engine) GetVirtualEntityByRecordID(ctx context.Context, recordKeys string, flags int64) (string, error) {
+	err := engine.validateRecordKeys(ctx, recordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.GetVirtualEntityByRecordID(ctx, recordKeys, flags) //nolint:wrapcheck
+}
+
+/*
+Method ReevaluateRecord validates dataSourceCode and calls SzEngine.ReevaluateRecord.
+*/
+func (engine *Engine) ReevaluateRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.ReevaluateRecord(ctx, codes[0].String(), recordID, flags) //nolint:wrapcheck
+}
+
+/*
+Method WhyRecordInEntity validates dataSourceCode and calls SzEngine.WhyRecordInEntity.
+*/
+func (engine *Engine) WhyRecordInEntity(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.SzEngine.WhyRecordInEntity(ctx, codes[0].String(), recordID, flags) //nolint:wrapcheck
+}
+
+/*
+Method WhyRecords validates both data source codes and calls SzEngine.WhyRecords.
+*/
+func (engine *Engine) WhyRecords(
+	ctx context.Context,
+	dataSourceCode1 string,
+	recordID1 string,
+	dataSourceCode2 string,
+	recordID2 string,
+	flags int64,
+) (string, error) {
+	codes, err := engine.registry.Validate(ctx, dataSourceCode1, dataSourceCode2)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:wrapcheck
+	return engine.SzEngine.WhyRecords(ctx, codes[0].String(), recordID1, codes[1].String(), recordID2, flags)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (engine *Engine) validateRecordKeys(ctx context.Context, recordKeysJSON string) error {
+	if recordKeysJSON == senzing.SzNoAvoidance {
+		return nil
+	}
+
+	parsed := &recordKeys{} //exhaustruct:ignore
+
+	err := json.Unmarshal([]byte(recordKeysJSON), parsed)
+	if err != nil {
+		return badJSON("record keys", recordKeysJSON, err)
+	}
+
+	codes := make([]string, 0, len(parsed.Records))
+	for _, record := range parsed.Records {
+		codes = append(codes, record.DataSource)
+	}
+
+	_, err = engine.registry.Validate(ctx, codes...)
+
+	return err
+}
+
+func (engine *Engine) validateRequiredDataSources(ctx context.Context, requiredDataSourcesJSON string) error {
+	if requiredDataSourcesJSON == senzing.SzNoRequiredDatasources {
+		return nil
+	}
+
+	parsed := &requiredDataSources{} //exhaustruct:ignore
+
+	err := json.Unmarshal([]byte(requiredDataSourcesJSON), parsed)
+	if err != nil {
+		return badJSON("required data sources", requiredDataSourcesJSON, err)
+	}
+
+	_, err = engine.registry.Validate(ctx, parsed.DataSources...)
+
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func badJSON(name string, value string, err error) error {
+	return szerror.Wrap(
+		fmt.Errorf("datasource cannot unmarshal %s %s: %w", name, value, err),
+		szerror.SzBadInputError,
+		szerror.SzError,
+	)
+}
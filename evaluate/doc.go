@@ -0,0 +1,19 @@
+/*
+Package evaluate measures resolution quality against expected clusters.
+
+The expected resolution is a [Truth]: each record's cluster label, e.g. read from truthset records
+with an added label by [ReadTruth]. The actual resolution is an [Assignment]: each record's entity ID,
+from SzEngine.GetEntityByRecordID with [Capture] or from an export captured by the snapshot package
+with [FromSnapshot]:
+
+	truth, err := evaluate.ReadTruth(labelledFile, "CLUSTER")
+	...
+	actual, err := evaluate.Capture(ctx, szEngine, maps.Keys(truth))
+	...
+	report := evaluate.Evaluate(truth, actual)
+	fmt.Print(report)
+
+A [Report] has pairwise and B-cubed precision, recall, and F1, cluster purity,
+and the over-merges and under-merges, each with an example pair of records to review.
+*/
+package evaluate
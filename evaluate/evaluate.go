@@ -0,0 +1,481 @@
+package evaluate
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/params"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/snapshot"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Assignment map is the actual resolution: record to entity ID.
+*/
+type Assignment map[params.RecordKey]int64
+
+/*
+Type OverMerge struct is an entity with records of more than one expected cluster.
+*/
+type OverMerge struct {
+	Clusters []string `json:"clusters"`
+	EntityID int64    `json:"entityId"`
+	Example  Pair     `json:"example"` // Records of different clusters in the entity.
+}
+
+/*
+Type Pair struct is two records.
+*/
+type Pair struct {
+	A params.RecordKey `json:"a"`
+	B params.RecordKey `json:"b"`
+}
+
+/*
+Type Report struct is the quality of a resolution compared with the expected clusters.
+Only records that are in both the truth and the assignment are scored.
+*/
+type Report struct {
+	BCubed      Scores             `json:"bCubed"`
+	Missing     []params.RecordKey `json:"missing"` // Records in the truth without an entity.
+	OverMerges  []OverMerge        `json:"overMerges"`
+	Pairwise    Scores             `json:"pairwise"`
+	Purity      float64            `json:"purity"`  // Share of records in the largest cluster of their entity.
+	Records     int                `json:"records"` // Records scored.
+	UnderMerges []UnderMerge       `json:"underMerges"`
+	Unlabeled   int                `json:"unlabeled"` // Records with an entity but not in the truth.
+}
+
+/*
+Type Scores struct is precision, recall, and their harmonic mean.
+*/
+type Scores struct {
+	F1        float64 `json:"f1"`
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+}
+
+/*
+Type Truth map is the expected resolution: record to cluster label.
+Records with the same label should resolve to the same entity.
+*/
+type Truth map[params.RecordKey]string
+
+/*
+Type UnderMerge struct is an expected cluster whose records are in more than one entity.
+*/
+type UnderMerge struct {
+	Cluster   string  `json:"cluster"`
+	EntityIDs []int64 `json:"entityIds"`
+	Example   Pair    `json:"example"` // Records of the cluster in different entities.
+}
+
+type cell struct {
+	cluster  string
+	entityID int64
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidTruth is returned when a truth record lacks DATA_SOURCE, RECORD_ID, or the cluster field.
+var ErrInvalidTruth = errors.New("invalid truth record")
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Capture gets the entity of each record with SzEngine.GetEntityByRecordID.
+Records that are not found are left out.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - records: The records, e.g. the keys of a Truth.
+
+Output
+  - The assignment.
+*/
+func Capture(ctx context.Context, szEngine senzing.SzEngine, records iter.Seq[params.RecordKey]) (Assignment, error) {
+	result := Assignment{}
+
+	for record := range records {
+		response, err := szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzNoFlags)
+		if errors.Is(err, szerror.ErrSzNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		entity := struct {
+			ResolvedEntity struct {
+				EntityID int64 `json:"ENTITY_ID"`
+			} `json:"RESOLVED_ENTITY"`
+		}{}
+
+		err = json.Unmarshal([]byte(response), &entity)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate cannot unmarshal entity of %s: %w", record, err)
+		}
+
+		result[record] = entity.ResolvedEntity.EntityID
+	}
+
+	return result, nil
+}
+
+/*
+Function Evaluate compares an actual resolution with the expected clusters.
+
+Pairwise scores count pairs of records: precision is the share of pairs in the same entity that are
+in the same cluster, and recall the share of pairs in the same cluster that are in the same entity.
+B-cubed scores average the same ratios per record, so large entities do not dominate.
+With no pairs to count, precision and recall are 1.
+
+Input
+  - truth: The expected clusters.
+  - actual: The entities, e.g. from Capture or FromSnapshot.
+
+Output
+  - The report. Over-merges and under-merges are sorted by entity ID and cluster label.
+*/
+func Evaluate(truth Truth, actual Assignment) *Report {
+	result := &Report{
+		BCubed:      Scores{F1: 0, Precision: 0, Recall: 0},
+		Missing:     []params.RecordKey{},
+		OverMerges:  []OverMerge{},
+		Pairwise:    Scores{F1: 0, Precision: 0, Recall: 0},
+		Purity:      0,
+		Records:     0,
+		UnderMerges: []UnderMerge{},
+		Unlabeled:   0,
+	}
+
+	cells := map[cell][]params.RecordKey{}
+	clusterSizes := map[string]int{}
+	entitySizes := map[int64]int{}
+
+	for _, record := range sortedRecords(truth) {
+		entityID, isPresent := actual[record]
+		if !isPresent {
+			result.Missing = append(result.Missing, record)
+
+			continue
+		}
+
+		key := cell{cluster: truth[record], entityID: entityID}
+		cells[key] = append(cells[key], record)
+		clusterSizes[key.cluster]++
+		entitySizes[entityID]++
+		result.Records++
+	}
+
+	for record := range actual {
+		if _, isPresent := truth[record]; !isPresent {
+			result.Unlabeled++
+		}
+	}
+
+	result.Pairwise = pairwiseScores(cells, clusterSizes, entitySizes)
+	result.BCubed = bCubedScores(cells, clusterSizes, entitySizes, result.Records)
+	result.Purity = purity(cells, result.Records)
+	result.OverMerges = overMerges(cells)
+	result.UnderMerges = underMerges(cells)
+
+	return result
+}
+
+/*
+Function FromSnapshot returns the assignment of the records in a snapshot, e.g. one captured from an export.
+*/
+func FromSnapshot(entities *snapshot.Snapshot) Assignment {
+	result := Assignment{}
+
+	for entity := range entities.Entities() {
+		for _, record := range entity.Records {
+			result[record] = entity.ID
+		}
+	}
+
+	return result
+}
+
+/*
+Function ReadTruth reads the expected clusters from records as JSON lines.
+
+Input
+  - reader: Records with DATA_SOURCE, RECORD_ID, and the cluster field, e.g. truthset records
+    with an added label. Blank lines are skipped.
+  - clusterField: The name of the cluster label attribute. Its value may be a string or a number.
+
+Output
+  - The truth.
+*/
+func ReadTruth(reader io.Reader, clusterField string) (Truth, error) {
+	result := Truth{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		record := struct {
+			DataSource string `json:"DATA_SOURCE"`
+			RecordID   string `json:"RECORD_ID"`
+		}{}
+		fields := map[string]json.RawMessage{}
+
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err == nil {
+			err = json.Unmarshal(scanner.Bytes(), &fields)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("evaluate cannot unmarshal line %d: %w", line, err)
+		}
+
+		// A string label is unquoted; a number is kept as written.
+		var label string
+
+		cluster := string(fields[clusterField])
+		if json.Unmarshal(fields[clusterField], &label) == nil {
+			cluster = label
+		}
+
+		if record.DataSource == "" || record.RecordID == "" || cluster == "" || cluster == "null" {
+			return nil, szerror.Wrap(
+				fmt.Errorf("%w: line %d needs DATA_SOURCE, RECORD_ID, and %s", ErrInvalidTruth, line, clusterField),
+				szerror.SzBadInputError,
+				szerror.SzError,
+			)
+		}
+
+		result[params.RecordKey{DataSource: record.DataSource, ID: record.RecordID}] = cluster
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("evaluate cannot read truth: %w", err)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method String returns the scores, then one line per over-merge and under-merge.
+*/
+func (report *Report) String() string {
+	var result strings.Builder
+
+	fmt.Fprintf(&result, "%d records scored, %d missing, %d unlabeled\n",
+		report.Records, len(report.Missing), report.Unlabeled)
+	fmt.Fprintf(&result, "pairwise: %s\n", report.Pairwise)
+	fmt.Fprintf(&result, "b-cubed:  %s\n", report.BCubed)
+	fmt.Fprintf(&result, "purity:   %.4f\n", report.Purity)
+
+	for _, overMerge := range report.OverMerges {
+		fmt.Fprintf(&result, "over-merge: entity %d has clusters %s, e.g. %s and %s\n", overMerge.EntityID,
+			strings.Join(overMerge.Clusters, ", "), overMerge.Example.A, overMerge.Example.B)
+	}
+
+	for _, underMerge := range report.UnderMerges {
+		fmt.Fprintf(&result, "under-merge: cluster %s is in entities %v, e.g. %s and %s\n", underMerge.Cluster,
+			underMerge.EntityIDs, underMerge.Example.A, underMerge.Example.B)
+	}
+
+	return result.String()
+}
+
+/*
+Method String returns the scores as "precision 0.9000 recall 0.7500 f1 0.8182".
+*/
+func (scores Scores) String() string {
+	return fmt.Sprintf("precision %.4f recall %.4f f1 %.4f", scores.Precision, scores.Recall, scores.F1)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func bCubedScores(
+	cells map[cell][]params.RecordKey,
+	clusterSizes map[string]int,
+	entitySizes map[int64]int,
+	records int,
+) Scores {
+	if records == 0 {
+		return newScores(1, 1)
+	}
+
+	var precision, recall float64
+
+	for key, members := range cells {
+		size := float64(len(members))
+		precision += size * size / float64(entitySizes[key.entityID])
+		recall += size * size / float64(clusterSizes[key.cluster])
+	}
+
+	return newScores(precision/float64(records), recall/float64(records))
+}
+
+func cellsBy[K cmp.Ordered](cells map[cell][]params.RecordKey, group func(cell) K) map[K][]cell {
+	result := map[K][]cell{}
+
+	for key := range cells {
+		result[group(key)] = append(result[group(key)], key)
+	}
+
+	for key := range result {
+		slices.SortFunc(result[key], func(a, b cell) int {
+			return cmp.Or(cmp.Compare(a.cluster, b.cluster), cmp.Compare(a.entityID, b.entityID))
+		})
+	}
+
+	return result
+}
+
+func newScores(precision float64, recall float64) Scores {
+	result := Scores{F1: 0, Precision: precision, Recall: recall}
+	if precision+recall > 0 {
+		result.F1 = 2 * precision * recall / (precision + recall)
+	}
+
+	return result
+}
+
+func overMerges(cells map[cell][]params.RecordKey) []OverMerge {
+	result := []OverMerge{}
+	byEntity := cellsBy(cells, func(key cell) int64 { return key.entityID })
+
+	for _, entityID := range slices.Sorted(maps.Keys(byEntity)) {
+		keys := byEntity[entityID]
+		if len(keys) < 2 {
+			continue
+		}
+
+		overMerge := OverMerge{
+			Clusters: []string{},
+			EntityID: entityID,
+			Example:  Pair{A: cells[keys[0]][0], B: cells[keys[1]][0]},
+		}
+
+		for _, key := range keys {
+			overMerge.Clusters = append(overMerge.Clusters, key.cluster)
+		}
+
+		result = append(result, overMerge)
+	}
+
+	return result
+}
+
+func pairs(count int) float64 {
+	return float64(count) * float64(count-1) / 2
+}
+
+func pairwiseScores(
+	cells map[cell][]params.RecordKey,
+	clusterSizes map[string]int,
+	entitySizes map[int64]int,
+) Scores {
+	var truePairs, actualPairs, matchedPairs float64
+
+	for _, members := range cells {
+		matchedPairs += pairs(len(members))
+	}
+
+	for _, size := range clusterSizes {
+		truePairs += pairs(size)
+	}
+
+	for _, size := range entitySizes {
+		actualPairs += pairs(size)
+	}
+
+	precision, recall := 1.0, 1.0
+
+	if actualPairs > 0 {
+		precision = matchedPairs / actualPairs
+	}
+
+	if truePairs > 0 {
+		recall = matchedPairs / truePairs
+	}
+
+	return newScores(precision, recall)
+}
+
+func purity(cells map[cell][]params.RecordKey, records int) float64 {
+	if records == 0 {
+		return 1
+	}
+
+	largest := map[int64]int{}
+
+	for key, members := range cells {
+		largest[key.entityID] = max(largest[key.entityID], len(members))
+	}
+
+	total := 0
+	for _, size := range largest {
+		total += size
+	}
+
+	return float64(total) / float64(records)
+}
+
+func sortedRecords(truth Truth) []params.RecordKey {
+	return slices.SortedFunc(maps.Keys(truth), func(a, b params.RecordKey) int {
+		return cmp.Or(cmp.Compare(a.DataSource, b.DataSource), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+func underMerges(cells map[cell][]params.RecordKey) []UnderMerge {
+	result := []UnderMerge{}
+	byCluster := cellsBy(cells, func(key cell) string { return key.cluster })
+
+	for _, cluster := range slices.Sorted(maps.Keys(byCluster)) {
+		keys := byCluster[cluster]
+		if len(keys) < 2 {
+			continue
+		}
+
+		underMerge := UnderMerge{
+			Cluster:   cluster,
+			EntityIDs: []int64{},
+			Example:   Pair{A: cells[keys[0]][0], B: cells[keys[1]][0]},
+		}
+
+		for _, key := range keys {
+			underMerge.EntityIDs = append(underMerge.EntityIDs, key.entityID)
+		}
+
+		result = append(result, underMerge)
+	}
+
+	return result
+}
@@ -0,0 +1,183 @@
+package evaluate_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/evaluate"
+	"github.com/senzing-garage/sz-sdk-go/params"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/snapshot"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const delta = 0.0001
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockEngine struct {
+	senzing.SzEngine
+
+	actual evaluate.Assignment
+}
+
+func (engine *mockEngine) GetEntityByRecordID(
+	_ context.Context,
+	dataSourceCode string,
+	recordID string,
+	_ int64,
+) (string, error) {
+	entityID, isPresent := engine.actual[record(dataSourceCode+":"+recordID)]
+	if !isPresent {
+		return "", szerror.New(33, "record not found")
+	}
+
+	return fmt.Sprintf(`{"RESOLVED_ENTITY": {"ENTITY_ID": %d}}`, entityID), nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Truth: X is a, b, c; Y is d, e; Z is f, h. Actual: 1 is a, b; 2 is c, d, e; 3 is f, g. h has no entity.
+func example() (evaluate.Truth, evaluate.Assignment) {
+	truth := evaluate.Truth{}
+	for key, cluster := range map[string]string{
+		"CUSTOMERS:a": "X", "CUSTOMERS:b": "X", "CUSTOMERS:c": "X",
+		"CUSTOMERS:d": "Y", "CUSTOMERS:e": "Y",
+		"CUSTOMERS:f": "Z", "CUSTOMERS:h": "Z",
+	} {
+		truth[record(key)] = cluster
+	}
+
+	actual := evaluate.Assignment{}
+	for key, entityID := range map[string]int64{
+		"CUSTOMERS:a": 1, "CUSTOMERS:b": 1,
+		"CUSTOMERS:c": 2, "CUSTOMERS:d": 2, "CUSTOMERS:e": 2,
+		"CUSTOMERS:f": 3, "CUSTOMERS:g": 3,
+	} {
+		actual[record(key)] = entityID
+	}
+
+	return truth, actual
+}
+
+func record(key string) params.RecordKey {
+	dataSource, recordID, _ := strings.Cut(key, ":")
+
+	return params.RecordKey{DataSource: dataSource, ID: recordID}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestEvaluate(test *testing.T) {
+	test.Parallel()
+
+	report := evaluate.Evaluate(example())
+	assert.Equal(test, 6, report.Records)
+	assert.Equal(test, []params.RecordKey{record("CUSTOMERS:h")}, report.Missing)
+	assert.Equal(test, 1, report.Unlabeled)
+
+	assert.InDelta(test, 0.5, report.Pairwise.Precision, delta)
+	assert.InDelta(test, 0.5, report.Pairwise.Recall, delta)
+	assert.InDelta(test, 0.5, report.Pairwise.F1, delta)
+	assert.InDelta(test, 14.0/18.0, report.BCubed.Precision, delta)
+	assert.InDelta(test, 14.0/18.0, report.BCubed.Recall, delta)
+	assert.InDelta(test, 14.0/18.0, report.BCubed.F1, delta)
+	assert.InDelta(test, 5.0/6.0, report.Purity, delta)
+
+	assert.Equal(test, []evaluate.OverMerge{{
+		Clusters: []string{"X", "Y"},
+		EntityID: 2,
+		Example:  evaluate.Pair{A: record("CUSTOMERS:c"), B: record("CUSTOMERS:d")},
+	}}, report.OverMerges)
+	assert.Equal(test, []evaluate.UnderMerge{{
+		Cluster:   "X",
+		EntityIDs: []int64{1, 2},
+		Example:   evaluate.Pair{A: record("CUSTOMERS:a"), B: record("CUSTOMERS:c")},
+	}}, report.UnderMerges)
+
+	assert.Equal(test, `6 records scored, 1 missing, 1 unlabeled
+pairwise: precision 0.5000 recall 0.5000 f1 0.5000
+b-cubed:  precision 0.7778 recall 0.7778 f1 0.7778
+purity:   0.8333
+over-merge: entity 2 has clusters X, Y, e.g. CUSTOMERS:c and CUSTOMERS:d
+under-merge: cluster X is in entities [1 2], e.g. CUSTOMERS:a and CUSTOMERS:c
+`, report.String())
+}
+
+func TestEvaluate_Perfect(test *testing.T) {
+	test.Parallel()
+
+	truth, _ := example()
+	actual := evaluate.Assignment{}
+
+	for key, cluster := range truth {
+		actual[key] = int64(cluster[0])
+	}
+
+	report := evaluate.Evaluate(truth, actual)
+	assert.Equal(test, evaluate.Scores{F1: 1, Precision: 1, Recall: 1}, report.Pairwise)
+	assert.Equal(test, evaluate.Scores{F1: 1, Precision: 1, Recall: 1}, report.BCubed)
+	assert.InDelta(test, 1.0, report.Purity, delta)
+	assert.Empty(test, report.OverMerges)
+	assert.Empty(test, report.UnderMerges)
+
+	report = evaluate.Evaluate(evaluate.Truth{}, evaluate.Assignment{})
+	assert.Equal(test, evaluate.Scores{F1: 1, Precision: 1, Recall: 1}, report.Pairwise)
+	assert.Equal(test, evaluate.Scores{F1: 1, Precision: 1, Recall: 1}, report.BCubed)
+}
+
+func TestCapture(test *testing.T) {
+	test.Parallel()
+
+	truth, actual := example()
+	engine := &mockEngine{actual: actual} //exhaustruct:ignore
+
+	captured, err := evaluate.Capture(test.Context(), engine, maps.Keys(truth))
+	require.NoError(test, err)
+	assert.Len(test, captured, 6)
+	assert.Equal(test, int64(2), captured[record("CUSTOMERS:d")])
+	assert.Equal(test, evaluate.Evaluate(truth, actual).Pairwise, evaluate.Evaluate(truth, captured).Pairwise)
+}
+
+func TestFromSnapshot(test *testing.T) {
+	test.Parallel()
+
+	entities := snapshot.New()
+	require.NoError(test, entities.Add(snapshot.Entity{
+		ID:        5,
+		Records:   []params.RecordKey{record("CUSTOMERS:a"), record("WATCHLIST:b")},
+		Relations: nil,
+	}))
+
+	assert.Equal(test, evaluate.Assignment{record("CUSTOMERS:a"): 5, record("WATCHLIST:b"): 5},
+		evaluate.FromSnapshot(entities))
+}
+
+func TestReadTruth(test *testing.T) {
+	test.Parallel()
+
+	truth, err := evaluate.ReadTruth(strings.NewReader(`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "CLUSTER": "A"}
+
+{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "1006", "CLUSTER": 1000000}
+`), "CLUSTER")
+	require.NoError(test, err)
+	assert.Equal(test, evaluate.Truth{record("CUSTOMERS:1001"): "A", record("WATCHLIST:1006"): "1000000"}, truth)
+
+	_, err = evaluate.ReadTruth(strings.NewReader(`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}`), "CLUSTER")
+	require.ErrorIs(test, err, evaluate.ErrInvalidTruth)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = evaluate.ReadTruth(strings.NewReader(`{`), "CLUSTER")
+	require.Error(test, err)
+}
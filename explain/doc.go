@@ -0,0 +1,22 @@
+/*
+Package explain renders the responses of the Why and How methods of senzing.SzEngine for people.
+
+Why responses (SzEngine.WhyEntities, SzEngine.WhyRecords, SzEngine.WhyRecordInEntity, and SzEngine.WhySearch)
+and How responses (SzEngine.HowEntityByEntityID) list feature scores and match keys as nested JSON.
+[Why] and [How] turn them into an [Explanation]: feature comparisons classified as matched, scored,
+or conflicted by their score bucket, and for How, the resolution steps in order and the final entities.
+
+	responseJSON, err := szEngine.WhyEntities(ctx, 1, 144, senzing.SzWhyEntitiesDefaultFlags)
+	...
+	whyEntitiesResponse, err := response.SzEngineWhyEntities(ctx, responseJSON)
+	...
+	explanation, err := explain.Why(whyEntitiesResponse)
+	...
+	fmt.Print(explanation)             // Plain text.
+	fmt.Print(explanation.Markdown())  // Markdown, with a table per comparison.
+	summary, err := explanation.JSON() // JSON, for UIs.
+
+[Why] accepts any of the typed Why responses of the response package (see [WhyResponse]),
+and [How] accepts a typedef.SzEngineHowEntityByEntityIDResponse.
+*/
+package explain
@@ -0,0 +1,367 @@
+package explain
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/internal/typedefview"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Comparison struct explains one result of a Why method: how two entities, a record and its entity,
+or a search and an entity compare.
+*/
+type Comparison struct {
+	CandidateKeys  []string       `json:"candidateKeys,omitempty"` // As "ADDR_KEY: 1515|ATL||89132".
+	EntityID       int64          `json:"entityId"`
+	EntityID2      int64          `json:"entityId2,omitempty"` // Zero for WhyRecordInEntity and WhySearch.
+	Features       []FeatureScore `json:"features"`
+	FocusRecords   []Record       `json:"focusRecords,omitempty"`
+	FocusRecords2  []Record       `json:"focusRecords2,omitempty"`
+	MatchKey       string         `json:"matchKey"`
+	MatchLevelCode string         `json:"matchLevelCode"`
+	RuleCode       string         `json:"ruleCode"`
+}
+
+/*
+Type Explanation struct is a structured explanation of a Why or How response.
+Marshaled to JSON, it is the summary returned by [Explanation.JSON].
+*/
+type Explanation struct {
+	Comparisons   []Comparison    `json:"comparisons,omitempty"`   // Why responses.
+	FinalEntities []VirtualEntity `json:"finalEntities,omitempty"` // How responses.
+	Kind          Kind            `json:"kind"`
+	Steps         []Step          `json:"steps,omitempty"` // How responses.
+}
+
+/*
+Type FeatureScore struct is the comparison of one pair of feature values.
+*/
+type FeatureScore struct {
+	Behavior  string  `json:"behavior"`  // SCORE_BEHAVIOR, e.g. "FF".
+	Bucket    string  `json:"bucket"`    // SCORE_BUCKET, e.g. "CLOSE".
+	Candidate string  `json:"candidate"` // CANDIDATE_FEAT_DESC.
+	Feature   string  `json:"feature"`   // Feature type code, e.g. "ADDRESS".
+	Inbound   string  `json:"inbound"`   // INBOUND_FEAT_DESC.
+	Outcome   Outcome `json:"outcome"`
+	Score     int64   `json:"score"`
+}
+
+/*
+Type Kind string is the kind of response explained.
+*/
+type Kind string
+
+/*
+Type Outcome string classifies a FeatureScore by its score bucket.
+*/
+type Outcome string
+
+/*
+Type Record struct identifies a record.
+*/
+type Record struct {
+	DataSource string `json:"dataSource"`
+	RecordID   string `json:"recordId"`
+}
+
+/*
+Type Step struct is one resolution step of a How response: two virtual entities combined into one.
+*/
+type Step struct {
+	Features        []FeatureScore `json:"features"`
+	InboundEntityID string         `json:"inboundEntityId"`
+	MatchKey        string         `json:"matchKey"`
+	Number          int64          `json:"number"`
+	ResultEntityID  string         `json:"resultEntityId"`
+	RuleCode        string         `json:"ruleCode"`
+	VirtualEntity1  VirtualEntity  `json:"virtualEntity1"`
+	VirtualEntity2  VirtualEntity  `json:"virtualEntity2"`
+}
+
+/*
+Type VirtualEntity struct is an intermediate entity of a How response.
+*/
+type VirtualEntity struct {
+	ID      string   `json:"id"`
+	Records []Record `json:"records"`
+}
+
+/*
+Type WhyResponse interface is satisfied by the responses of the Why methods of senzing.SzEngine,
+e.g. from response.SzEngineWhyEntities.
+*/
+type WhyResponse interface {
+	*typedef.SzEngineWhyEntitiesResponse |
+		*typedef.SzEngineWhyRecordInEntityResponse |
+		*typedef.SzEngineWhyRecordsResponse |
+		*typedef.SzEngineWhySearchResponse
+}
+
+type featureScoresJSON map[string][]struct {
+	CandidateFeatDesc string `json:"CANDIDATE_FEAT_DESC"`
+	InboundFeatDesc   string `json:"INBOUND_FEAT_DESC"`
+	Score             int64  `json:"SCORE"`
+	ScoreBehavior     string `json:"SCORE_BEHAVIOR"`
+	ScoreBucket       string `json:"SCORE_BUCKET"`
+}
+
+type candidateKeysJSON map[string][]struct {
+	FeatDesc string `json:"FEAT_DESC"`
+}
+
+type recordJSON struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+}
+
+type virtualEntityJSON struct {
+	MemberRecords []struct {
+		Records []recordJSON `json:"RECORDS"`
+	} `json:"MEMBER_RECORDS"`
+	VirtualEntityID string `json:"VIRTUAL_ENTITY_ID"`
+}
+
+// The parts of typedef.SzEngineHowEntityByEntityIDResponse used by an Explanation.
+type howView struct {
+	HowResults struct {
+		FinalState struct {
+			VirtualEntities []virtualEntityJSON `json:"VIRTUAL_ENTITIES"`
+		} `json:"FINAL_STATE"`
+		ResolutionSteps []struct {
+			InboundVirtualEntityID string `json:"INBOUND_VIRTUAL_ENTITY_ID"`
+			MatchInfo              struct {
+				ErruleCode    string            `json:"ERRULE_CODE"`
+				FeatureScores featureScoresJSON `json:"FEATURE_SCORES"`
+				MatchKey      string            `json:"MATCH_KEY"`
+			} `json:"MATCH_INFO"`
+			ResultVirtualEntityID string            `json:"RESULT_VIRTUAL_ENTITY_ID"`
+			Step                  int64             `json:"STEP"`
+			VirtualEntity1        virtualEntityJSON `json:"VIRTUAL_ENTITY_1"`
+			VirtualEntity2        virtualEntityJSON `json:"VIRTUAL_ENTITY_2"`
+		} `json:"RESOLUTION_STEPS"`
+	} `json:"HOW_RESULTS"`
+}
+
+// The parts of the Why responses used by an Explanation.
+type whyView struct {
+	WhyResults []struct {
+		EntityID      int64        `json:"ENTITY_ID"`
+		EntityID2     int64        `json:"ENTITY_ID_2"`
+		FocusRecords  []recordJSON `json:"FOCUS_RECORDS"`
+		FocusRecords2 []recordJSON `json:"FOCUS_RECORDS_2"`
+		MatchInfo     struct {
+			CandidateKeys  candidateKeysJSON `json:"CANDIDATE_KEYS"`
+			FeatureScores  featureScoresJSON `json:"FEATURE_SCORES"`
+			MatchLevelCode string            `json:"MATCH_LEVEL_CODE"`
+			WhyErruleCode  string            `json:"WHY_ERRULE_CODE"`
+			WhyKey         string            `json:"WHY_KEY"`
+		} `json:"MATCH_INFO"`
+	} `json:"WHY_RESULTS"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Kinds of responses.
+const (
+	KindHow Kind = "how"
+	KindWhy Kind = "why"
+)
+
+// Outcomes of feature comparisons.
+const (
+	OutcomeConflicted Outcome = "conflicted" // Bucket NO_CHANCE.
+	OutcomeMatched    Outcome = "matched"    // Buckets SAME and CLOSE.
+	OutcomeScored     Outcome = "scored"     // Any other bucket, e.g. LIKELY, PLAUSIBLE, or UNLIKELY.
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var bucketOutcomes = map[string]Outcome{
+	"CLOSE":     OutcomeMatched,
+	"NO_CHANCE": OutcomeConflicted,
+	"SAME":      OutcomeMatched,
+}
+
+// Outcomes in the order they are rendered.
+var outcomes = []Outcome{OutcomeMatched, OutcomeScored, OutcomeConflicted}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function How explains a response of SzEngine.HowEntityByEntityID.
+
+Input
+  - howEntityResponse: The response, e.g. from response.SzEngineHowEntityByEntityID.
+
+Output
+  - The resolution steps, in order, and the final virtual entities.
+*/
+func How(howEntityResponse *typedef.SzEngineHowEntityByEntityIDResponse) (*Explanation, error) {
+	response := howView{} //exhaustruct:ignore
+
+	err := typedefview.Decode(howEntityResponse, &response)
+	if err != nil {
+		return nil, fmt.Errorf("explain cannot read How response: %w", err)
+	}
+
+	result := &Explanation{
+		Comparisons:   nil,
+		FinalEntities: []VirtualEntity{},
+		Kind:          KindHow,
+		Steps:         []Step{},
+	}
+
+	for _, step := range response.HowResults.ResolutionSteps {
+		result.Steps = append(result.Steps, Step{
+			Features:        featureScores(step.MatchInfo.FeatureScores),
+			InboundEntityID: step.InboundVirtualEntityID,
+			MatchKey:        step.MatchInfo.MatchKey,
+			Number:          step.Step,
+			ResultEntityID:  step.ResultVirtualEntityID,
+			RuleCode:        step.MatchInfo.ErruleCode,
+			VirtualEntity1:  virtualEntity(step.VirtualEntity1),
+			VirtualEntity2:  virtualEntity(step.VirtualEntity2),
+		})
+	}
+
+	slices.SortStableFunc(result.Steps, func(a, b Step) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+
+	for _, entity := range response.HowResults.FinalState.VirtualEntities {
+		result.FinalEntities = append(result.FinalEntities, virtualEntity(entity))
+	}
+
+	return result, nil
+}
+
+/*
+Function Why explains a response of SzEngine.WhyEntities, SzEngine.WhyRecords, SzEngine.WhyRecordInEntity,
+or SzEngine.WhySearch.
+
+Input
+  - whyResponse: The response, e.g. from response.SzEngineWhyEntities. Feature scores are only present
+    if the request included senzing.SzIncludeFeatureScores.
+
+Output
+  - One Comparison per WHY_RESULTS item.
+*/
+func Why[Response WhyResponse](whyResponse Response) (*Explanation, error) {
+	response := whyView{} //exhaustruct:ignore
+
+	err := typedefview.Decode(whyResponse, &response)
+	if err != nil {
+		return nil, fmt.Errorf("explain cannot read Why response: %w", err)
+	}
+
+	result := &Explanation{
+		Comparisons:   []Comparison{},
+		FinalEntities: nil,
+		Kind:          KindWhy,
+		Steps:         nil,
+	}
+
+	for _, whyResult := range response.WhyResults {
+		matchInfo := whyResult.MatchInfo
+		result.Comparisons = append(result.Comparisons, Comparison{
+			CandidateKeys:  candidateKeys(matchInfo.CandidateKeys),
+			EntityID:       whyResult.EntityID,
+			EntityID2:      whyResult.EntityID2,
+			Features:       featureScores(matchInfo.FeatureScores),
+			FocusRecords:   records(whyResult.FocusRecords),
+			FocusRecords2:  records(whyResult.FocusRecords2),
+			MatchKey:       matchInfo.WhyKey,
+			MatchLevelCode: matchInfo.MatchLevelCode,
+			RuleCode:       matchInfo.WhyErruleCode,
+		})
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func candidateKeys(keys candidateKeysJSON) []string {
+	result := []string{}
+
+	for _, keyType := range slices.Sorted(maps.Keys(keys)) {
+		for _, key := range keys[keyType] {
+			result = append(result, keyType+": "+key.FeatDesc)
+		}
+	}
+
+	return result
+}
+
+// Sorted by outcome, then feature type, then descending score.
+func featureScores(scores featureScoresJSON) []FeatureScore {
+	result := []FeatureScore{}
+
+	for feature, values := range scores {
+		for _, value := range values {
+			outcome, isPresent := bucketOutcomes[value.ScoreBucket]
+			if !isPresent {
+				outcome = OutcomeScored
+			}
+
+			result = append(result, FeatureScore{
+				Behavior:  value.ScoreBehavior,
+				Bucket:    value.ScoreBucket,
+				Candidate: value.CandidateFeatDesc,
+				Feature:   feature,
+				Inbound:   value.InboundFeatDesc,
+				Outcome:   outcome,
+				Score:     value.Score,
+			})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b FeatureScore) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(outcomes, a.Outcome), slices.Index(outcomes, b.Outcome)),
+			cmp.Compare(a.Feature, b.Feature),
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.Inbound, b.Inbound),
+		)
+	})
+
+	return result
+}
+
+func records(values []recordJSON) []Record {
+	if len(values) == 0 {
+		return nil
+	}
+
+	result := make([]Record, 0, len(values))
+	for _, value := range values {
+		result = append(result, Record{DataSource: value.DataSource, RecordID: value.RecordID})
+	}
+
+	return result
+}
+
+func virtualEntity(value virtualEntityJSON) VirtualEntity {
+	result := VirtualEntity{ID: value.VirtualEntityID, Records: []Record{}}
+	for _, member := range value.MemberRecords {
+		result.Records = append(result.Records, records(member.Records)...)
+	}
+
+	return result
+}
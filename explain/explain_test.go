@@ -0,0 +1,213 @@
+package explain_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/explain"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const whyRecordsResponse = `{"WHY_RESULTS": [{"ENTITY_ID": 1, "ENTITY_ID_2": 2, "INTERNAL_ID": 1, "INTERNAL_ID_2": 2,
+	"FOCUS_RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}],
+	"FOCUS_RECORDS_2": [{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "2001"}],
+	"MATCH_INFO": {"MATCH_LEVEL_CODE": "POSSIBLY_SAME", "WHY_ERRULE_CODE": "CNAME", "WHY_KEY": "+NAME-DOB",
+		"CANDIDATE_KEYS": {"NAME_KEY": [{"FEAT_DESC": "RPRT|SM0", "FEAT_ID": 5}]},
+		"FEATURE_SCORES": {
+			"DOB": [{"CANDIDATE_FEAT_DESC": "1/2/1980", "INBOUND_FEAT_DESC": "3/4/1990", "SCORE": 10,
+				"SCORE_BEHAVIOR": "FMES", "SCORE_BUCKET": "NO_CHANCE"}],
+			"NAME": [{"CANDIDATE_FEAT_DESC": "Robert Smith", "INBOUND_FEAT_DESC": "Bob | Smith", "SCORE": 90,
+				"SCORE_BEHAVIOR": "NAME", "SCORE_BUCKET": "CLOSE"}],
+			"PHONE": [{"CANDIDATE_FEAT_DESC": "702-555-1212", "INBOUND_FEAT_DESC": "702-555-1213", "SCORE": 80,
+				"SCORE_BEHAVIOR": "FF", "SCORE_BUCKET": "LIKELY"}]
+		}}}]}`
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func explainWhyRecords(t *testing.T, responseJSON string) *explain.Explanation {
+	t.Helper()
+
+	whyRecordsResponse, err := response.SzEngineWhyRecords(t.Context(), responseJSON)
+	require.NoError(t, err)
+
+	explanation, err := explain.Why(whyRecordsResponse)
+	require.NoError(t, err)
+
+	return explanation
+}
+
+func readResponses(t *testing.T, fileName string) []string {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", fileName))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	result := []string{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestHow(test *testing.T) {
+	test.Parallel()
+	responses := readResponses(test, "SzEngineHowEntityByEntityIdResponse.jsonl")
+	howEntityResponse, err := response.SzEngineHowEntityByEntityID(test.Context(), responses[0])
+	require.NoError(test, err)
+	explanation, err := explain.How(howEntityResponse)
+	require.NoError(test, err)
+	assert.Equal(test, explain.KindHow, explanation.Kind)
+	require.Len(test, explanation.Steps, 3)
+
+	step := explanation.Steps[0]
+	assert.Equal(test, int64(1), step.Number)
+	assert.Equal(test, "V2", step.VirtualEntity1.ID)
+	assert.Equal(test, "V4", step.VirtualEntity2.ID)
+	assert.Equal(test, "V2-S1", step.ResultEntityID)
+	assert.Equal(test, "+NAME+DOB+ADDRESS", step.MatchKey)
+	assert.Equal(test, "CNAME_CFF_CEXCL", step.RuleCode)
+
+	require.Len(test, explanation.FinalEntities, 1)
+	assert.Len(test, explanation.FinalEntities[0].Records, 4)
+
+	text := explanation.String()
+	assert.Contains(test, text, "Step 1: V2 + V4 -> V2-S1: match key +NAME+DOB+ADDRESS, rule CNAME_CFF_CEXCL\n")
+	assert.Contains(test, text, "  V1-S3: CUSTOMERS:1001, CUSTOMERS:1002, CUSTOMERS:1003, CUSTOMERS:1004\n")
+	assert.Contains(test, explanation.Markdown(), "## Step 3: V1 + V2-S2 -> V1-S3\n")
+}
+
+func TestHow_Nil(test *testing.T) {
+	test.Parallel()
+	explanation, err := explain.How(nil)
+	require.NoError(test, err)
+	assert.Empty(test, explanation.Steps)
+	assert.Empty(test, explanation.FinalEntities)
+}
+
+func TestWhy(test *testing.T) {
+	test.Parallel()
+	explanation := explainWhyRecords(test, whyRecordsResponse)
+	assert.Equal(test, explain.KindWhy, explanation.Kind)
+	require.Len(test, explanation.Comparisons, 1)
+
+	comparison := explanation.Comparisons[0]
+	assert.Equal(test, []string{"NAME_KEY: RPRT|SM0"}, comparison.CandidateKeys)
+	assert.Equal(test, "POSSIBLY_SAME", comparison.MatchLevelCode)
+
+	outcomes := []explain.Outcome{}
+	for _, feature := range comparison.Features {
+		outcomes = append(outcomes, feature.Outcome)
+	}
+
+	assert.Equal(test, []explain.Outcome{explain.OutcomeMatched, explain.OutcomeScored, explain.OutcomeConflicted},
+		outcomes)
+}
+
+func TestWhy_Nil(test *testing.T) {
+	test.Parallel()
+	explanation, err := explain.Why[*typedef.SzEngineWhySearchResponse](nil)
+	require.NoError(test, err)
+	assert.Equal(test, explain.KindWhy, explanation.Kind)
+	assert.Empty(test, explanation.Comparisons)
+}
+
+func TestWhy_Fixtures(test *testing.T) {
+	test.Parallel()
+
+	parsers := map[string]func(string) (*explain.Explanation, error){
+		"SzEngineWhyEntitiesResponse.jsonl": func(responseJSON string) (*explain.Explanation, error) {
+			whyResponse, err := response.SzEngineWhyEntities(test.Context(), responseJSON)
+			require.NoError(test, err)
+
+			return explain.Why(whyResponse)
+		},
+		"SzEngineWhyRecordInEntityResponse.jsonl": func(responseJSON string) (*explain.Explanation, error) {
+			whyResponse, err := response.SzEngineWhyRecordInEntity(test.Context(), responseJSON)
+			require.NoError(test, err)
+
+			return explain.Why(whyResponse)
+		},
+		"SzEngineWhySearchResponse.jsonl": func(responseJSON string) (*explain.Explanation, error) {
+			whyResponse, err := response.SzEngineWhySearch(test.Context(), responseJSON)
+			require.NoError(test, err)
+
+			return explain.Why(whyResponse)
+		},
+	}
+
+	for fileName, parse := range parsers {
+		for _, responseJSON := range readResponses(test, fileName) {
+			explanation, err := parse(responseJSON)
+			require.NoError(test, err)
+			assert.Equal(test, len(explanation.Comparisons), strings.Count(explanation.String(), "Why "))
+			assert.Equal(test, len(explanation.Comparisons), strings.Count(explanation.Markdown(), "## Why "))
+		}
+	}
+}
+
+func TestExplanation_JSON(test *testing.T) {
+	test.Parallel()
+	explanation := explainWhyRecords(test, whyRecordsResponse)
+
+	summary, err := explanation.JSON()
+	require.NoError(test, err)
+
+	decoded := explain.Explanation{} //exhaustruct:ignore
+	require.NoError(test, json.Unmarshal([]byte(summary), &decoded))
+	assert.Equal(test, *explanation, decoded)
+}
+
+func TestExplanation_Markdown(test *testing.T) {
+	test.Parallel()
+	explanation := explainWhyRecords(test, whyRecordsResponse)
+
+	markdown := explanation.Markdown()
+	assert.Contains(test, markdown, "## Why record CUSTOMERS:1001 (entity 1) and record WATCHLIST:2001 (entity 2)\n")
+	assert.Contains(test, markdown, "- Match key: `+NAME-DOB`\n")
+	assert.Contains(test, markdown, "| matched | NAME | Bob \\| Smith | Robert Smith | 90 | CLOSE |\n")
+	assert.Contains(test, markdown, "| conflicted | DOB | 3/4/1990 | 1/2/1980 | 10 | NO\\_CHANCE |\n")
+}
+
+func TestExplanation_String(test *testing.T) {
+	test.Parallel()
+	explanation := explainWhyRecords(test, whyRecordsResponse)
+
+	lines := strings.Split(explanation.String(), "\n")
+	assert.Equal(test, []string{
+		"Why record CUSTOMERS:1001 (entity 1) and record WATCHLIST:2001 (entity 2): " +
+			"POSSIBLY_SAME, match key +NAME-DOB, rule CNAME",
+		"  Matched:",
+		`    NAME 90 CLOSE: "Bob | Smith" vs "Robert Smith"`,
+		"  Scored:",
+		`    PHONE 80 LIKELY: "702-555-1213" vs "702-555-1212"`,
+		"  Conflicted:",
+		`    DOB 10 NO_CHANCE: "3/4/1990" vs "1/2/1980"`,
+		"",
+	}, lines)
+
+	explanation = explainWhyRecords(test, `{"WHY_RESULTS": [{"ENTITY_ID": 7, "MATCH_INFO": {}}]}`)
+	assert.Equal(test, "Why the search matched entity 7: no match\n", explanation.String())
+}
@@ -0,0 +1,217 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method JSON returns the explanation as JSON, for use by UIs.
+*/
+func (explanation *Explanation) JSON() (string, error) {
+	result, err := json.Marshal(explanation)
+	if err != nil {
+		return "", fmt.Errorf("explain cannot marshal explanation: %w", err)
+	}
+
+	return string(result), nil
+}
+
+/*
+Method Markdown returns the explanation as Markdown, with a table of feature scores per comparison or step.
+*/
+func (explanation *Explanation) Markdown() string {
+	var result strings.Builder
+
+	for _, comparison := range explanation.Comparisons {
+		fmt.Fprintf(&result, "## Why %s\n\n", comparison.subject())
+		writeMarkdownMatch(&result, comparison.MatchLevelCode, comparison.MatchKey, comparison.RuleCode)
+		writeMarkdownFeatures(&result, comparison.Features)
+	}
+
+	for _, step := range explanation.Steps {
+		fmt.Fprintf(&result, "## Step %d: %s\n\n", step.Number, step.summary())
+		writeMarkdownMatch(&result, "", step.MatchKey, step.RuleCode)
+		writeMarkdownFeatures(&result, step.Features)
+	}
+
+	if len(explanation.FinalEntities) > 0 {
+		result.WriteString("## Final entities\n\n")
+
+		for _, entity := range explanation.FinalEntities {
+			fmt.Fprintf(&result, "- **%s**: %s\n", markdownEscape(entity.ID), markdownEscape(recordList(entity.Records)))
+		}
+
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+/*
+Method String returns the explanation as plain text. Feature scores are grouped by outcome.
+*/
+func (explanation *Explanation) String() string {
+	var result strings.Builder
+
+	for _, comparison := range explanation.Comparisons {
+		fmt.Fprintf(&result, "Why %s: %s\n", comparison.subject(),
+			matchSummary(comparison.MatchLevelCode, comparison.MatchKey, comparison.RuleCode))
+		writeTextFeatures(&result, comparison.Features)
+	}
+
+	for _, step := range explanation.Steps {
+		fmt.Fprintf(&result, "Step %d: %s: %s\n", step.Number, step.summary(),
+			matchSummary("", step.MatchKey, step.RuleCode))
+		writeTextFeatures(&result, step.Features)
+	}
+
+	if len(explanation.FinalEntities) > 0 {
+		result.WriteString("Final entities:\n")
+
+		for _, entity := range explanation.FinalEntities {
+			fmt.Fprintf(&result, "  %s: %s\n", entity.ID, recordList(entity.Records))
+		}
+	}
+
+	return result.String()
+}
+
+/*
+Method String returns the record as "DATA_SOURCE:RECORD_ID".
+*/
+func (record Record) String() string {
+	return record.DataSource + ":" + record.RecordID
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (comparison Comparison) subject() string {
+	switch {
+	case len(comparison.FocusRecords) > 0 && len(comparison.FocusRecords2) > 0:
+		return fmt.Sprintf("record %s (entity %d) and record %s (entity %d)",
+			recordList(comparison.FocusRecords), comparison.EntityID,
+			recordList(comparison.FocusRecords2), comparison.EntityID2)
+	case len(comparison.FocusRecords) > 0:
+		return fmt.Sprintf("record %s is in entity %d", recordList(comparison.FocusRecords), comparison.EntityID)
+	case comparison.EntityID2 != 0:
+		return fmt.Sprintf("entity %d and entity %d", comparison.EntityID, comparison.EntityID2)
+	default:
+		return fmt.Sprintf("the search matched entity %d", comparison.EntityID)
+	}
+}
+
+func (step Step) summary() string {
+	return fmt.Sprintf("%s + %s -> %s", step.VirtualEntity1.ID, step.VirtualEntity2.ID, step.ResultEntityID)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Escapes characters that would break a Markdown table cell or add formatting.
+func markdownEscape(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "\n", " ")
+
+	return replacer.Replace(value)
+}
+
+// Formats e.g. "POSSIBLY_RELATED, match key +SURNAME+ADDRESS, rule CFF_SURNAME".
+func matchSummary(matchLevelCode string, matchKey string, ruleCode string) string {
+	parts := []string{}
+
+	if matchLevelCode != "" {
+		parts = append(parts, matchLevelCode)
+	}
+
+	if matchKey != "" {
+		parts = append(parts, "match key "+matchKey)
+	}
+
+	if ruleCode != "" {
+		parts = append(parts, "rule "+ruleCode)
+	}
+
+	if len(parts) == 0 {
+		return "no match"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func recordList(records []Record) string {
+	parts := make([]string, 0, len(records))
+	for _, record := range records {
+		parts = append(parts, record.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func writeMarkdownFeatures(builder *strings.Builder, features []FeatureScore) {
+	if len(features) == 0 {
+		return
+	}
+
+	builder.WriteString("| Outcome | Feature | Inbound | Candidate | Score | Bucket |\n")
+	builder.WriteString("| --- | --- | --- | --- | ---: | --- |\n")
+
+	for _, feature := range features {
+		fmt.Fprintf(builder, "| %s | %s | %s | %s | %d | %s |\n",
+			feature.Outcome,
+			markdownEscape(feature.Feature),
+			markdownEscape(feature.Inbound),
+			markdownEscape(feature.Candidate),
+			feature.Score,
+			markdownEscape(feature.Bucket),
+		)
+	}
+
+	builder.WriteString("\n")
+}
+
+func writeMarkdownMatch(builder *strings.Builder, matchLevelCode string, matchKey string, ruleCode string) {
+	if matchLevelCode != "" {
+		fmt.Fprintf(builder, "- Match level: %s\n", markdownEscape(matchLevelCode))
+	}
+
+	if matchKey != "" {
+		fmt.Fprintf(builder, "- Match key: `%s`\n", matchKey)
+	}
+
+	if ruleCode != "" {
+		fmt.Fprintf(builder, "- Rule: %s\n", markdownEscape(ruleCode))
+	}
+
+	builder.WriteString("\n")
+}
+
+func writeTextFeatures(builder *strings.Builder, features []FeatureScore) {
+	for _, outcome := range outcomes {
+		isFirst := true
+
+		for _, feature := range features {
+			if feature.Outcome != outcome {
+				continue
+			}
+
+			if isFirst {
+				fmt.Fprintf(builder, "  %s%s:\n", strings.ToUpper(string(outcome[:1])), outcome[1:])
+
+				isFirst = false
+			}
+
+			fmt.Fprintf(builder, "    %s %d %s: %s vs %s\n",
+				feature.Feature, feature.Score, feature.Bucket,
+				strconv.Quote(feature.Inbound), strconv.Quote(feature.Candidate))
+		}
+	}
+}
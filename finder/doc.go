@@ -0,0 +1,21 @@
+/*
+Package finder is an options-based layer over the FindPath and FindNetwork methods of senzing.SzEngine.
+
+SzEngine.FindPathByRecordID takes nine positional parameters, mostly strings and int64s,
+so swapped arguments compile and fail quietly. [FindPath] and [FindNetwork] take named options instead,
+build the JSON list parameters with the params package, and choose the ByEntityID or ByRecordID method
+from the kind of [Endpoint]:
+
+	result, err := finder.FindPath(ctx, szEngine,
+		finder.From(finder.Record("CUSTOMERS", "1001")),
+		finder.To(finder.Record("WATCHLIST", "2001")),
+		finder.MaxDegrees(3),
+		finder.Avoid(finder.Record("CUSTOMERS", "1009")),
+		finder.Require("WATCHLIST"),
+	)
+
+Options that are not given default to senzing.SzNoAvoidance, senzing.SzNoRequiredDatasources,
+senzing.SzFindPathDefaultFlags (or senzing.SzFindNetworkDefaultFlags), and the Default constants.
+Any senzing.SzEngine implementation can be used.
+*/
+package finder
@@ -0,0 +1,393 @@
+package finder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/senzing-garage/sz-sdk-go/params"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Endpoint struct is an entity or a record. Create one with [Entity] or [Record].
+*/
+type Endpoint struct {
+	entityID  int64
+	isRecord  bool
+	recordKey params.RecordKey
+}
+
+/*
+Type Option func configures a call to [FindPath] or [FindNetwork].
+*/
+type Option func(request *request)
+
+type request struct {
+	among               []Endpoint
+	avoid               []Endpoint
+	buildOutDegrees     int64
+	buildOutMaxEntities int64
+	flags               int64
+	from                *Endpoint
+	hasBuildOut         bool
+	maxDegrees          int64
+	require             []string
+	to                  *Endpoint
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Defaults for options that are not given.
+const (
+	DefaultBuildOutDegrees     int64 = 1
+	DefaultBuildOutMaxEntities int64 = 10
+	DefaultMaxDegrees          int64 = 3
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidOptions is returned when options are missing, conflicting, or not supported by the call.
+var ErrInvalidOptions = errors.New("invalid find options")
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Among sets the entities or records whose network FindNetwork finds.
+They must all be entities or all be records.
+*/
+func Among(endpoints ...Endpoint) Option {
+	return func(request *request) {
+		request.among = append(request.among, endpoints...)
+	}
+}
+
+/*
+Function Avoid sets the entities or records FindPath avoids.
+They must be the same kind as the From and To endpoints.
+*/
+func Avoid(endpoints ...Endpoint) Option {
+	return func(request *request) {
+		request.avoid = append(request.avoid, endpoints...)
+	}
+}
+
+/*
+Function BuildOut sets how far FindNetwork builds out from the entities on the paths.
+Without BuildOut, DefaultBuildOutDegrees and DefaultBuildOutMaxEntities are used.
+*/
+func BuildOut(degrees int64, maxEntities int64) Option {
+	return func(request *request) {
+		request.buildOutDegrees = degrees
+		request.buildOutMaxEntities = maxEntities
+		request.hasBuildOut = true
+	}
+}
+
+/*
+Function Entity returns an Endpoint for an entity.
+*/
+func Entity(entityID int64) Endpoint {
+	return Endpoint{entityID: entityID, isRecord: false, recordKey: params.RecordKey{DataSource: "", ID: ""}}
+}
+
+/*
+Function FindNetwork finds the network among entities or records with SzEngine.FindNetworkByEntityID
+or SzEngine.FindNetworkByRecordID.
+
+Options Among (required), MaxDegrees, BuildOut, and WithFlags are supported.
+Flags default to senzing.SzFindNetworkDefaultFlags.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - options: The network to find.
+
+Output
+  - The engine's JSON response.
+*/
+func FindNetwork(ctx context.Context, szEngine senzing.SzEngine, options ...Option) (string, error) {
+	request := newRequest(senzing.SzFindNetworkDefaultFlags, options)
+
+	switch {
+	case request.from != nil || request.to != nil:
+		return "", invalidOptions("FindNetwork does not support From or To; use Among")
+	case len(request.avoid) > 0 || len(request.require) > 0:
+		return "", invalidOptions("FindNetwork does not support Avoid or Require")
+	case len(request.among) == 0:
+		return "", invalidOptions("FindNetwork requires Among")
+	}
+
+	isRecords, err := sameKind(request.among...)
+	if err != nil {
+		return "", err
+	}
+
+	if isRecords {
+		recordKeys, err := params.RecordKeys(endpointRecordKeys(request.among)...)
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+
+		return szEngine.FindNetworkByRecordID( //nolint:wrapcheck
+			ctx,
+			recordKeys,
+			request.maxDegrees,
+			request.buildOutDegrees,
+			request.buildOutMaxEntities,
+			request.flags,
+		)
+	}
+
+	entityIDs, err := params.EntityIDs(endpointEntityIDs(request.among)...)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return szEngine.FindNetworkByEntityID( //nolint:wrapcheck
+		ctx,
+		entityIDs,
+		request.maxDegrees,
+		request.buildOutDegrees,
+		request.buildOutMaxEntities,
+		request.flags,
+	)
+}
+
+/*
+Function FindPath finds a path between two entities or two records with SzEngine.FindPathByEntityID
+or SzEngine.FindPathByRecordID.
+
+Options From and To (required), MaxDegrees, Avoid, Require, and WithFlags are supported.
+Avoidance and required data sources default to senzing.SzNoAvoidance and senzing.SzNoRequiredDatasources.
+Flags default to senzing.SzFindPathDefaultFlags.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - options: The path to find.
+
+Output
+  - The engine's JSON response.
+*/
+func FindPath(ctx context.Context, szEngine senzing.SzEngine, options ...Option) (string, error) {
+	request := newRequest(senzing.SzFindPathDefaultFlags, options)
+
+	switch {
+	case len(request.among) > 0 || request.hasBuildOut:
+		return "", invalidOptions("FindPath does not support Among or BuildOut; use From and To")
+	case request.from == nil || request.to == nil:
+		return "", invalidOptions("FindPath requires From and To")
+	}
+
+	isRecords, err := sameKind(append([]Endpoint{*request.from, *request.to}, request.avoid...)...)
+	if err != nil {
+		return "", err
+	}
+
+	requiredDataSources, err := params.DataSources(request.require...)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if isRecords {
+		return findPathByRecordID(ctx, szEngine, request, requiredDataSources)
+	}
+
+	avoidEntityIDs, err := params.AvoidEntityIDs(endpointEntityIDs(request.avoid)...)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return szEngine.FindPathByEntityID( //nolint:wrapcheck
+		ctx,
+		request.from.entityID,
+		request.to.entityID,
+		request.maxDegrees,
+		avoidEntityIDs,
+		requiredDataSources,
+		request.flags,
+	)
+}
+
+/*
+Function From sets the start of the path.
+*/
+func From(endpoint Endpoint) Option {
+	return func(request *request) {
+		request.from = &endpoint
+	}
+}
+
+/*
+Function MaxDegrees sets the maximum number of degrees of separation.
+Without MaxDegrees, DefaultMaxDegrees is used.
+*/
+func MaxDegrees(maxDegrees int64) Option {
+	return func(request *request) {
+		request.maxDegrees = maxDegrees
+	}
+}
+
+/*
+Function Record returns an Endpoint for a record.
+*/
+func Record(dataSourceCode string, recordID string) Endpoint {
+	return Endpoint{entityID: 0, isRecord: true, recordKey: params.RecordKey{DataSource: dataSourceCode, ID: recordID}}
+}
+
+/*
+Function Require sets data sources that must be on the path.
+*/
+func Require(dataSourceCodes ...string) Option {
+	return func(request *request) {
+		request.require = append(request.require, dataSourceCodes...)
+	}
+}
+
+/*
+Function To sets the end of the path.
+*/
+func To(endpoint Endpoint) Option {
+	return func(request *request) {
+		request.to = &endpoint
+	}
+}
+
+/*
+Function WithFlags sets the flags, replacing the default flags.
+*/
+func WithFlags(flags int64) Option {
+	return func(request *request) {
+		request.flags = flags
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method IsRecord returns true if the endpoint is a record.
+*/
+func (endpoint Endpoint) IsRecord() bool {
+	return endpoint.isRecord
+}
+
+/*
+Method String returns the entity ID, or the record key as "DATA_SOURCE:RECORD_ID".
+*/
+func (endpoint Endpoint) String() string {
+	if endpoint.IsRecord() {
+		return endpoint.recordKey.String()
+	}
+
+	return strconv.FormatInt(endpoint.entityID, 10)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func endpointEntityIDs(endpoints []Endpoint) []int64 {
+	result := make([]int64, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		result = append(result, endpoint.entityID)
+	}
+
+	return result
+}
+
+func endpointRecordKeys(endpoints []Endpoint) []params.RecordKey {
+	result := make([]params.RecordKey, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		result = append(result, endpoint.recordKey)
+	}
+
+	return result
+}
+
+func findPathByRecordID(
+	ctx context.Context,
+	szEngine senzing.SzEngine,
+	request *request,
+	requiredDataSources string,
+) (string, error) {
+	// Round trip through params to validate and normalize the start and end records.
+	recordKeys, err := params.RecordKeys(request.from.recordKey, request.to.recordKey)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	normalized, err := params.ParseRecordKeys(recordKeys)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	avoidRecordKeys, err := params.AvoidRecordKeys(endpointRecordKeys(request.avoid)...)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return szEngine.FindPathByRecordID( //nolint:wrapcheck
+		ctx,
+		normalized[0].DataSource,
+		normalized[0].ID,
+		normalized[1].DataSource,
+		normalized[1].ID,
+		request.maxDegrees,
+		avoidRecordKeys,
+		requiredDataSources,
+		request.flags,
+	)
+}
+
+func invalidOptions(reason string) error {
+	return szerror.Wrap(
+		fmt.Errorf("%w: %s", ErrInvalidOptions, reason),
+		szerror.SzBadInputError,
+		szerror.SzError,
+	)
+}
+
+func newRequest(flags int64, options []Option) *request {
+	//exhaustruct:ignore
+	result := &request{
+		buildOutDegrees:     DefaultBuildOutDegrees,
+		buildOutMaxEntities: DefaultBuildOutMaxEntities,
+		flags:               flags,
+		maxDegrees:          DefaultMaxDegrees,
+	}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	return result
+}
+
+// Reports whether the endpoints are records, or an error if entities and records are mixed.
+func sameKind(endpoints ...Endpoint) (bool, error) {
+	isRecords := endpoints[0].IsRecord()
+
+	for _, endpoint := range endpoints[1:] {
+		if endpoint.IsRecord() != isRecords {
+			return false, invalidOptions(fmt.Sprintf("%s and %s are not both entities or both records",
+				endpoints[0], endpoint))
+		}
+	}
+
+	return isRecords, nil
+}
@@ -0,0 +1,240 @@
+package finder_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/finder"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockEngine struct {
+	senzing.SzEngine
+
+	arguments []any
+	method    string
+}
+
+func (engine *mockEngine) FindNetworkByEntityID(
+	_ context.Context,
+	entityIDs string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	engine.method = "FindNetworkByEntityID"
+	engine.arguments = []any{entityIDs, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags}
+
+	return "{}", nil
+}
+
+func (engine *mockEngine) FindNetworkByRecordID(
+	_ context.Context,
+	recordKeys string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	engine.method = "FindNetworkByRecordID"
+	engine.arguments = []any{recordKeys, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags}
+
+	return "{}", nil
+}
+
+func (engine *mockEngine) FindPathByEntityID(
+	_ context.Context,
+	startEntityID int64,
+	endEntityID int64,
+	maxDegrees int64,
+	avoidEntityIDs string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	engine.method = "FindPathByEntityID"
+	engine.arguments = []any{startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags}
+
+	return "{}", nil
+}
+
+func (engine *mockEngine) FindPathByRecordID(
+	_ context.Context,
+	startDataSourceCode string,
+	startRecordID string,
+	endDataSourceCode string,
+	endRecordID string,
+	maxDegrees int64,
+	avoidRecordKeys string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	engine.method = "FindPathByRecordID"
+	engine.arguments = []any{
+		startDataSourceCode,
+		startRecordID,
+		endDataSourceCode,
+		endRecordID,
+		maxDegrees,
+		avoidRecordKeys,
+		requiredDataSources,
+		flags,
+	}
+
+	return "{}", nil
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestFindPath_Defaults(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	engine := &mockEngine{} //exhaustruct:ignore
+
+	_, err := finder.FindPath(ctx, engine, finder.From(finder.Entity(1)), finder.To(finder.Entity(2)))
+	require.NoError(test, err)
+	assert.Equal(test, "FindPathByEntityID", engine.method)
+	assert.Equal(test, []any{
+		int64(1),
+		int64(2),
+		finder.DefaultMaxDegrees,
+		senzing.SzNoAvoidance,
+		senzing.SzNoRequiredDatasources,
+		senzing.SzFindPathDefaultFlags,
+	}, engine.arguments)
+}
+
+func TestFindPath_Entities(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	engine := &mockEngine{} //exhaustruct:ignore
+
+	_, err := finder.FindPath(ctx, engine,
+		finder.From(finder.Entity(1)),
+		finder.To(finder.Entity(2)),
+		finder.MaxDegrees(5),
+		finder.Avoid(finder.Entity(3), finder.Entity(4)),
+		finder.Require("customers"),
+		finder.WithFlags(senzing.SzFindPathStrictAvoid),
+	)
+	require.NoError(test, err)
+	assert.Equal(test, []any{
+		int64(1),
+		int64(2),
+		int64(5),
+		`{"ENTITIES":[{"ENTITY_ID":3},{"ENTITY_ID":4}]}`,
+		`{"DATA_SOURCES":["CUSTOMERS"]}`,
+		senzing.SzFindPathStrictAvoid,
+	}, engine.arguments)
+}
+
+func TestFindPath_Records(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	engine := &mockEngine{} //exhaustruct:ignore
+
+	_, err := finder.FindPath(ctx, engine,
+		finder.From(finder.Record("customers", "1001")),
+		finder.To(finder.Record("WATCHLIST", "2001")),
+		finder.Avoid(finder.Record("CUSTOMERS", "1009")),
+	)
+	require.NoError(test, err)
+	assert.Equal(test, "FindPathByRecordID", engine.method)
+	assert.Equal(test, []any{
+		"CUSTOMERS",
+		"1001",
+		"WATCHLIST",
+		"2001",
+		finder.DefaultMaxDegrees,
+		`{"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1009"}]}`,
+		senzing.SzNoRequiredDatasources,
+		senzing.SzFindPathDefaultFlags,
+	}, engine.arguments)
+}
+
+func TestFindPath_InvalidOptions(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	engine := &mockEngine{} //exhaustruct:ignore
+
+	for name, options := range map[string][]finder.Option{
+		"missing To": {finder.From(finder.Entity(1))},
+		"mixed":      {finder.From(finder.Entity(1)), finder.To(finder.Record("CUSTOMERS", "1"))},
+		"mixed avoid": {
+			finder.From(finder.Entity(1)),
+			finder.To(finder.Entity(2)),
+			finder.Avoid(finder.Record("CUSTOMERS", "1")),
+		},
+		"network option": {finder.From(finder.Entity(1)), finder.To(finder.Entity(2)), finder.BuildOut(1, 1)},
+	} {
+		_, err := finder.FindPath(ctx, engine, options...)
+		require.ErrorIs(test, err, finder.ErrInvalidOptions, name)
+		require.ErrorIs(test, err, szerror.ErrSzBadInput, name)
+	}
+
+	_, err := finder.FindPath(ctx, engine, finder.From(finder.Entity(1)), finder.To(finder.Entity(2)),
+		finder.Require("bad code"))
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Empty(test, engine.method)
+}
+
+func TestFindNetwork(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	engine := &mockEngine{} //exhaustruct:ignore
+
+	_, err := finder.FindNetwork(ctx, engine, finder.Among(finder.Entity(1), finder.Entity(2)))
+	require.NoError(test, err)
+	assert.Equal(test, "FindNetworkByEntityID", engine.method)
+	assert.Equal(test, []any{
+		`{"ENTITIES":[{"ENTITY_ID":1},{"ENTITY_ID":2}]}`,
+		finder.DefaultMaxDegrees,
+		finder.DefaultBuildOutDegrees,
+		finder.DefaultBuildOutMaxEntities,
+		senzing.SzFindNetworkDefaultFlags,
+	}, engine.arguments)
+
+	_, err = finder.FindNetwork(ctx, engine,
+		finder.Among(finder.Record("CUSTOMERS", "1001")),
+		finder.MaxDegrees(2),
+		finder.BuildOut(0, 0),
+	)
+	require.NoError(test, err)
+	assert.Equal(test, "FindNetworkByRecordID", engine.method)
+	assert.Equal(test, []any{
+		`{"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}]}`,
+		int64(2),
+		int64(0),
+		int64(0),
+		senzing.SzFindNetworkDefaultFlags,
+	}, engine.arguments)
+
+	_, err = finder.FindNetwork(ctx, engine)
+	require.ErrorIs(test, err, finder.ErrInvalidOptions)
+
+	_, err = finder.FindNetwork(ctx, engine, finder.Among(finder.Entity(1)), finder.Require("CUSTOMERS"))
+	require.ErrorIs(test, err, finder.ErrInvalidOptions)
+}
+
+func TestEndpoint_String(test *testing.T) {
+	test.Parallel()
+
+	assert.Equal(test, "42", finder.Entity(42).String())
+	assert.Equal(test, "CUSTOMERS:1001", finder.Record("CUSTOMERS", "1001").String())
+	assert.True(test, finder.Record("CUSTOMERS", "1001").IsRecord())
+	assert.False(test, finder.Entity(42).IsRecord())
+}
@@ -0,0 +1,23 @@
+/*
+Package graph is an in-memory graph of entities built from engine responses.
+
+[FromResponses] merges the responses of SzEngine.FindNetworkByEntityID, SzEngine.FindPathByEntityID,
+SzEngine.GetEntityByEntityID, and the corresponding ByRecordID methods, as parsed by the response package.
+[Graph.AddNetwork], [Graph.AddPath], and [Graph.AddEntity] merge more ByEntityID responses into a graph.
+Entities become nodes; ENTITY_NETWORK_LINKS, ENTITY_PATH_LINKS, and RELATED_ENTITIES become edges
+carrying the match level code, match key, and rule code:
+
+	responseJSON, err := finder.FindNetwork(ctx, szEngine, finder.Among(finder.Entity(1), finder.Entity(100)))
+	...
+	networkResponse, err := response.SzEngineFindNetworkByEntityID(ctx, responseJSON)
+	...
+	entityGraph, err := graph.FromResponses(networkResponse)
+	...
+	path, isFound := entityGraph.FilterMatchLevels(graph.MatchLevelPossiblySame).ShortestPath(1, 100)
+
+The graph supports breadth-first traversal, shortest paths, connected components, filtering edges,
+and export to GraphML and Graphviz DOT.
+Responses must include related entity match info (e.g. senzing.SzFindNetworkIncludeMatchingInfo)
+for edges to have a match level.
+*/
+package graph
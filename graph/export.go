@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method WriteDOT writes the graph in the Graphviz DOT language.
+Nodes are labeled with the entity name and ID; edges with the match key, or the match level if there is no key.
+
+Input
+  - writer: Where to write.
+*/
+func (graph *Graph) WriteDOT(writer io.Writer) error {
+	buffer := bufio.NewWriter(writer)
+
+	fmt.Fprintln(buffer, "graph entities {")
+
+	for _, node := range graph.Nodes() {
+		fmt.Fprintf(buffer, "  %d [label=%s];\n", node.EntityID, dotQuote(nodeLabel(node)))
+	}
+
+	for _, edge := range graph.Edges() {
+		label := edge.MatchKey
+		if label == "" {
+			label = edge.MatchLevelCode
+		}
+
+		fmt.Fprintf(buffer, "  %d -- %d [label=%s, match_level=%s];\n",
+			edge.FromEntityID, edge.ToEntityID, dotQuote(label), dotQuote(edge.MatchLevelCode))
+	}
+
+	fmt.Fprintln(buffer, "}")
+
+	err := buffer.Flush()
+	if err != nil {
+		return fmt.Errorf("graph cannot write DOT: %w", err)
+	}
+
+	return nil
+}
+
+/*
+Method WriteGraphML writes the graph as undirected GraphML.
+Nodes have name and records attributes; edges have matchKey, matchLevel, ruleCode, isAmbiguous, and isDisclosed.
+
+Input
+  - writer: Where to write.
+*/
+func (graph *Graph) WriteGraphML(writer io.Writer) error {
+	buffer := bufio.NewWriter(writer)
+
+	fmt.Fprint(buffer, xml.Header)
+	fmt.Fprintln(buffer, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+
+	for _, key := range [][3]string{
+		{"node", "name", "string"},
+		{"node", "records", "string"},
+		{"edge", "matchKey", "string"},
+		{"edge", "matchLevel", "string"},
+		{"edge", "ruleCode", "string"},
+		{"edge", "isAmbiguous", "boolean"},
+		{"edge", "isDisclosed", "boolean"},
+	} {
+		fmt.Fprintf(buffer, `  <key id="%s" for="%s" attr.name="%s" attr.type="%s"/>`+"\n",
+			key[1], key[0], key[1], key[2])
+	}
+
+	fmt.Fprintln(buffer, `  <graph id="entities" edgedefault="undirected">`)
+
+	for _, node := range graph.Nodes() {
+		fmt.Fprintf(buffer, `    <node id="%d">`+"\n", node.EntityID)
+		writeGraphMLData(buffer, "name", node.Name)
+		writeGraphMLData(buffer, "records", recordSummaryString(node.RecordSummary))
+		fmt.Fprintln(buffer, `    </node>`)
+	}
+
+	for _, edge := range graph.Edges() {
+		fmt.Fprintf(buffer, `    <edge source="%d" target="%d">`+"\n", edge.FromEntityID, edge.ToEntityID)
+		writeGraphMLData(buffer, "matchKey", edge.MatchKey)
+		writeGraphMLData(buffer, "matchLevel", edge.MatchLevelCode)
+		writeGraphMLData(buffer, "ruleCode", edge.RuleCode)
+		writeGraphMLData(buffer, "isAmbiguous", strconv.FormatBool(edge.IsAmbiguous))
+		writeGraphMLData(buffer, "isDisclosed", strconv.FormatBool(edge.IsDisclosed))
+		fmt.Fprintln(buffer, `    </edge>`)
+	}
+
+	fmt.Fprintln(buffer, `  </graph>`)
+	fmt.Fprintln(buffer, `</graphml>`)
+
+	err := buffer.Flush()
+	if err != nil {
+		return fmt.Errorf("graph cannot write GraphML: %w", err)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func dotQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func nodeLabel(node Node) string {
+	if node.Name == "" {
+		return strconv.FormatInt(node.EntityID, 10)
+	}
+
+	return fmt.Sprintf("%s (%d)", node.Name, node.EntityID)
+}
+
+// Formats a record summary as "CUSTOMERS:2,WATCHLIST:1".
+func recordSummaryString(recordSummary map[string]int64) string {
+	parts := make([]string, 0, len(recordSummary))
+	for _, dataSource := range slices.Sorted(maps.Keys(recordSummary)) {
+		parts = append(parts, dataSource+":"+strconv.FormatInt(recordSummary[dataSource], 10))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Empty values are omitted.
+func writeGraphMLData(writer io.Writer, key string, value string) {
+	if value == "" {
+		return
+	}
+
+	fmt.Fprintf(writer, `      <data key="%s">`, key)
+	_ = xml.EscapeText(writer, []byte(value))
+	fmt.Fprintln(writer, `</data>`)
+}
@@ -0,0 +1,516 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/internal/typedefview"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Edge struct is a relationship between two entities.
+FromEntityID is always the smaller entity ID.
+*/
+type Edge struct {
+	FromEntityID   int64  `json:"fromEntityId"`
+	IsAmbiguous    bool   `json:"isAmbiguous"`
+	IsDisclosed    bool   `json:"isDisclosed"`
+	MatchKey       string `json:"matchKey"`       // e.g. "+NAME+ADDRESS-DOB". Empty if the response had no match info.
+	MatchLevelCode string `json:"matchLevelCode"` // e.g. MatchLevelPossiblySame. Empty if the response had no match info.
+	RuleCode       string `json:"ruleCode"`       // The ERRULE_CODE.
+	ToEntityID     int64  `json:"toEntityId"`
+}
+
+/*
+Type Graph struct is an undirected graph of entities and their relationships.
+It is not safe for concurrent modification.
+*/
+type Graph struct {
+	adjacency map[int64]map[int64]bool
+	edges     map[[2]int64]Edge
+	nodes     map[int64]Node
+}
+
+/*
+Type Node struct is an entity.
+*/
+type Node struct {
+	EntityID      int64            `json:"entityId"`
+	Name          string           `json:"name"`          // The ENTITY_NAME, if the response had one.
+	RecordSummary map[string]int64 `json:"recordSummary"` // Data source code to record count.
+}
+
+/*
+Type Response interface is satisfied by the responses that FromResponses accepts.
+*/
+type Response interface {
+	*typedef.SzEngineFindNetworkByEntityIDResponse |
+		*typedef.SzEngineFindNetworkByRecordIDResponse |
+		*typedef.SzEngineFindPathByEntityIDResponse |
+		*typedef.SzEngineFindPathByRecordIDResponse |
+		*typedef.SzEngineGetEntityByEntityIDResponse |
+		*typedef.SzEngineGetEntityByRecordIDResponse
+}
+
+type entityJSON struct {
+	EntityID      int64  `json:"ENTITY_ID"`
+	EntityName    string `json:"ENTITY_NAME"`
+	RecordSummary []struct {
+		DataSource  string `json:"DATA_SOURCE"`
+		RecordCount int64  `json:"RECORD_COUNT"`
+	} `json:"RECORD_SUMMARY"`
+}
+
+type linkJSON struct {
+	ErruleCode     string `json:"ERRULE_CODE"`
+	IsAmbiguous    int64  `json:"IS_AMBIGUOUS"`
+	IsDisclosed    int64  `json:"IS_DISCLOSED"`
+	MatchKey       string `json:"MATCH_KEY"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	MaxEntityID    int64  `json:"MAX_ENTITY_ID"`
+	MinEntityID    int64  `json:"MIN_ENTITY_ID"`
+}
+
+type relatedEntityJSON struct {
+	entityJSON
+	linkJSON
+}
+
+// The parts of the responses accepted by FromResponses that make up a Graph.
+type engineResponse struct {
+	Entities []struct {
+		RelatedEntities []relatedEntityJSON `json:"RELATED_ENTITIES"`
+		ResolvedEntity  entityJSON          `json:"RESOLVED_ENTITY"`
+	} `json:"ENTITIES"`
+	EntityNetworkLinks []linkJSON `json:"ENTITY_NETWORK_LINKS"`
+	EntityPathLinks    []linkJSON `json:"ENTITY_PATH_LINKS"`
+	EntityPaths        []struct {
+		Entities []int64 `json:"ENTITIES"`
+	} `json:"ENTITY_PATHS"`
+	RelatedEntities []relatedEntityJSON `json:"RELATED_ENTITIES"`
+	ResolvedEntity  *entityJSON         `json:"RESOLVED_ENTITY"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Match level codes, as in MATCH_LEVEL_CODE.
+const (
+	MatchLevelDisclosed       = "DISCLOSED"
+	MatchLevelNameOnly        = "NAME_ONLY"
+	MatchLevelPossiblyRelated = "POSSIBLY_RELATED"
+	MatchLevelPossiblySame    = "POSSIBLY_SAME"
+	MatchLevelResolved        = "RESOLVED"
+)
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function New creates an empty Graph.
+*/
+func New() *Graph {
+	return &Graph{
+		adjacency: map[int64]map[int64]bool{},
+		edges:     map[[2]int64]Edge{},
+		nodes:     map[int64]Node{},
+	}
+}
+
+/*
+Function FromResponses creates a Graph from engine responses.
+
+Entities come from ENTITIES, RESOLVED_ENTITY, and RELATED_ENTITIES.
+Edges come from ENTITY_NETWORK_LINKS, ENTITY_PATH_LINKS, and RELATED_ENTITIES (with match info),
+and from consecutive entities in ENTITY_PATHS (without match info, unless a link supplies it).
+
+Input
+  - responses: Responses of SzEngine.FindNetworkByEntityID, SzEngine.FindPathByEntityID,
+    SzEngine.GetEntityByEntityID, or the corresponding ByRecordID methods, e.g. from
+    response.SzEngineFindNetworkByEntityID.
+
+Output
+  - The merged graph.
+*/
+func FromResponses[R Response](responses ...R) (*Graph, error) {
+	result := New()
+
+	for _, response := range responses {
+		err := result.add(response)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method AddEdge adds an edge, adding its entities as nodes if needed.
+If the edge exists with a match level, it is kept; otherwise it is replaced.
+*/
+func (graph *Graph) AddEdge(edge Edge) {
+	if edge.FromEntityID == edge.ToEntityID {
+		return
+	}
+
+	if edge.FromEntityID > edge.ToEntityID {
+		edge.FromEntityID, edge.ToEntityID = edge.ToEntityID, edge.FromEntityID
+	}
+
+	graph.AddNode(Node{EntityID: edge.FromEntityID}) //exhaustruct:ignore
+	graph.AddNode(Node{EntityID: edge.ToEntityID})   //exhaustruct:ignore
+
+	key := [2]int64{edge.FromEntityID, edge.ToEntityID}
+	if existing, isPresent := graph.edges[key]; isPresent && existing.MatchLevelCode != "" {
+		return
+	}
+
+	graph.edges[key] = edge
+	graph.adjacency[edge.FromEntityID][edge.ToEntityID] = true
+	graph.adjacency[edge.ToEntityID][edge.FromEntityID] = true
+}
+
+/*
+Method AddEntity merges a response of SzEngine.GetEntityByEntityID into the graph, as FromResponses does.
+Information already in the graph is kept unless the response adds to it.
+*/
+func (graph *Graph) AddEntity(entityResponse *typedef.SzEngineGetEntityByEntityIDResponse) error {
+	return graph.add(entityResponse)
+}
+
+/*
+Method AddNetwork merges a response of SzEngine.FindNetworkByEntityID into the graph, as FromResponses does.
+Information already in the graph is kept unless the response adds to it.
+*/
+func (graph *Graph) AddNetwork(networkResponse *typedef.SzEngineFindNetworkByEntityIDResponse) error {
+	return graph.add(networkResponse)
+}
+
+/*
+Method AddNode adds a node. If the node exists, an empty name or record summary is filled from the new one.
+*/
+func (graph *Graph) AddNode(node Node) {
+	existing, isPresent := graph.nodes[node.EntityID]
+	if !isPresent {
+		if node.RecordSummary == nil {
+			node.RecordSummary = map[string]int64{}
+		}
+
+		graph.nodes[node.EntityID] = node
+		graph.adjacency[node.EntityID] = map[int64]bool{}
+
+		return
+	}
+
+	if existing.Name == "" {
+		existing.Name = node.Name
+	}
+
+	if len(existing.RecordSummary) == 0 && len(node.RecordSummary) > 0 {
+		existing.RecordSummary = maps.Clone(node.RecordSummary)
+	}
+
+	graph.nodes[node.EntityID] = existing
+}
+
+/*
+Method AddPath merges a response of SzEngine.FindPathByEntityID into the graph, as FromResponses does.
+Information already in the graph is kept unless the response adds to it.
+*/
+func (graph *Graph) AddPath(pathResponse *typedef.SzEngineFindPathByEntityIDResponse) error {
+	return graph.add(pathResponse)
+}
+
+/*
+Method BreadthFirst visits the entities reachable from an entity in breadth-first order.
+
+Input
+  - start: The entity to start from.
+  - visit: Called with each entity and its distance from start. Return false to stop.
+*/
+func (graph *Graph) BreadthFirst(start int64, visit func(entityID int64, depth int) bool) {
+	graph.breadthFirst(start, func(entityID int64, depth int, _ int64) bool {
+		return visit(entityID, depth)
+	})
+}
+
+/*
+Method Components returns the connected components, each sorted by entity ID,
+ordered by size (largest first) and then by smallest entity ID.
+*/
+func (graph *Graph) Components() [][]int64 {
+	seen := map[int64]bool{}
+	result := [][]int64{}
+
+	for _, entityID := range graph.entityIDs() {
+		if seen[entityID] {
+			continue
+		}
+
+		component := []int64{}
+
+		graph.BreadthFirst(entityID, func(reached int64, _ int) bool {
+			seen[reached] = true
+			component = append(component, reached)
+
+			return true
+		})
+		slices.Sort(component)
+		result = append(result, component)
+	}
+
+	slices.SortStableFunc(result, func(a, b []int64) int {
+		return cmp.Compare(len(b), len(a))
+	})
+
+	return result
+}
+
+/*
+Method Edge returns the edge between two entities, in either order.
+*/
+func (graph *Graph) Edge(entityID1 int64, entityID2 int64) (Edge, bool) {
+	result, isPresent := graph.edges[[2]int64{min(entityID1, entityID2), max(entityID1, entityID2)}]
+
+	return result, isPresent
+}
+
+/*
+Method Edges returns the edges, sorted by FromEntityID and then ToEntityID.
+*/
+func (graph *Graph) Edges() []Edge {
+	result := slices.Collect(maps.Values(graph.edges))
+	slices.SortFunc(result, func(a, b Edge) int {
+		return cmp.Or(cmp.Compare(a.FromEntityID, b.FromEntityID), cmp.Compare(a.ToEntityID, b.ToEntityID))
+	})
+
+	return result
+}
+
+/*
+Method Filter returns a new graph with every node and the edges for which keep returns true.
+*/
+func (graph *Graph) Filter(keep func(edge Edge) bool) *Graph {
+	result := New()
+
+	for _, node := range graph.nodes {
+		result.AddNode(node)
+	}
+
+	for _, edge := range graph.edges {
+		if keep(edge) {
+			result.AddEdge(edge)
+		}
+	}
+
+	return result
+}
+
+/*
+Method FilterMatchLevels returns a new graph with every node and the edges with one of the match level codes.
+
+Input
+  - matchLevelCodes: The match level codes to keep, e.g. MatchLevelPossiblySame.
+*/
+func (graph *Graph) FilterMatchLevels(matchLevelCodes ...string) *Graph {
+	return graph.Filter(func(edge Edge) bool {
+		return slices.Contains(matchLevelCodes, edge.MatchLevelCode)
+	})
+}
+
+/*
+Method Neighbors returns the entities adjacent to an entity, sorted.
+*/
+func (graph *Graph) Neighbors(entityID int64) []int64 {
+	result := slices.Collect(maps.Keys(graph.adjacency[entityID]))
+	slices.Sort(result)
+
+	return result
+}
+
+/*
+Method Node returns an entity.
+*/
+func (graph *Graph) Node(entityID int64) (Node, bool) {
+	result, isPresent := graph.nodes[entityID]
+
+	return result, isPresent
+}
+
+/*
+Method Nodes returns the entities, sorted by entity ID.
+*/
+func (graph *Graph) Nodes() []Node {
+	result := make([]Node, 0, len(graph.nodes))
+	for _, entityID := range graph.entityIDs() {
+		result = append(result, graph.nodes[entityID])
+	}
+
+	return result
+}
+
+/*
+Method ShortestPath returns a path with the fewest edges between two entities.
+
+Input
+  - from: The first entity.
+  - to: The last entity.
+
+Output
+  - The entities on the path, including from and to.
+  - False if there is no path.
+*/
+func (graph *Graph) ShortestPath(from int64, to int64) ([]int64, bool) {
+	if _, isPresent := graph.nodes[from]; !isPresent {
+		return nil, false
+	}
+
+	previous := map[int64]int64{}
+	isFound := false
+
+	graph.breadthFirst(from, func(entityID int64, _ int, parent int64) bool {
+		previous[entityID] = parent
+		isFound = entityID == to
+
+		return !isFound
+	})
+
+	if !isFound {
+		return nil, false
+	}
+
+	result := []int64{to}
+	for entityID := to; entityID != from; {
+		entityID = previous[entityID]
+		result = append(result, entityID)
+	}
+
+	slices.Reverse(result)
+
+	return result, true
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (graph *Graph) add(value any) error {
+	response := engineResponse{} //exhaustruct:ignore
+
+	err := typedefview.Decode(value, &response)
+	if err != nil {
+		return fmt.Errorf("graph cannot read response: %w", err)
+	}
+
+	if response.ResolvedEntity != nil && response.ResolvedEntity.EntityID != 0 {
+		graph.addEntity(*response.ResolvedEntity)
+		graph.addRelated(response.ResolvedEntity.EntityID, response.RelatedEntities)
+	}
+
+	for _, entity := range response.Entities {
+		graph.addEntity(entity.ResolvedEntity)
+		graph.addRelated(entity.ResolvedEntity.EntityID, entity.RelatedEntities)
+	}
+
+	for _, path := range response.EntityPaths {
+		for index := 1; index < len(path.Entities); index++ {
+			graph.AddEdge(Edge{FromEntityID: path.Entities[index-1], ToEntityID: path.Entities[index]}) //exhaustruct:ignore
+		}
+	}
+
+	for _, link := range slices.Concat(response.EntityNetworkLinks, response.EntityPathLinks) {
+		graph.AddEdge(link.edge(link.MinEntityID, link.MaxEntityID))
+	}
+
+	return nil
+}
+
+func (graph *Graph) addEntity(entity entityJSON) {
+	if entity.EntityID == 0 {
+		return
+	}
+
+	node := Node{
+		EntityID:      entity.EntityID,
+		Name:          entity.EntityName,
+		RecordSummary: make(map[string]int64, len(entity.RecordSummary)),
+	}
+
+	for _, summary := range entity.RecordSummary {
+		node.RecordSummary[summary.DataSource] = summary.RecordCount
+	}
+
+	graph.AddNode(node)
+}
+
+func (graph *Graph) addRelated(entityID int64, relatedEntities []relatedEntityJSON) {
+	for _, related := range relatedEntities {
+		graph.addEntity(related.entityJSON)
+		graph.AddEdge(related.edge(entityID, related.EntityID))
+	}
+}
+
+// Visits reachable entities with their depth and the entity they were reached from (start is its own parent).
+func (graph *Graph) breadthFirst(start int64, visit func(entityID int64, depth int, parent int64) bool) {
+	if _, isPresent := graph.nodes[start]; !isPresent {
+		return
+	}
+
+	type step struct {
+		depth    int
+		entityID int64
+		parent   int64
+	}
+
+	seen := map[int64]bool{start: true}
+	queue := []step{{depth: 0, entityID: start, parent: start}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if !visit(current.entityID, current.depth, current.parent) {
+			return
+		}
+
+		for _, neighbor := range graph.Neighbors(current.entityID) {
+			if !seen[neighbor] {
+				seen[neighbor] = true
+				queue = append(queue, step{depth: current.depth + 1, entityID: neighbor, parent: current.entityID})
+			}
+		}
+	}
+}
+
+func (graph *Graph) entityIDs() []int64 {
+	result := slices.Collect(maps.Keys(graph.nodes))
+	slices.Sort(result)
+
+	return result
+}
+
+func (link linkJSON) edge(entityID1 int64, entityID2 int64) Edge {
+	return Edge{
+		FromEntityID:   entityID1,
+		IsAmbiguous:    link.IsAmbiguous != 0,
+		IsDisclosed:    link.IsDisclosed != 0,
+		MatchKey:       link.MatchKey,
+		MatchLevelCode: link.MatchLevelCode,
+		RuleCode:       link.ErruleCode,
+		ToEntityID:     entityID2,
+	}
+}
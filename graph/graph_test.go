@@ -0,0 +1,309 @@
+package graph_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const networkResponse = `{
+	"ENTITIES": [
+		{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "ENTITY_NAME": "Robert Smith",
+			"RECORD_SUMMARY": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_COUNT": 2}]}},
+		{"RESOLVED_ENTITY": {"ENTITY_ID": 2, "ENTITY_NAME": "Bob Smith"}},
+		{"RESOLVED_ENTITY": {"ENTITY_ID": 3, "ENTITY_NAME": "Smith & \"Sons\""}},
+		{"RESOLVED_ENTITY": {"ENTITY_ID": 4, "ENTITY_NAME": "Jane Doe"}},
+		{"RESOLVED_ENTITY": {"ENTITY_ID": 5, "ENTITY_NAME": "John Doe"}}
+	],
+	"ENTITY_NETWORK_LINKS": [
+		{"ERRULE_CODE": "CNAME_CFF", "IS_AMBIGUOUS": 0, "IS_DISCLOSED": 0, "MATCH_KEY": "+NAME+ADDRESS",
+			"MATCH_LEVEL_CODE": "POSSIBLY_SAME", "MAX_ENTITY_ID": 2, "MIN_ENTITY_ID": 1},
+		{"ERRULE_CODE": "", "IS_AMBIGUOUS": 0, "IS_DISCLOSED": 1, "MATCH_KEY": "+REL_POINTER(OWNS:)",
+			"MATCH_LEVEL_CODE": "DISCLOSED", "MAX_ENTITY_ID": 3, "MIN_ENTITY_ID": 2},
+		{"ERRULE_CODE": "SFF", "IS_AMBIGUOUS": 1, "IS_DISCLOSED": 0, "MATCH_KEY": "+ADDRESS",
+			"MATCH_LEVEL_CODE": "POSSIBLY_RELATED", "MAX_ENTITY_ID": 3, "MIN_ENTITY_ID": 1},
+		{"ERRULE_CODE": "SF1", "IS_AMBIGUOUS": 0, "IS_DISCLOSED": 0, "MATCH_KEY": "+NAME",
+			"MATCH_LEVEL_CODE": "NAME_ONLY", "MAX_ENTITY_ID": 5, "MIN_ENTITY_ID": 4}
+	]
+}`
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newNetworkGraph(t *testing.T) *graph.Graph {
+	t.Helper()
+
+	result, err := graph.FromResponses(parseNetwork(t, networkResponse))
+	require.NoError(t, err)
+
+	return result
+}
+
+func parseNetwork(t *testing.T, responseJSON string) *typedef.SzEngineFindNetworkByEntityIDResponse {
+	t.Helper()
+
+	result, err := response.SzEngineFindNetworkByEntityID(t.Context(), responseJSON)
+	require.NoError(t, err)
+
+	return result
+}
+
+func readResponses(t *testing.T, fileName string) []string {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", fileName))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	result := []string{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestFromResponses(test *testing.T) {
+	test.Parallel()
+	entityGraph := newNetworkGraph(test)
+	assert.Len(test, entityGraph.Nodes(), 5)
+	assert.Len(test, entityGraph.Edges(), 4)
+
+	node, isPresent := entityGraph.Node(1)
+	require.True(test, isPresent)
+	assert.Equal(test, "Robert Smith", node.Name)
+	assert.Equal(test, map[string]int64{"CUSTOMERS": 2}, node.RecordSummary)
+
+	edge, isPresent := entityGraph.Edge(3, 2)
+	require.True(test, isPresent)
+	assert.Equal(test, int64(2), edge.FromEntityID)
+	assert.Equal(test, graph.MatchLevelDisclosed, edge.MatchLevelCode)
+	assert.True(test, edge.IsDisclosed)
+	assert.Equal(test, []int64{2, 3}, entityGraph.Neighbors(1))
+}
+
+func TestFromResponses_Nil(test *testing.T) {
+	test.Parallel()
+	entityGraph, err := graph.FromResponses[*typedef.SzEngineFindNetworkByEntityIDResponse](nil)
+	require.NoError(test, err)
+	assert.Empty(test, entityGraph.Nodes())
+}
+
+func TestGraph_AddEntity(test *testing.T) {
+	test.Parallel()
+	entityResponse, err := response.SzEngineGetEntityByEntityID(test.Context(), `{
+		"RESOLVED_ENTITY": {"ENTITY_ID": 10, "ENTITY_NAME": "A"},
+		"RELATED_ENTITIES": [{"ENTITY_ID": 11, "ENTITY_NAME": "B", "ERRULE_CODE": "SF1",
+			"IS_AMBIGUOUS": 0, "IS_DISCLOSED": 0, "MATCH_KEY": "+NAME", "MATCH_LEVEL_CODE": "NAME_ONLY"}]
+	}`)
+	require.NoError(test, err)
+
+	entityGraph := graph.New()
+	require.NoError(test, entityGraph.AddEntity(entityResponse))
+
+	edge, isPresent := entityGraph.Edge(10, 11)
+	require.True(test, isPresent)
+	assert.Equal(test, "+NAME", edge.MatchKey)
+	assert.Equal(test, "SF1", edge.RuleCode)
+
+	node, _ := entityGraph.Node(11)
+	assert.Equal(test, "B", node.Name)
+}
+
+func TestGraph_AddPath_WithoutLinks(test *testing.T) {
+	test.Parallel()
+	pathResponse, err := response.SzEngineFindPathByEntityID(test.Context(),
+		`{"ENTITY_PATHS": [{"START_ENTITY_ID": 1, "END_ENTITY_ID": 3, "ENTITIES": [1, 2, 3]}]}`)
+	require.NoError(test, err)
+
+	entityGraph := graph.New()
+	require.NoError(test, entityGraph.AddPath(pathResponse))
+
+	edge, isPresent := entityGraph.Edge(1, 2)
+	require.True(test, isPresent)
+	assert.Empty(test, edge.MatchLevelCode)
+
+	// Match info from a later response replaces the bare edge.
+	require.NoError(test, entityGraph.AddNetwork(parseNetwork(test, networkResponse)))
+	edge, _ = entityGraph.Edge(1, 2)
+	assert.Equal(test, graph.MatchLevelPossiblySame, edge.MatchLevelCode)
+}
+
+func TestFromResponses_Fixtures(test *testing.T) {
+	test.Parallel()
+
+	parsers := map[string]func(string) (*graph.Graph, error){
+		"SzEngineFindPathByEntityIdResponse.jsonl": func(responseJSON string) (*graph.Graph, error) {
+			pathResponse, err := response.SzEngineFindPathByEntityID(test.Context(), responseJSON)
+			require.NoError(test, err)
+
+			return graph.FromResponses(pathResponse)
+		},
+		"SzEngineGetEntityByEntityIdResponse.jsonl": func(responseJSON string) (*graph.Graph, error) {
+			entityResponse, err := response.SzEngineGetEntityByEntityID(test.Context(), responseJSON)
+			require.NoError(test, err)
+
+			return graph.FromResponses(entityResponse)
+		},
+		"SzEngineGetEntityByRecordIdResponse.jsonl": func(responseJSON string) (*graph.Graph, error) {
+			entityResponse, err := response.SzEngineGetEntityByRecordID(test.Context(), responseJSON)
+			require.NoError(test, err)
+
+			return graph.FromResponses(entityResponse)
+		},
+	}
+
+	for fileName, parse := range parsers {
+		for _, responseJSON := range readResponses(test, fileName) {
+			entityGraph, err := parse(responseJSON)
+			require.NoError(test, err, fileName)
+
+			paths := struct {
+				EntityPaths []struct {
+					EndEntityID   int64   `json:"END_ENTITY_ID"`
+					Entities      []int64 `json:"ENTITIES"`
+					StartEntityID int64   `json:"START_ENTITY_ID"`
+				} `json:"ENTITY_PATHS"`
+			}{}
+			require.NoError(test, json.Unmarshal([]byte(responseJSON), &paths))
+
+			for _, path := range paths.EntityPaths {
+				if len(path.Entities) == 0 {
+					continue
+				}
+
+				shortest, isFound := entityGraph.ShortestPath(path.StartEntityID, path.EndEntityID)
+				require.True(test, isFound, responseJSON)
+				assert.LessOrEqual(test, len(shortest), len(path.Entities))
+			}
+		}
+	}
+}
+
+func TestGraph_BreadthFirst(test *testing.T) {
+	test.Parallel()
+	entityGraph := newNetworkGraph(test)
+	depths := map[int64]int{}
+	entityGraph.BreadthFirst(2, func(entityID int64, depth int) bool {
+		depths[entityID] = depth
+
+		return true
+	})
+	assert.Equal(test, map[int64]int{1: 1, 2: 0, 3: 1}, depths)
+
+	visited := 0
+	entityGraph.BreadthFirst(2, func(int64, int) bool {
+		visited++
+
+		return false
+	})
+	assert.Equal(test, 1, visited)
+}
+
+func TestGraph_Components(test *testing.T) {
+	test.Parallel()
+	entityGraph := newNetworkGraph(test)
+	entityGraph.AddNode(graph.Node{EntityID: 6}) //exhaustruct:ignore
+	assert.Equal(test, [][]int64{{1, 2, 3}, {4, 5}, {6}}, entityGraph.Components())
+}
+
+func TestGraph_FilterMatchLevels(test *testing.T) {
+	test.Parallel()
+	entityGraph := newNetworkGraph(test)
+	filtered := entityGraph.FilterMatchLevels(graph.MatchLevelPossiblySame, graph.MatchLevelPossiblyRelated)
+	assert.Len(test, filtered.Nodes(), 5)
+	assert.Len(test, filtered.Edges(), 2)
+
+	_, isFound := filtered.ShortestPath(2, 3)
+	assert.True(test, isFound)
+
+	_, isFound = filtered.ShortestPath(4, 5)
+	assert.False(test, isFound)
+
+	// The original graph is unchanged.
+	assert.Len(test, entityGraph.Edges(), 4)
+}
+
+func TestGraph_ShortestPath(test *testing.T) {
+	test.Parallel()
+	entityGraph := newNetworkGraph(test)
+
+	path, isFound := entityGraph.ShortestPath(1, 3)
+	require.True(test, isFound)
+	assert.Equal(test, []int64{1, 3}, path)
+
+	path, isFound = entityGraph.Filter(func(edge graph.Edge) bool {
+		return edge.MatchLevelCode != graph.MatchLevelPossiblyRelated
+	}).ShortestPath(1, 3)
+	require.True(test, isFound)
+	assert.Equal(test, []int64{1, 2, 3}, path)
+
+	path, isFound = entityGraph.ShortestPath(1, 1)
+	require.True(test, isFound)
+	assert.Equal(test, []int64{1}, path)
+
+	_, isFound = entityGraph.ShortestPath(1, 4)
+	assert.False(test, isFound)
+
+	_, isFound = entityGraph.ShortestPath(99, 1)
+	assert.False(test, isFound)
+}
+
+func TestGraph_WriteDOT(test *testing.T) {
+	test.Parallel()
+	entityGraph := newNetworkGraph(test)
+	buffer := &bytes.Buffer{}
+	require.NoError(test, entityGraph.WriteDOT(buffer))
+
+	output := buffer.String()
+	assert.Contains(test, output, "graph entities {")
+	assert.Contains(test, output, `3 [label="Smith & \"Sons\" (3)"];`)
+	assert.Contains(test, output, `1 -- 2 [label="+NAME+ADDRESS", match_level="POSSIBLY_SAME"];`)
+}
+
+func TestGraph_WriteGraphML(test *testing.T) {
+	test.Parallel()
+	entityGraph := newNetworkGraph(test)
+	buffer := &bytes.Buffer{}
+	require.NoError(test, entityGraph.WriteGraphML(buffer))
+
+	document := struct {
+		Graph struct {
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+			Nodes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"node"`
+		} `xml:"graph"`
+	}{}
+	require.NoError(test, xml.Unmarshal(buffer.Bytes(), &document))
+	assert.Len(test, document.Graph.Nodes, 5)
+	assert.Len(test, document.Graph.Edges, 4)
+	assert.Contains(test, buffer.String(), `<data key="name">Smith &amp; &#34;Sons&#34;</data>`)
+	assert.Contains(test, buffer.String(), `<data key="records">CUSTOMERS:2</data>`)
+}
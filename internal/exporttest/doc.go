@@ -0,0 +1,5 @@
+/*
+Package exporttest provides a senzing.SzEngine test double that returns an export report
+in fragments that are not aligned with lines, as SzEngine.FetchNext does.
+*/
+package exporttest
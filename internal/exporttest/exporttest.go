@@ -0,0 +1,81 @@
+package exporttest
+
+import (
+	"context"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Engine struct returns Export from SzEngine.ExportJSONEntityReport in fragments of ChunkSize bytes.
+Other SzEngine methods panic unless a test double that embeds Engine implements them.
+*/
+type Engine struct {
+	senzing.SzEngine
+
+	ChunkSize int
+	Export    string
+	FailAfter int   // Fail the FetchNext call after this many bytes were returned. Zero never fails.
+	Flags     int64 // The flags of the last ExportJSONEntityReport call.
+	IsClosed  bool  // CloseExportReport was called.
+	fetched   int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Handle is the export handle returned by Engine.
+const Handle uintptr = 7
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method CloseExportReport records that the export was closed. Handles other than Handle are an error.
+*/
+func (engine *Engine) CloseExportReport(_ context.Context, handle uintptr) error {
+	if handle != Handle {
+		return szerror.New(7, "bad handle")
+	}
+
+	engine.IsClosed = true
+
+	return nil
+}
+
+/*
+Method ExportJSONEntityReport starts the export from the beginning.
+*/
+func (engine *Engine) ExportJSONEntityReport(_ context.Context, flags int64) (uintptr, error) {
+	engine.Flags = flags
+	engine.IsClosed = false
+	engine.fetched = 0
+
+	return Handle, nil
+}
+
+/*
+Method FetchNext returns the next fragment of Export, or "" at the end.
+*/
+func (engine *Engine) FetchNext(_ context.Context, _ uintptr) (string, error) {
+	if engine.FailAfter > 0 && engine.fetched >= engine.FailAfter {
+		return "", szerror.New(7, "connection lost")
+	}
+
+	if engine.fetched >= len(engine.Export) {
+		return "", nil
+	}
+
+	end := min(engine.fetched+engine.ChunkSize, len(engine.Export))
+	result := engine.Export[engine.fetched:end]
+	engine.fetched = end
+
+	return result, nil
+}
@@ -0,0 +1,8 @@
+/*
+Package typedefview reads the typed responses of the response package into views:
+private structs that declare only the fields a package uses.
+
+The typedef types keep the engine's JSON names, so a view uses the same JSON tags as the engine,
+and [Decode] copies a response into it through its JSON encoding.
+*/
+package typedefview
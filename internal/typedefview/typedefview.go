@@ -0,0 +1,31 @@
+package typedefview
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Decode copies a typed response into a view.
+
+Input
+  - value: The response, e.g. a *typedef.SzEngineSearchByAttributesResponse. Nil leaves the view unchanged.
+  - view: A pointer to the view.
+*/
+func Decode(value any, view any) error {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("typedefview cannot marshal response: %w", err)
+	}
+
+	err = json.Unmarshal(valueJSON, view)
+	if err != nil {
+		return fmt.Errorf("typedefview cannot read response: %w", err)
+	}
+
+	return nil
+}
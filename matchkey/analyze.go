@@ -0,0 +1,380 @@
+package matchkey
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Count struct is a value and how often it occurred.
+*/
+type Count struct {
+	Count int64  `json:"count"`
+	Value string `json:"value"`
+}
+
+/*
+Type Predicate func selects match keys.
+*/
+type Predicate func(matchKey MatchKey) bool
+
+/*
+Type Stats struct aggregates match keys. Create one with [NewStats].
+*/
+type Stats struct {
+	Annotations   map[string]int64 `json:"annotations"`   // Annotation to count.
+	Keys          map[string]int64 `json:"keys"`          // Whole match key to count.
+	Negative      map[string]int64 `json:"negative"`      // Feature type code of "-" terms to count.
+	Positive      map[string]int64 `json:"positive"`      // Feature type code of "+" terms to count.
+	Relationships map[string]int64 `json:"relationships"` // Relationship role, from either side, to count.
+	Total         int64            `json:"total"`         // Non-empty match keys added.
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The JSON keys whose values are match keys.
+var matchKeyFields = []string{"MATCH_KEY", "WHY_KEY"}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewStats creates empty Stats.
+*/
+func NewStats() *Stats {
+	return &Stats{
+		Annotations:   map[string]int64{},
+		Keys:          map[string]int64{},
+		Negative:      map[string]int64{},
+		Positive:      map[string]int64{},
+		Relationships: map[string]int64{},
+		Total:         0,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function All returns a Predicate that selects match keys selected by every predicate.
+*/
+func All(predicates ...Predicate) Predicate {
+	return func(matchKey MatchKey) bool {
+		for _, predicate := range predicates {
+			if !predicate(matchKey) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+/*
+Function Any returns a Predicate that selects match keys selected by at least one predicate.
+*/
+func Any(predicates ...Predicate) Predicate {
+	return func(matchKey MatchKey) bool {
+		for _, predicate := range predicates {
+			if predicate(matchKey) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+/*
+Function Extract returns the non-empty match keys in an engine response.
+
+Input
+  - responseJSON: Any JSON response, e.g. of SzEngine.GetEntityByEntityID, SzEngine.WhyEntities,
+    SzEngine.FindPathByEntityID, or one entity of an export. MATCH_KEY and WHY_KEY values are parsed
+    at any depth, with object keys visited in sorted order.
+
+Output
+  - The match keys.
+*/
+func Extract(responseJSON string) ([]MatchKey, error) {
+	var value any
+
+	err := json.Unmarshal([]byte(responseJSON), &value)
+	if err != nil {
+		return nil, fmt.Errorf("matchkey cannot unmarshal response: %w", err)
+	}
+
+	return extract(value)
+}
+
+/*
+Function Filter returns the responses with at least one match key selected by the predicate,
+e.g. the entities of an export that are related by a disclosed relationship.
+
+Input
+  - responses: JSON responses. See Extract.
+  - predicate: Selects match keys.
+
+Output
+  - The selected responses, in order.
+*/
+func Filter(responses []string, predicate Predicate) ([]string, error) {
+	result := []string{}
+
+	for _, response := range responses {
+		isMatch, err := Matches(response, predicate)
+		if err != nil {
+			return nil, err
+		}
+
+		if isMatch {
+			result = append(result, response)
+		}
+	}
+
+	return result, nil
+}
+
+/*
+Function Matches returns true if at least one match key in the response is selected by the predicate.
+*/
+func Matches(responseJSON string, predicate Predicate) (bool, error) {
+	matchKeys, err := Extract(responseJSON)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(matchKeys, predicate), nil
+}
+
+/*
+Function Not returns a Predicate that selects the match keys the predicate does not.
+*/
+func Not(predicate Predicate) Predicate {
+	return func(matchKey MatchKey) bool {
+		return !predicate(matchKey)
+	}
+}
+
+/*
+Function Sorted returns counts sorted by descending count, then by value.
+*/
+func Sorted(counts map[string]int64) []Count {
+	result := make([]Count, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, Count{Count: count, Value: value})
+	}
+
+	slices.SortFunc(result, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Value, b.Value))
+	})
+
+	return result
+}
+
+/*
+Function WithAnnotation returns a Predicate that selects match keys with the annotation, e.g. AnnotationAmbiguous.
+*/
+func WithAnnotation(annotation string) Predicate {
+	return func(matchKey MatchKey) bool {
+		return matchKey.HasAnnotation(annotation)
+	}
+}
+
+/*
+Function WithNegative returns a Predicate that selects match keys with a "-" term for every feature type code.
+*/
+func WithNegative(codes ...string) Predicate {
+	return func(matchKey MatchKey) bool {
+		return containsAll(matchKey.Negative(), codes)
+	}
+}
+
+/*
+Function WithPositive returns a Predicate that selects match keys with a "+" term for every feature type code.
+*/
+func WithPositive(codes ...string) Predicate {
+	return func(matchKey MatchKey) bool {
+		return containsAll(matchKey.Positive(), codes)
+	}
+}
+
+/*
+Function WithRelationship returns a Predicate that selects match keys with a relationship.
+If roles are given, the relationship must have one of them on either side.
+*/
+func WithRelationship(roles ...string) Predicate {
+	return func(matchKey MatchKey) bool {
+		for _, relationship := range matchKey.Relationships() {
+			if len(roles) == 0 || slices.Contains(roles, relationship.Role) ||
+				slices.Contains(roles, relationship.OtherRole) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Add counts match keys. Empty match keys are ignored.
+*/
+func (stats *Stats) Add(matchKeys ...MatchKey) {
+	for _, matchKey := range matchKeys {
+		if matchKey.IsEmpty() {
+			continue
+		}
+
+		stats.Total++
+		stats.Keys[matchKey.String()]++
+
+		for _, annotation := range matchKey.Annotations {
+			stats.Annotations[annotation]++
+		}
+
+		for _, code := range matchKey.Negative() {
+			stats.Negative[code]++
+		}
+
+		for _, code := range matchKey.Positive() {
+			stats.Positive[code]++
+		}
+
+		for _, relationship := range matchKey.Relationships() {
+			for _, role := range []string{relationship.Role, relationship.OtherRole} {
+				if role != "" {
+					stats.Relationships[role]++
+				}
+			}
+		}
+	}
+}
+
+/*
+Method AddResponse counts the match keys in a response. See Extract.
+*/
+func (stats *Stats) AddResponse(responseJSON string) error {
+	matchKeys, err := Extract(responseJSON)
+	if err != nil {
+		return err
+	}
+
+	stats.Add(matchKeys...)
+
+	return nil
+}
+
+/*
+Method AddStream counts the match keys in a stream of JSON values, e.g. an export written as JSON lines.
+
+Input
+  - reader: The JSON values, separated by whitespace.
+*/
+func (stats *Stats) AddStream(reader io.Reader) error {
+	decoder := json.NewDecoder(reader)
+
+	for {
+		var value any
+
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("matchkey cannot decode stream: %w", err)
+		}
+
+		matchKeys, err := extract(value)
+		if err != nil {
+			return err
+		}
+
+		stats.Add(matchKeys...)
+	}
+}
+
+/*
+Method String returns the total and the most frequent match keys, one per line.
+*/
+func (stats *Stats) String() string {
+	var result strings.Builder
+
+	fmt.Fprintf(&result, "%d match keys\n", stats.Total)
+
+	for _, count := range Sorted(stats.Keys) {
+		fmt.Fprintf(&result, "%8d %s\n", count.Count, count.Value)
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func containsAll(values []string, wanted []string) bool {
+	for _, value := range wanted {
+		if !slices.Contains(values, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func extract(value any) ([]MatchKey, error) {
+	result := []MatchKey{}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(typed)) {
+			if text, isString := typed[key].(string); isString && text != "" && slices.Contains(matchKeyFields, key) {
+				matchKey, err := Parse(text)
+				if err != nil {
+					return nil, err
+				}
+
+				result = append(result, matchKey)
+
+				continue
+			}
+
+			nested, err := extract(typed[key])
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, nested...)
+		}
+	case []any:
+		for _, item := range typed {
+			nested, err := extract(item)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, nested...)
+		}
+	}
+
+	return result, nil
+}
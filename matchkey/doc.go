@@ -0,0 +1,23 @@
+/*
+Package matchkey parses and analyzes the match keys in engine responses.
+
+A match key such as "+NAME+DOB-PHONE" lists the features that matched ("+") and that were compared
+but did not match ("-"). Terms may carry a qualifier in parentheses; for relationship features such as
+REL_POINTER the qualifier holds the disclosed roles, e.g. "+REL_POINTER(OWNS 60%:)".
+A key may end with annotations, e.g. " (Ambiguous)". [Parse] returns a [MatchKey]:
+
+	matchKey, err := matchkey.Parse("+NAME+ADDRESS-DOB")
+	...
+	matchKey.Positive() // ["NAME", "ADDRESS"]
+	matchKey.Negative() // ["DOB"]
+
+[Extract] finds the MATCH_KEY and WHY_KEY values in any entity, why, path, or export response.
+[Stats] counts keys, features, roles, and annotations across responses or an export stream,
+and [Filter] selects responses with a match key chosen by a [Predicate]:
+
+	related, err := matchkey.Filter(entities, matchkey.All(
+		matchkey.WithPositive("NAME"),
+		matchkey.Not(matchkey.WithNegative("DOB")),
+	))
+*/
+package matchkey
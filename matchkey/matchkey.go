@@ -0,0 +1,304 @@
+package matchkey
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Feature struct is one term of a match key, e.g. "+NAME", "-DOB", or "+REL_POINTER(OWNS 60%:)".
+*/
+type Feature struct {
+	Code       string // Feature type code, e.g. "NAME".
+	IsNegative bool   // True for "-" terms: the feature was compared and did not match.
+	Qualifier  string // The text in parentheses, without them. Empty if there are none.
+}
+
+/*
+Type MatchKey struct is a parsed match key, e.g. "+NAME+DOB-PHONE (Ambiguous)".
+The zero value is the empty match key.
+*/
+type MatchKey struct {
+	Annotations []string  // Trailing parenthesized words, e.g. "Ambiguous".
+	Features    []Feature // In the order they appear.
+}
+
+/*
+Type Relationship struct is a disclosed relationship from the qualifier of a relationship feature,
+e.g. "+REL_POINTER(OWNS 60%:)" or "+REL_POINTER(:OWNS 60%)".
+*/
+type Relationship struct {
+	Code      string // Feature type code, e.g. "REL_POINTER".
+	OtherRole string // The role after the colon, e.g. "OWNS 60%" in "(:OWNS 60%)".
+	Role      string // The role before the colon, e.g. "OWNS 60%" in "(OWNS 60%:)".
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// AnnotationAmbiguous marks a match key of an ambiguous relationship.
+const AnnotationAmbiguous = "Ambiguous"
+
+// RelationshipPrefix starts the feature type codes of disclosed relationships, e.g. "REL_POINTER".
+const RelationshipPrefix = "REL_"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidMatchKey is returned when a match key cannot be parsed.
+var ErrInvalidMatchKey = errors.New("invalid match key")
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function MustParse is like Parse but panics on an invalid match key. It is meant for constants.
+*/
+func MustParse(matchKey string) MatchKey {
+	result, err := Parse(matchKey)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+/*
+Function Parse parses a match key.
+
+Each term is "+" or "-", a feature type code, and an optional qualifier in parentheses,
+which may contain any characters except unbalanced parentheses.
+Terms may be followed by annotations, each a space and a parenthesized word, e.g. " (Ambiguous)".
+
+Input
+  - matchKey: The MATCH_KEY or WHY_KEY value. An empty string is the empty match key.
+
+Output
+  - The parsed match key. Its String method returns matchKey.
+*/
+func Parse(matchKey string) (MatchKey, error) {
+	result := MatchKey{Annotations: []string{}, Features: []Feature{}}
+	rest := matchKey
+
+	for rest != "" && (rest[0] == '+' || rest[0] == '-') {
+		feature := Feature{Code: "", IsNegative: rest[0] == '-', Qualifier: ""}
+		rest = rest[1:]
+
+		end := strings.IndexAny(rest, "+-( ")
+		if end < 0 {
+			end = len(rest)
+		}
+
+		feature.Code = rest[:end]
+		rest = rest[end:]
+
+		if !isCode(feature.Code) {
+			return MatchKey{}, invalidMatchKey(matchKey, "feature type code "+strconv.Quote(feature.Code)) //exhaustruct:ignore
+		}
+
+		if strings.HasPrefix(rest, "(") {
+			qualifier, remainder, isClosed := cutParentheses(rest)
+			if !isClosed {
+				return MatchKey{}, invalidMatchKey(matchKey, "unclosed parenthesis") //exhaustruct:ignore
+			}
+
+			feature.Qualifier = qualifier
+			rest = remainder
+		}
+
+		result.Features = append(result.Features, feature)
+	}
+
+	for rest != "" {
+		if !strings.HasPrefix(rest, " (") {
+			return MatchKey{}, invalidMatchKey(matchKey, "unexpected "+strconv.Quote(rest)) //exhaustruct:ignore
+		}
+
+		annotation, remainder, isClosed := cutParentheses(rest[1:])
+		if !isClosed {
+			return MatchKey{}, invalidMatchKey(matchKey, "unclosed parenthesis") //exhaustruct:ignore
+		}
+
+		result.Annotations = append(result.Annotations, annotation)
+		rest = remainder
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Relationship returns the relationship in the qualifier of a relationship feature.
+*/
+func (feature Feature) Relationship() (Relationship, bool) {
+	if !strings.HasPrefix(feature.Code, RelationshipPrefix) {
+		return Relationship{}, false //exhaustruct:ignore
+	}
+
+	role, otherRole, isFound := strings.Cut(feature.Qualifier, ":")
+	if !isFound {
+		return Relationship{}, false //exhaustruct:ignore
+	}
+
+	return Relationship{Code: feature.Code, OtherRole: otherRole, Role: role}, true
+}
+
+/*
+Method String returns the term, e.g. "-DOB" or "+REL_POINTER(OWNS 60%:)".
+*/
+func (feature Feature) String() string {
+	sign := "+"
+	if feature.IsNegative {
+		sign = "-"
+	}
+
+	if feature.Qualifier == "" {
+		return sign + feature.Code
+	}
+
+	return sign + feature.Code + "(" + feature.Qualifier + ")"
+}
+
+/*
+Method HasAnnotation returns true if the match key has the annotation, e.g. AnnotationAmbiguous.
+*/
+func (matchKey MatchKey) HasAnnotation(annotation string) bool {
+	return slices.Contains(matchKey.Annotations, annotation)
+}
+
+/*
+Method HasNegative returns true if the match key has a "-" term for the feature type code.
+*/
+func (matchKey MatchKey) HasNegative(code string) bool {
+	return slices.Contains(matchKey.Negative(), code)
+}
+
+/*
+Method HasPositive returns true if the match key has a "+" term for the feature type code.
+*/
+func (matchKey MatchKey) HasPositive(code string) bool {
+	return slices.Contains(matchKey.Positive(), code)
+}
+
+/*
+Method IsEmpty returns true if the match key has no terms.
+*/
+func (matchKey MatchKey) IsEmpty() bool {
+	return len(matchKey.Features) == 0
+}
+
+/*
+Method Negative returns the feature type codes of the "-" terms, in order.
+*/
+func (matchKey MatchKey) Negative() []string {
+	return matchKey.codes(true)
+}
+
+/*
+Method Positive returns the feature type codes of the "+" terms, in order.
+*/
+func (matchKey MatchKey) Positive() []string {
+	return matchKey.codes(false)
+}
+
+/*
+Method Relationships returns the relationships of the relationship features.
+*/
+func (matchKey MatchKey) Relationships() []Relationship {
+	result := []Relationship{}
+
+	for _, feature := range matchKey.Features {
+		if relationship, isRelationship := feature.Relationship(); isRelationship {
+			result = append(result, relationship)
+		}
+	}
+
+	return result
+}
+
+/*
+Method String returns the match key as the engine writes it.
+*/
+func (matchKey MatchKey) String() string {
+	var result strings.Builder
+
+	for _, feature := range matchKey.Features {
+		result.WriteString(feature.String())
+	}
+
+	for _, annotation := range matchKey.Annotations {
+		result.WriteString(" (" + annotation + ")")
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (matchKey MatchKey) codes(isNegative bool) []string {
+	result := []string{}
+
+	for _, feature := range matchKey.Features {
+		if feature.IsNegative == isNegative {
+			result = append(result, feature.Code)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Splits "(a(b)c)rest" into "a(b)c" and "rest".
+func cutParentheses(value string) (string, string, bool) {
+	depth := 0
+
+	for index, character := range value {
+		switch character {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return value[1:index], value[index+1:], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func invalidMatchKey(matchKey string, reason string) error {
+	return fmt.Errorf("%w: %s in %s", ErrInvalidMatchKey, reason, strconv.Quote(matchKey))
+}
+
+func isCode(code string) bool {
+	if code == "" {
+		return false
+	}
+
+	for _, character := range code {
+		if (character < 'A' || character > 'Z') && (character < '0' || character > '9') && character != '_' {
+			return false
+		}
+	}
+
+	return true
+}
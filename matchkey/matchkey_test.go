@@ -0,0 +1,195 @@
+package matchkey_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/matchkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const entityResponse = `{"RESOLVED_ENTITY": {"ENTITY_ID": 1,
+	"RECORDS": [{"MATCH_KEY": ""}, {"MATCH_KEY": "+NAME+DOB-PHONE"}]},
+	"RELATED_ENTITIES": [{"ENTITY_ID": 2, "MATCH_KEY": "+REL_POINTER(:OWNS 60%)"},
+		{"ENTITY_ID": 3, "MATCH_KEY": "+NAME+ADDRESS (Ambiguous)"}]}`
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParse(test *testing.T) {
+	test.Parallel()
+
+	matchKey, err := matchkey.Parse("+SURNAME+ADDRESS+EMAIL-DOB-SSN")
+	require.NoError(test, err)
+	assert.Equal(test, []string{"SURNAME", "ADDRESS", "EMAIL"}, matchKey.Positive())
+	assert.Equal(test, []string{"DOB", "SSN"}, matchKey.Negative())
+	assert.True(test, matchKey.HasPositive("EMAIL"))
+	assert.True(test, matchKey.HasNegative("SSN"))
+	assert.False(test, matchKey.HasPositive("SSN"))
+
+	matchKey, err = matchkey.Parse("+NAME+ADDRESS (Ambiguous)")
+	require.NoError(test, err)
+	assert.Equal(test, []string{"NAME", "ADDRESS"}, matchKey.Positive())
+	assert.True(test, matchKey.HasAnnotation(matchkey.AnnotationAmbiguous))
+
+	matchKey, err = matchkey.Parse("")
+	require.NoError(test, err)
+	assert.True(test, matchKey.IsEmpty())
+}
+
+func TestParse_Qualifiers(test *testing.T) {
+	test.Parallel()
+
+	matchKey, err := matchkey.Parse("+TRUSTED_ID+REL_POINTER(CO-OWNER (50%):)-ADDRESS(HOME)")
+	require.NoError(test, err)
+	require.Len(test, matchKey.Features, 3)
+	assert.Equal(test, "CO-OWNER (50%):", matchKey.Features[1].Qualifier)
+	assert.Equal(test, matchkey.Feature{Code: "ADDRESS", IsNegative: true, Qualifier: "HOME"}, matchKey.Features[2])
+	assert.Equal(test, []matchkey.Relationship{{Code: "REL_POINTER", OtherRole: "", Role: "CO-OWNER (50%)"}},
+		matchKey.Relationships())
+
+	_, isRelationship := matchKey.Features[2].Relationship()
+	assert.False(test, isRelationship)
+
+	matchKey = matchkey.MustParse("+REL_POINTER(:GLOBAL PARENT)")
+	assert.Equal(test, []matchkey.Relationship{{Code: "REL_POINTER", OtherRole: "GLOBAL PARENT", Role: ""}},
+		matchKey.Relationships())
+}
+
+func TestParse_Invalid(test *testing.T) {
+	test.Parallel()
+
+	for _, value := range []string{"NAME", "+", "+name", "+NAME+", "+REL_POINTER(OWNS:", "+NAME (Ambiguous", "+NAME x"} {
+		_, err := matchkey.Parse(value)
+		require.ErrorIs(test, err, matchkey.ErrInvalidMatchKey, value)
+	}
+
+	assert.Panics(test, func() { matchkey.MustParse("+") })
+}
+
+func TestMatchKey_String(test *testing.T) {
+	test.Parallel()
+
+	for _, value := range []string{
+		"",
+		"+NAME",
+		"+NAME+DOB-PHONE",
+		"+REL_POINTER(OWNS 60%:)",
+		"+NAME+ADDRESS (Ambiguous)",
+		"-DOB+NAME(X(Y))",
+	} {
+		assert.Equal(test, value, matchkey.MustParse(value).String())
+	}
+}
+
+func TestExtract(test *testing.T) {
+	test.Parallel()
+
+	matchKeys, err := matchkey.Extract(entityResponse)
+	require.NoError(test, err)
+
+	values := []string{}
+	for _, matchKey := range matchKeys {
+		values = append(values, matchKey.String())
+	}
+
+	// Object keys are visited in sorted order, so RELATED_ENTITIES comes before RESOLVED_ENTITY.
+	assert.Equal(test, []string{"+REL_POINTER(:OWNS 60%)", "+NAME+ADDRESS (Ambiguous)", "+NAME+DOB-PHONE"}, values)
+
+	_, err = matchkey.Extract("{")
+	require.Error(test, err)
+
+	_, err = matchkey.Extract(`{"WHY_KEY": "NAME"}`)
+	require.ErrorIs(test, err, matchkey.ErrInvalidMatchKey)
+}
+
+func TestFilter(test *testing.T) {
+	test.Parallel()
+
+	responses := []string{
+		entityResponse,
+		`{"RELATED_ENTITIES": [{"MATCH_KEY": "+NAME+EMAIL"}]}`,
+		`{"WHY_RESULTS": [{"MATCH_INFO": {"WHY_KEY": "+NAME-DOB"}}]}`,
+	}
+
+	for _, testCase := range []struct {
+		expected  []string
+		name      string
+		predicate matchkey.Predicate
+	}{
+		{name: "positive", predicate: matchkey.WithPositive("NAME", "EMAIL"), expected: responses[1:2]},
+		{name: "negative", predicate: matchkey.WithNegative("DOB"), expected: responses[2:]},
+		{name: "not", predicate: matchkey.Not(matchkey.WithPositive("NAME")), expected: responses[:1]},
+		{name: "annotation", predicate: matchkey.WithAnnotation("Ambiguous"), expected: responses[:1]},
+		{name: "any relationship", predicate: matchkey.WithRelationship(), expected: responses[:1]},
+		{name: "role", predicate: matchkey.WithRelationship("OWNS 60%"), expected: responses[:1]},
+		{name: "other role", predicate: matchkey.WithRelationship("PRINCIPAL"), expected: []string{}},
+		{
+			name: "all",
+			predicate: matchkey.All(
+				matchkey.WithPositive("NAME"),
+				matchkey.Not(matchkey.Any(matchkey.WithNegative("DOB"), matchkey.WithNegative("PHONE"))),
+				matchkey.Not(matchkey.WithAnnotation(matchkey.AnnotationAmbiguous)),
+			),
+			expected: responses[1:2],
+		},
+		{
+			name:      "any",
+			predicate: matchkey.Any(matchkey.WithPositive("EMAIL"), matchkey.WithNegative("DOB")),
+			expected:  responses[1:],
+		},
+	} {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := matchkey.Filter(responses, testCase.predicate)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+
+	_, err := matchkey.Filter([]string{"["}, matchkey.WithPositive())
+	require.Error(test, err)
+}
+
+func TestStats(test *testing.T) {
+	test.Parallel()
+
+	stats := matchkey.NewStats()
+	require.NoError(test, stats.AddResponse(entityResponse))
+	require.NoError(test, stats.AddStream(strings.NewReader(entityResponse+"\n"+`{"MATCH_KEY": "+NAME+DOB-PHONE"}`)))
+	require.Error(test, stats.AddResponse("{"))
+	require.Error(test, stats.AddStream(strings.NewReader("{} {")))
+
+	assert.Equal(test, int64(7), stats.Total)
+	assert.Equal(test, int64(3), stats.Keys["+NAME+DOB-PHONE"])
+	assert.Equal(test, int64(5), stats.Positive["NAME"])
+	assert.Equal(test, int64(3), stats.Negative["PHONE"])
+	assert.Equal(test, int64(2), stats.Relationships["OWNS 60%"])
+	assert.Equal(test, int64(2), stats.Annotations["Ambiguous"])
+
+	assert.Equal(test, matchkey.Count{Count: 3, Value: "+NAME+DOB-PHONE"}, matchkey.Sorted(stats.Keys)[0])
+	assert.True(test, strings.HasPrefix(stats.String(), "7 match keys\n       3 +NAME+DOB-PHONE\n"))
+}
+
+func TestStats_Fixtures(test *testing.T) {
+	test.Parallel()
+
+	for _, fileName := range []string{
+		"SzEngineFindPathByEntityIdResponse.jsonl",
+		"SzEngineGetEntityByEntityIdResponse.jsonl",
+		"SzEngineWhyEntitiesResponse.jsonl",
+	} {
+		file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", fileName))
+		require.NoError(test, err)
+
+		stats := matchkey.NewStats()
+		require.NoError(test, stats.AddStream(file), fileName)
+		require.NoError(test, file.Close())
+		assert.Positive(test, stats.Total, fileName)
+	}
+}
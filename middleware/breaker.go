@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Type BreakerState int is the state of a [Breaker].
+type BreakerState int
+
+/*
+Type BreakerConfig struct configures a [Breaker].
+*/
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive tripping errors that opens the breaker.
+	// Zero means DefaultFailureThreshold.
+	FailureThreshold int
+
+	// OnStateChange, if set, is called after each state change.
+	OnStateChange func(from BreakerState, to BreakerState)
+
+	// OpenTimeout is how long the breaker stays open before probing. Zero means DefaultOpenTimeout.
+	OpenTimeout time.Duration
+
+	// Probe, if set, is called in the half-open state to decide whether to close the breaker.
+	// It should be a cheap call on the unwrapped implementation, e.g. szEngine.GetActiveConfigID.
+	// If nil, the first call after OpenTimeout is used as the probe.
+	Probe func(ctx context.Context) error
+
+	// TripOn lists the szerror instances that count toward FailureThreshold.
+	// Nil means szerror.ErrSzDatabaseConnectionLost and szerror.ErrSzDatabase.
+	TripOn []error
+}
+
+/*
+Type Breaker struct is a circuit breaker for database-related Senzing failures.
+While open, calls fail immediately instead of reaching the Senzing engine.
+*/
+type Breaker struct {
+	config    BreakerConfig
+	failures  int
+	mutex     sync.Mutex
+	openedAt  time.Time
+	probing   bool
+	state     BreakerState
+	tripOnErr []error
+}
+
+type stateChange struct {
+	from BreakerState
+	to   BreakerState
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Breaker states.
+const (
+	BreakerClosed   BreakerState = iota // Calls pass through.
+	BreakerOpen                         // Calls are rejected.
+	BreakerHalfOpen                     // A single probe decides whether to close or re-open.
+)
+
+// Breaker defaults.
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrBreakerOpen is wrapped by errors returned for calls rejected by an open [Breaker].
+var ErrBreakerOpen = errors.New("circuit breaker is open")
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewBreaker creates a closed Breaker.
+
+Input
+  - config: Threshold, timeout, probe, tripping errors, and state change callback.
+
+Output
+  - A Breaker. Use its Interceptor method with NewEngine, NewDiagnostic, etc.
+*/
+func NewBreaker(config BreakerConfig) *Breaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultFailureThreshold
+	}
+
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = DefaultOpenTimeout
+	}
+
+	tripOnErr := config.TripOn
+	if tripOnErr == nil {
+		tripOnErr = []error{szerror.ErrSzDatabaseConnectionLost, szerror.ErrSzDatabase}
+	}
+
+	return &Breaker{
+		config:    config,
+		failures:  0,
+		mutex:     sync.Mutex{},
+		openedAt:  time.Time{},
+		probing:   false,
+		state:     BreakerClosed,
+		tripOnErr: tripOnErr,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Interceptor returns an interceptor that rejects calls while the breaker is open.
+Rejected calls return an error classified as szerror.SzRetryableError that wraps ErrBreakerOpen.
+Methods that release resources are never rejected.
+*/
+func (breaker *Breaker) Interceptor() Interceptor {
+	return func(ctx context.Context, call Call) (any, error) {
+		if cleanupMethods[call.Method] {
+			return call.Invoke(ctx)
+		}
+
+		isProbe, err := breaker.admit(ctx, call.Method)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := call.Invoke(ctx)
+		if isProbe {
+			breaker.endProbe(err)
+		} else {
+			breaker.observe(err)
+		}
+
+		return result, err
+	}
+}
+
+/*
+Method State returns the current state of the breaker.
+*/
+func (breaker *Breaker) State() BreakerState {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	return breaker.state
+}
+
+/*
+Method String returns the name of the state.
+*/
+func (state BreakerState) String() string {
+	switch state {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(state))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Decide whether a call may proceed. If the call itself is the half-open probe, isProbe is true.
+func (breaker *Breaker) admit(ctx context.Context, method string) (bool, error) {
+	breaker.mutex.Lock()
+
+	var changes []stateChange
+
+	if breaker.state == BreakerOpen && time.Now().Sub(breaker.openedAt) >= breaker.config.OpenTimeout {
+		changes = append(changes, breaker.transition(BreakerHalfOpen))
+	}
+
+	state := breaker.state
+	isProbe := state == BreakerHalfOpen && !breaker.probing
+
+	if isProbe {
+		breaker.probing = true
+	}
+
+	breaker.mutex.Unlock()
+	breaker.notify(changes...)
+
+	switch {
+	case state == BreakerClosed:
+		return false, nil
+	case isProbe && breaker.config.Probe == nil:
+		return true, nil
+	case isProbe:
+		breaker.endProbe(breaker.config.Probe(ctx))
+
+		if breaker.State() == BreakerClosed {
+			return false, nil
+		}
+	}
+
+	return false, breakerError(method)
+}
+
+func (breaker *Breaker) endProbe(err error) {
+	breaker.mutex.Lock()
+
+	var change stateChange
+
+	breaker.probing = false
+
+	if breaker.isTripping(err) || (err != nil && breaker.config.Probe != nil) {
+		breaker.openedAt = time.Now()
+		change = breaker.transition(BreakerOpen)
+	} else {
+		breaker.failures = 0
+		change = breaker.transition(BreakerClosed)
+	}
+
+	breaker.mutex.Unlock()
+	breaker.notify(change)
+}
+
+func (breaker *Breaker) observe(err error) {
+	breaker.mutex.Lock()
+
+	var change stateChange
+
+	switch {
+	case !breaker.isTripping(err):
+		if breaker.state == BreakerClosed {
+			breaker.failures = 0
+		}
+	case breaker.state == BreakerClosed:
+		breaker.failures++
+
+		if breaker.failures >= breaker.config.FailureThreshold {
+			breaker.openedAt = time.Now()
+			change = breaker.transition(BreakerOpen)
+		}
+	}
+
+	breaker.mutex.Unlock()
+	breaker.notify(change)
+}
+
+func (breaker *Breaker) isTripping(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, tripOnErr := range breaker.tripOnErr {
+		if errors.Is(err, tripOnErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Change state. The mutex must be held. The returned change is passed to notify after unlocking.
+func (breaker *Breaker) transition(state BreakerState) stateChange {
+	change := stateChange{from: breaker.state, to: state}
+	breaker.state = state
+
+	return change
+}
+
+func (breaker *Breaker) notify(changes ...stateChange) {
+	if breaker.config.OnStateChange == nil {
+		return
+	}
+
+	for _, change := range changes {
+		if change.from != change.to {
+			breaker.config.OnStateChange(change.from, change.to)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func breakerError(method string) error {
+	return szerror.Wrap(fmt.Errorf("%s: %w", method, ErrBreakerOpen), szerror.SzRetryableError, szerror.SzError)
+}
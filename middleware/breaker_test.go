@@ -0,0 +1,115 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/middleware"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errProbe = errors.New("probe failed")
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBreaker_OpensAndRecovers(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+
+	var (
+		mutex   sync.Mutex
+		changes []string
+	)
+
+	mock := &mockEngine{err: szerror.New(1007, "Database Connection Lost")} //exhaustruct:ignore
+	breaker := middleware.NewBreaker(middleware.BreakerConfig{              //exhaustruct:ignore
+		FailureThreshold: 2,
+		OpenTimeout:      shortDelay,
+		OnStateChange: func(from middleware.BreakerState, to middleware.BreakerState) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	})
+	engine := middleware.NewEngine(mock, breaker.Interceptor())
+
+	for range 2 {
+		_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+		require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+	}
+
+	assert.Equal(test, middleware.BreakerOpen, breaker.State())
+
+	_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.ErrorIs(test, err, middleware.ErrBreakerOpen)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	assert.Equal(test, 2, mock.callCount())
+
+	time.Sleep(2 * shortDelay)
+
+	mock.err = nil
+	_, err = engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, middleware.BreakerClosed, breaker.State())
+	assert.Equal(test, []string{"closed->open", "open->half-open", "half-open->closed"}, changes)
+}
+
+func TestBreaker_IgnoresOtherErrors(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	mock := &mockEngine{err: szerror.New(33, "Unknown record")}                     //exhaustruct:ignore
+	breaker := middleware.NewBreaker(middleware.BreakerConfig{FailureThreshold: 1}) //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, breaker.Interceptor())
+
+	for range 3 {
+		_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+		require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	}
+
+	assert.Equal(test, middleware.BreakerClosed, breaker.State())
+}
+
+func TestBreaker_Probe(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	mock := &mockEngine{err: szerror.New(1006, "Database Connection Failure")} //exhaustruct:ignore
+	probeErr := errProbe
+	breaker := middleware.NewBreaker(middleware.BreakerConfig{ //exhaustruct:ignore
+		FailureThreshold: 1,
+		OpenTimeout:      shortDelay,
+		Probe: func(_ context.Context) error {
+			return probeErr
+		},
+	})
+	engine := middleware.NewEngine(mock, breaker.Interceptor())
+
+	_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+
+	time.Sleep(2 * shortDelay)
+
+	_, err = engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.ErrorIs(test, err, middleware.ErrBreakerOpen)
+	assert.Equal(test, middleware.BreakerOpen, breaker.State())
+	assert.Equal(test, 1, mock.callCount())
+
+	time.Sleep(2 * shortDelay)
+
+	probeErr = nil
+	mock.err = nil
+	_, err = engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, middleware.BreakerClosed, breaker.State())
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szconfigmanager"
+	"github.com/senzing-garage/sz-sdk-go/szdiagnostic"
+	"github.com/senzing-garage/sz-sdk-go/szengine"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/senzing-garage/sz-sdk-go/szproduct"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type DeadlineConfig struct configures the [Deadline] interceptor.
+*/
+type DeadlineConfig struct {
+	// DefaultTimeout is applied to every call. Zero means no timeout.
+	DefaultTimeout time.Duration
+
+	// Timeouts overrides DefaultTimeout for specific methods.
+	// Keys are method names, e.g. szengine.Prefix + "FindNetworkByEntityID".
+	Timeouts map[string]time.Duration
+}
+
+type callOutcome struct {
+	err    error
+	result any
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Methods that release resources. They are allowed to run after the caller's context is done.
+var cleanupMethods = map[string]bool{
+	AbstractFactoryPrefix + "Close":       true,
+	szconfigmanager.Prefix + "Destroy":    true,
+	szdiagnostic.Prefix + "Destroy":       true,
+	szengine.Prefix + "CloseExportReport": true,
+	szengine.Prefix + "Destroy":           true,
+	szproduct.Prefix + "Destroy":          true,
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Deadline returns an interceptor that enforces context cancellation and deadlines.
+
+Before each call, the context is checked; a done context fails the call without calling Senzing.
+During a call, if the context is done before the call returns, the interceptor returns immediately.
+The abandoned call keeps running in the background and, if its result holds a native resource
+(e.g. an export handle), that resource is released when the call eventually returns.
+
+Errors caused by the context are classified as szerror.SzSdkError and wrap ctx.Err(),
+so both errors.Is(err, szerror.ErrSzSdk) and errors.Is(err, context.DeadlineExceeded) work.
+
+Input
+  - config: Default and per-method timeouts.
+
+Output
+  - An interceptor for use with NewEngine, NewDiagnostic, etc.
+*/
+func Deadline(config DeadlineConfig) Interceptor {
+	return func(ctx context.Context, call Call) (any, error) {
+		if cleanupMethods[call.Method] {
+			return call.Invoke(context.WithoutCancel(ctx))
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, contextError(call.Method, err)
+		}
+
+		if timeout := config.timeout(call.Method); timeout > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		if ctx.Done() == nil {
+			return call.Invoke(ctx)
+		}
+
+		return invokeUntilDone(ctx, call)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (config DeadlineConfig) timeout(method string) time.Duration {
+	if timeout, isSet := config.Timeouts[method]; isSet {
+		return timeout
+	}
+
+	return config.DefaultTimeout
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func contextError(method string, err error) error {
+	return szerror.Wrap(
+		fmt.Errorf("%s abandoned: %w", method, err),
+		szerror.SzSdkError,
+		szerror.SzGeneralError,
+		szerror.SzError,
+	)
+}
+
+func invokeUntilDone(ctx context.Context, call Call) (any, error) {
+	outcomeChannel := make(chan callOutcome, 1)
+
+	go func() {
+		result, err := call.Invoke(ctx)
+		outcomeChannel <- callOutcome{err: err, result: result}
+	}()
+
+	select {
+	case outcome := <-outcomeChannel:
+		return outcome.result, outcome.err
+	case <-ctx.Done():
+		go releaseAbandoned(call, outcomeChannel)
+
+		return nil, contextError(call.Method, ctx.Err())
+	}
+}
+
+func releaseAbandoned(call Call, outcomeChannel chan callOutcome) {
+	outcome := <-outcomeChannel
+	if outcome.err == nil && call.Release != nil {
+		call.Release(outcome.result)
+	}
+}
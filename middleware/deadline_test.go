@@ -0,0 +1,142 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/middleware"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szengine"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	longDelay  = 2 * time.Second
+	shortDelay = 20 * time.Millisecond
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestDeadline_CanceledBeforeCall(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	mock := &mockEngine{}                                                                  //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, middleware.Deadline(middleware.DeadlineConfig{})) //exhaustruct:ignore
+	_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.Canceled)
+	require.ErrorIs(test, err, szerror.ErrSzSdk)
+	assert.Equal(test, 0, mock.callCount())
+}
+
+func TestDeadline_ReturnsPromptly(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), shortDelay)
+	defer cancel()
+
+	mock := &mockEngine{delay: longDelay}                                                  //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, middleware.Deadline(middleware.DeadlineConfig{})) //exhaustruct:ignore
+	start := time.Now()
+	_, err := engine.FindNetworkByEntityID(ctx, `{"ENTITIES":[{"ENTITY_ID":1}]}`, 1, 0, 0, senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	require.ErrorIs(test, err, szerror.ErrSzSdk)
+	assert.Less(test, time.Since(start), longDelay)
+}
+
+func TestDeadline_PerMethodTimeout(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	mock := &mockEngine{delay: longDelay} //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, middleware.Deadline(middleware.DeadlineConfig{
+		DefaultTimeout: time.Hour,
+		Timeouts: map[string]time.Duration{
+			szengine.Prefix + "SearchByAttributes": shortDelay,
+		},
+	}))
+	_, err := engine.SearchByAttributes(ctx, `{"NAME_FULL":"BOB"}`, senzing.SzNoSearchProfile, senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+}
+
+func TestDeadline_ReleasesAbandonedExportHandle(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), shortDelay)
+	defer cancel()
+
+	mock := &mockEngine{delay: 5 * shortDelay}                                             //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, middleware.Deadline(middleware.DeadlineConfig{})) //exhaustruct:ignore
+	_, err := engine.ExportJSONEntityReport(ctx, senzing.SzExportDefaultFlags)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	assert.Eventually(test, func() bool {
+		return len(mock.closed()) == 1
+	}, longDelay, shortDelay)
+}
+
+func TestDeadline_IteratorClosesAfterAbandonedFetch(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	mock := &mockEngine{fetchDelay: 5 * shortDelay, fragments: []string{"a\n"}} //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, middleware.Deadline(middleware.DeadlineConfig{
+		DefaultTimeout: time.Hour,
+		Timeouts:       map[string]time.Duration{szengine.Prefix + "FetchNext": shortDelay},
+	}))
+
+	for fragment := range engine.ExportJSONEntityReportIterator(ctx, senzing.SzExportDefaultFlags) {
+		require.ErrorIs(test, fragment.Error, context.DeadlineExceeded)
+	}
+
+	assert.Eventually(test, func() bool {
+		return len(mock.closed()) == 1
+	}, longDelay, shortDelay)
+
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	assert.False(test, mock.closedWhileFetching)
+}
+
+func TestDeadline_CloseExportReportAfterCancel(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	mock := &mockEngine{}                                                                  //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, middleware.Deadline(middleware.DeadlineConfig{})) //exhaustruct:ignore
+	err := engine.CloseExportReport(ctx, exportHandle)
+	require.NoError(test, err)
+	assert.Equal(test, []uintptr{exportHandle}, mock.closed())
+}
+
+func TestDeadline_IteratorStopsOnCancel(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	mock := &mockEngine{fragments: []string{"a\n", "b\n", "c\n"}}                          //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, middleware.Deadline(middleware.DeadlineConfig{})) //exhaustruct:ignore
+	count := 0
+
+	for fragment := range engine.ExportJSONEntityReportIterator(ctx, senzing.SzExportDefaultFlags) {
+		count++
+
+		if fragment.Error == nil {
+			cancel()
+		}
+	}
+
+	cancel()
+	assert.LessOrEqual(test, count, 2)
+	assert.Eventually(test, func() bool {
+		return len(mock.closed()) == 1
+	}, longDelay, shortDelay)
+}
@@ -0,0 +1,10 @@
+/*
+Package middleware contains decorators for the Senzing Go SDK interfaces.
+
+Each decorator wraps an existing implementation of a [senzing] interface
+(e.g. sz-sdk-go-core, sz-sdk-go-grpc, sz-sdk-go-mock) and routes every method call
+through a chain of [Interceptor] functions before it reaches the wrapped implementation.
+Because the decorators implement the same interfaces, they can be used anywhere the
+wrapped implementation can.
+*/
+package middleware
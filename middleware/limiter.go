@@ -0,0 +1,399 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szconfigmanager"
+	"github.com/senzing-garage/sz-sdk-go/szdiagnostic"
+	"github.com/senzing-garage/sz-sdk-go/szengine"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Type MethodGroup string names a set of methods that share a concurrency limit.
+type MethodGroup string
+
+/*
+Type GroupLimit struct configures the bulkhead for one [MethodGroup].
+*/
+type GroupLimit struct {
+	// Capacity is the total weight of calls allowed to run concurrently. Must be greater than zero.
+	Capacity int64
+
+	// MaxQueueDepth is the number of calls allowed to wait for capacity. Zero means unbounded.
+	MaxQueueDepth int
+
+	// QueueTimeout is the longest a call may wait for capacity. Zero means wait until the context is done.
+	QueueTimeout time.Duration
+}
+
+/*
+Type LimiterConfig struct configures a [Limiter].
+*/
+type LimiterConfig struct {
+	// Limits holds the bulkhead configuration for each group. Calls in groups without a limit are not limited.
+	Limits map[MethodGroup]GroupLimit
+
+	// MethodGroups overrides the default group of a method. Keys are method names, e.g. szengine.Prefix + "AddRecord".
+	MethodGroups map[string]MethodGroup
+
+	// Weights is the number of capacity units a method uses. Methods not listed use 1.
+	Weights map[string]int64
+}
+
+/*
+Type LimiterStats struct is a point-in-time view of one bulkhead.
+*/
+type LimiterStats struct {
+	Admitted   int64         // Calls that acquired capacity.
+	Canceled   int64         // Calls whose context was done while waiting.
+	Capacity   int64         // Configured capacity.
+	InUse      int64         // Capacity currently in use.
+	MaxWait    time.Duration // Longest time a call waited for capacity.
+	QueueDepth int           // Calls currently waiting.
+	Rejected   int64         // Calls rejected because the queue was full or the queue timeout elapsed.
+	TotalWait  time.Duration // Sum of the time admitted calls waited for capacity.
+}
+
+/*
+Type Limiter struct applies weighted semaphores to groups of SDK methods.
+A burst of calls in one group cannot consume the capacity of another group.
+*/
+type Limiter struct {
+	bulkheads    map[MethodGroup]*bulkhead
+	methodGroups map[string]MethodGroup
+	weights      map[string]int64
+}
+
+type bulkhead struct {
+	limit   GroupLimit
+	mutex   sync.Mutex
+	stats   LimiterStats
+	waiters *list.List
+}
+
+type waiter struct {
+	ready  chan struct{}
+	weight int64
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Method groups.
+const (
+	GroupAdmin  MethodGroup = "admin"  // Configuration, diagnostic, and housekeeping methods.
+	GroupExport MethodGroup = "export" // Export report methods.
+	GroupLoad   MethodGroup = "load"   // Methods that add, delete, or re-resolve records.
+	GroupQuery  MethodGroup = "query"  // Entity, record, network, path, and search methods.
+	GroupWhyHow MethodGroup = "whyhow" // Why and How methods.
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	errQueueFull    = errors.New("queue is full")
+	errQueueTimeout = errors.New("queue timeout elapsed")
+)
+
+// Default method groups. Methods that release resources are not listed so they are never limited.
+var defaultMethodGroups = map[string]MethodGroup{
+	szconfigmanager.Prefix + "CreateConfigFromConfigID":   GroupAdmin,
+	szconfigmanager.Prefix + "CreateConfigFromString":     GroupAdmin,
+	szconfigmanager.Prefix + "CreateConfigFromTemplate":   GroupAdmin,
+	szconfigmanager.Prefix + "GetConfigRegistry":          GroupAdmin,
+	szconfigmanager.Prefix + "GetDefaultConfigID":         GroupAdmin,
+	szconfigmanager.Prefix + "RegisterConfig":             GroupAdmin,
+	szconfigmanager.Prefix + "ReplaceDefaultConfigID":     GroupAdmin,
+	szconfigmanager.Prefix + "SetDefaultConfig":           GroupAdmin,
+	szconfigmanager.Prefix + "SetDefaultConfigID":         GroupAdmin,
+	szdiagnostic.Prefix + "CheckRepositoryPerformance":    GroupAdmin,
+	szdiagnostic.Prefix + "GetFeature":                    GroupAdmin,
+	szdiagnostic.Prefix + "GetRepositoryInfo":             GroupAdmin,
+	szdiagnostic.Prefix + "PurgeRepository":               GroupAdmin,
+	szengine.Prefix + "AddRecord":                         GroupLoad,
+	szengine.Prefix + "CountRedoRecords":                  GroupLoad,
+	szengine.Prefix + "DeleteRecord":                      GroupLoad,
+	szengine.Prefix + "ExportCsvEntityReport":             GroupExport,
+	szengine.Prefix + "ExportJSONEntityReport":            GroupExport,
+	szengine.Prefix + "FetchNext":                         GroupExport,
+	szengine.Prefix + "FindInterestingEntitiesByEntityID": GroupQuery,
+	szengine.Prefix + "FindInterestingEntitiesByRecordID": GroupQuery,
+	szengine.Prefix + "FindNetworkByEntityID":             GroupQuery,
+	szengine.Prefix + "FindNetworkByRecordID":             GroupQuery,
+	szengine.Prefix + "FindPathByEntityID":                GroupQuery,
+	szengine.Prefix + "FindPathByRecordID":                GroupQuery,
+	szengine.Prefix + "GetActiveConfigID":                 GroupAdmin,
+	szengine.Prefix + "GetEntityByEntityID":               GroupQuery,
+	szengine.Prefix + "GetEntityByRecordID":               GroupQuery,
+	szengine.Prefix + "GetRecord":                         GroupQuery,
+	szengine.Prefix + "GetRecordPreview":                  GroupQuery,
+	szengine.Prefix + "GetRedoRecord":                     GroupLoad,
+	szengine.Prefix + "GetStats":                          GroupAdmin,
+	szengine.Prefix + "GetVirtualEntityByRecordID":        GroupQuery,
+	szengine.Prefix + "HowEntityByEntityID":               GroupWhyHow,
+	szengine.Prefix + "PrimeEngine":                       GroupAdmin,
+	szengine.Prefix + "ProcessRedoRecord":                 GroupLoad,
+	szengine.Prefix + "ReevaluateEntity":                  GroupLoad,
+	szengine.Prefix + "ReevaluateRecord":                  GroupLoad,
+	szengine.Prefix + "SearchByAttributes":                GroupQuery,
+	szengine.Prefix + "WhyEntities":                       GroupWhyHow,
+	szengine.Prefix + "WhyRecordInEntity":                 GroupWhyHow,
+	szengine.Prefix + "WhyRecords":                        GroupWhyHow,
+	szengine.Prefix + "WhySearch":                         GroupWhyHow,
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewLimiter creates a Limiter.
+
+Input
+  - config: Group limits, method group overrides, and method weights.
+
+Output
+  - A Limiter. Use its Interceptor method with NewEngine, NewDiagnostic, etc.
+*/
+func NewLimiter(config LimiterConfig) *Limiter {
+	result := &Limiter{
+		bulkheads:    make(map[MethodGroup]*bulkhead, len(config.Limits)),
+		methodGroups: make(map[string]MethodGroup, len(defaultMethodGroups)+len(config.MethodGroups)),
+		weights:      make(map[string]int64, len(config.Weights)),
+	}
+
+	for method, group := range defaultMethodGroups {
+		result.methodGroups[method] = group
+	}
+
+	for method, group := range config.MethodGroups {
+		result.methodGroups[method] = group
+	}
+
+	for method, weight := range config.Weights {
+		result.weights[method] = weight
+	}
+
+	for group, limit := range config.Limits {
+		if limit.Capacity <= 0 {
+			continue
+		}
+
+		result.bulkheads[group] = &bulkhead{
+			limit:   limit,
+			mutex:   sync.Mutex{},
+			stats:   LimiterStats{Capacity: limit.Capacity}, //exhaustruct:ignore
+			waiters: list.New(),
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Interceptor returns an interceptor that acquires capacity in the method's group before each call
+and releases it when the wrapped call returns.
+
+Calls that cannot acquire capacity are rejected with an error classified as szerror.SzResourceExhaustedError.
+Calls whose context is done while waiting return an error wrapping ctx.Err().
+*/
+func (limiter *Limiter) Interceptor() Interceptor {
+	return func(ctx context.Context, call Call) (any, error) {
+		group, isGrouped := limiter.methodGroups[call.Method]
+		if !isGrouped {
+			return call.Invoke(ctx)
+		}
+
+		bulkhead, isLimited := limiter.bulkheads[group]
+		if !isLimited {
+			return call.Invoke(ctx)
+		}
+
+		weight := limiter.weight(call.Method)
+
+		err := bulkhead.acquire(ctx, weight)
+		if err != nil {
+			return nil, limiterError(call.Method, group, err)
+		}
+
+		defer bulkhead.release(weight)
+
+		return call.Invoke(ctx)
+	}
+}
+
+/*
+Method Stats returns the current statistics of each limited group.
+*/
+func (limiter *Limiter) Stats() map[MethodGroup]LimiterStats {
+	result := make(map[MethodGroup]LimiterStats, len(limiter.bulkheads))
+
+	for group, bulkhead := range limiter.bulkheads {
+		result[group] = bulkhead.snapshot()
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (limiter *Limiter) weight(method string) int64 {
+	if weight, isSet := limiter.weights[method]; isSet && weight > 0 {
+		return weight
+	}
+
+	return 1
+}
+
+func (bulkhead *bulkhead) acquire(ctx context.Context, weight int64) error {
+	weight = min(weight, bulkhead.limit.Capacity)
+
+	bulkhead.mutex.Lock()
+
+	if bulkhead.waiters.Len() == 0 && bulkhead.stats.InUse+weight <= bulkhead.limit.Capacity {
+		bulkhead.stats.InUse += weight
+		bulkhead.stats.Admitted++
+		bulkhead.mutex.Unlock()
+
+		return nil
+	}
+
+	if bulkhead.limit.MaxQueueDepth > 0 && bulkhead.waiters.Len() >= bulkhead.limit.MaxQueueDepth {
+		bulkhead.stats.Rejected++
+		bulkhead.mutex.Unlock()
+
+		return errQueueFull
+	}
+
+	queued := &waiter{ready: make(chan struct{}), weight: weight}
+	element := bulkhead.waiters.PushBack(queued)
+	bulkhead.stats.QueueDepth = bulkhead.waiters.Len()
+	bulkhead.mutex.Unlock()
+
+	start := time.Now()
+
+	var timeout <-chan time.Time
+
+	if bulkhead.limit.QueueTimeout > 0 {
+		timer := time.NewTimer(bulkhead.limit.QueueTimeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	var err error
+
+	select {
+	case <-queued.ready:
+	case <-timeout:
+		err = errQueueTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return bulkhead.dequeue(element, queued, err, time.Since(start))
+}
+
+// Remove a waiter from the queue, unless it was admitted while giving up.
+func (bulkhead *bulkhead) dequeue(element *list.Element, queued *waiter, err error, wait time.Duration) error {
+	bulkhead.mutex.Lock()
+	defer bulkhead.mutex.Unlock()
+
+	select {
+	case <-queued.ready:
+		err = nil
+	default:
+	}
+
+	if err == nil {
+		bulkhead.stats.Admitted++
+		bulkhead.stats.TotalWait += wait
+		bulkhead.stats.MaxWait = max(bulkhead.stats.MaxWait, wait)
+
+		return nil
+	}
+
+	isFront := bulkhead.waiters.Front() == element
+	bulkhead.waiters.Remove(element)
+	bulkhead.stats.QueueDepth = bulkhead.waiters.Len()
+
+	if isFront {
+		bulkhead.admitWaiters()
+	}
+
+	if errors.Is(err, errQueueTimeout) {
+		bulkhead.stats.Rejected++
+	} else {
+		bulkhead.stats.Canceled++
+	}
+
+	return err
+}
+
+func (bulkhead *bulkhead) release(weight int64) {
+	weight = min(weight, bulkhead.limit.Capacity)
+
+	bulkhead.mutex.Lock()
+	defer bulkhead.mutex.Unlock()
+
+	bulkhead.stats.InUse -= weight
+	bulkhead.admitWaiters()
+}
+
+// Admit waiters in FIFO order while capacity allows. The mutex must be held.
+func (bulkhead *bulkhead) admitWaiters() {
+	for element := bulkhead.waiters.Front(); element != nil; element = bulkhead.waiters.Front() {
+		queued, isWaiter := element.Value.(*waiter)
+		if !isWaiter || bulkhead.stats.InUse+queued.weight > bulkhead.limit.Capacity {
+			break
+		}
+
+		bulkhead.stats.InUse += queued.weight
+		bulkhead.waiters.Remove(element)
+		close(queued.ready)
+	}
+
+	bulkhead.stats.QueueDepth = bulkhead.waiters.Len()
+}
+
+func (bulkhead *bulkhead) snapshot() LimiterStats {
+	bulkhead.mutex.Lock()
+	defer bulkhead.mutex.Unlock()
+
+	return bulkhead.stats
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func limiterError(method string, group MethodGroup, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return contextError(method, err)
+	}
+
+	return szerror.Wrap(
+		fmt.Errorf("%s rejected by %s limiter: %w", method, group, err),
+		szerror.SzResourceExhaustedError,
+		szerror.SzRetryableError,
+		szerror.SzError,
+	)
+}
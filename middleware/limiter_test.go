@@ -0,0 +1,148 @@
+package middleware_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/middleware"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szengine"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLimiter_QueueTimeout(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	limiter := middleware.NewLimiter(middleware.LimiterConfig{ //exhaustruct:ignore
+		Limits: map[middleware.MethodGroup]middleware.GroupLimit{
+			middleware.GroupQuery: {Capacity: 1, MaxQueueDepth: 0, QueueTimeout: shortDelay},
+		},
+	})
+	mock := &mockEngine{delay: 10 * shortDelay} //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, limiter.Interceptor())
+
+	var waitGroup sync.WaitGroup
+
+	waitGroup.Go(func() {
+		_, _ = engine.SearchByAttributes(ctx, "{}", senzing.SzNoSearchProfile, senzing.SzNoFlags)
+	})
+
+	require.Eventually(test, func() bool {
+		return limiter.Stats()[middleware.GroupQuery].InUse == 1
+	}, longDelay, time.Millisecond)
+
+	_, err := engine.SearchByAttributes(ctx, "{}", senzing.SzNoSearchProfile, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzResourceExhausted)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+
+	waitGroup.Wait()
+
+	stats := limiter.Stats()[middleware.GroupQuery]
+	assert.Equal(test, int64(1), stats.Admitted)
+	assert.Equal(test, int64(1), stats.Rejected)
+	assert.Equal(test, int64(0), stats.InUse)
+	assert.Equal(test, 0, stats.QueueDepth)
+}
+
+func TestLimiter_GroupsAreIsolated(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	limiter := middleware.NewLimiter(middleware.LimiterConfig{ //exhaustruct:ignore
+		Limits: map[middleware.MethodGroup]middleware.GroupLimit{
+			middleware.GroupLoad:  {Capacity: 1, MaxQueueDepth: 1, QueueTimeout: 0},
+			middleware.GroupQuery: {Capacity: 1, MaxQueueDepth: 1, QueueTimeout: 0},
+		},
+	})
+	mock := &mockEngine{delay: 10 * shortDelay} //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, limiter.Interceptor())
+
+	var waitGroup sync.WaitGroup
+
+	waitGroup.Go(func() {
+		_, _ = engine.SearchByAttributes(ctx, "{}", senzing.SzNoSearchProfile, senzing.SzNoFlags)
+	})
+
+	require.Eventually(test, func() bool {
+		return limiter.Stats()[middleware.GroupQuery].InUse == 1
+	}, longDelay, time.Millisecond)
+
+	_, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.NoError(test, err)
+	waitGroup.Wait()
+}
+
+func TestLimiter_WeightsAndQueueing(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	limiter := middleware.NewLimiter(middleware.LimiterConfig{
+		Limits: map[middleware.MethodGroup]middleware.GroupLimit{
+			middleware.GroupQuery: {Capacity: 2, MaxQueueDepth: 0, QueueTimeout: 0},
+		},
+		MethodGroups: map[string]middleware.MethodGroup{
+			szengine.Prefix + "FindNetworkByEntityID": middleware.GroupQuery,
+		},
+		Weights: map[string]int64{
+			szengine.Prefix + "FindNetworkByEntityID": 2,
+		},
+	})
+	mock := &mockEngine{delay: shortDelay} //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, limiter.Interceptor())
+
+	var waitGroup sync.WaitGroup
+
+	for range 4 {
+		waitGroup.Go(func() {
+			_, err := engine.FindNetworkByEntityID(ctx, "{}", 1, 0, 0, senzing.SzNoFlags)
+			assert.NoError(test, err)
+		})
+	}
+
+	waitGroup.Wait()
+
+	stats := limiter.Stats()[middleware.GroupQuery]
+	assert.Equal(test, int64(4), stats.Admitted)
+	assert.Positive(test, stats.MaxWait)
+	assert.GreaterOrEqual(test, stats.TotalWait, stats.MaxWait)
+}
+
+func TestLimiter_CanceledWhileQueued(test *testing.T) {
+	test.Parallel()
+
+	limiter := middleware.NewLimiter(middleware.LimiterConfig{ //exhaustruct:ignore
+		Limits: map[middleware.MethodGroup]middleware.GroupLimit{
+			middleware.GroupQuery: {Capacity: 1, MaxQueueDepth: 0, QueueTimeout: 0},
+		},
+	})
+	mock := &mockEngine{delay: 10 * shortDelay} //exhaustruct:ignore
+	engine := middleware.NewEngine(mock, limiter.Interceptor())
+
+	var waitGroup sync.WaitGroup
+
+	waitGroup.Go(func() {
+		_, _ = engine.SearchByAttributes(context.TODO(), "{}", senzing.SzNoSearchProfile, senzing.SzNoFlags)
+	})
+
+	require.Eventually(test, func() bool {
+		return limiter.Stats()[middleware.GroupQuery].InUse == 1
+	}, longDelay, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), shortDelay)
+	defer cancel()
+
+	_, err := engine.SearchByAttributes(ctx, "{}", senzing.SzNoSearchProfile, senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	require.NotErrorIs(test, err, szerror.ErrSzResourceExhausted)
+	waitGroup.Wait()
+	assert.Equal(test, int64(1), limiter.Stats()[middleware.GroupQuery].Canceled)
+}
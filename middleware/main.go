@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Call struct describes a single SDK method call as seen by an [Interceptor].
+*/
+type Call struct {
+	// Method is the fully qualified method name, e.g. szengine.Prefix + "AddRecord".
+	Method string
+
+	// Invoke performs the call.  In a chain, it invokes the next interceptor.
+	Invoke func(ctx context.Context) (any, error)
+
+	// Release frees any native resource held by a successful result.
+	// It is used when a caller abandons a call whose result is returned later.
+	// It is nil for methods whose results hold no resources.
+	Release func(result any)
+}
+
+/*
+Type Interceptor func is called for each SDK method call.
+An interceptor may inspect the call, return early, or call call.Invoke(ctx) to proceed.
+*/
+type Interceptor func(ctx context.Context, call Call) (any, error)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Chain combines interceptors into a single interceptor.
+The first interceptor is the outermost; the last interceptor calls the wrapped implementation.
+
+Input
+  - interceptors: An ordered list of interceptors.
+
+Output
+  - A single interceptor.
+*/
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, call Call) (any, error) {
+		return runChain(ctx, call, interceptors)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func runChain(ctx context.Context, call Call, interceptors []Interceptor) (any, error) {
+	if len(interceptors) == 0 {
+		return call.Invoke(ctx)
+	}
+
+	next := call
+	next.Invoke = func(ctx context.Context) (any, error) {
+		return runChain(ctx, call, interceptors[1:])
+	}
+
+	return interceptors[0](ctx, next)
+}
+
+func invoke[T any](
+	ctx context.Context,
+	interceptor Interceptor,
+	method string,
+	function func(context.Context) (T, error),
+) (T, error) {
+	return invokeWithRelease(ctx, interceptor, method, function, nil)
+}
+
+func invokeWithRelease[T any](
+	ctx context.Context,
+	interceptor Interceptor,
+	method string,
+	function func(context.Context) (T, error),
+	release func(T),
+) (T, error) {
+	var result T
+
+	call := Call{
+		Method: method,
+		Invoke: func(ctx context.Context) (any, error) {
+			return function(ctx)
+		},
+		Release: nil,
+	}
+
+	if release != nil {
+		call.Release = func(result any) {
+			if typedResult, isTyped := result.(T); isTyped {
+				release(typedResult)
+			}
+		}
+	}
+
+	response, err := interceptor(ctx, call)
+	if typedResponse, isTyped := response.(T); isTyped {
+		result = typedResponse
+	}
+
+	return result, err //nolint:wrapcheck
+}
+
+func invokeError(
+	ctx context.Context,
+	interceptor Interceptor,
+	method string,
+	function func(context.Context) error,
+) error {
+	_, err := invoke(ctx, interceptor, method, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, function(ctx)
+	})
+
+	return err
+}
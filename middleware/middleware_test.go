@@ -0,0 +1,184 @@
+package middleware_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/middleware"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exportHandle uintptr = 42
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+// Unimplemented SzEngine methods panic via the nil embedded interface.
+type mockEngine struct {
+	senzing.SzEngine
+
+	delay      time.Duration
+	err        error
+	fetchDelay time.Duration
+	fragments  []string
+
+	mutex               sync.Mutex
+	calls               []string
+	closedHandles       []uintptr
+	closedWhileFetching bool
+	fetchCount          int
+	fetching            int
+}
+
+func (engine *mockEngine) record(method string) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.calls = append(engine.calls, method)
+}
+
+func (engine *mockEngine) sleep() {
+	time.Sleep(engine.delay)
+}
+
+func (engine *mockEngine) callCount() int {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	return len(engine.calls)
+}
+
+func (engine *mockEngine) closed() []uintptr {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	return append([]uintptr{}, engine.closedHandles...)
+}
+
+func (engine *mockEngine) AddRecord(_ context.Context, _, _, _ string, _ int64) (string, error) {
+	engine.record("AddRecord")
+	engine.sleep()
+
+	return "{}", engine.err
+}
+
+func (engine *mockEngine) CloseExportReport(_ context.Context, exportHandle uintptr) error {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.closedHandles = append(engine.closedHandles, exportHandle)
+	engine.closedWhileFetching = engine.closedWhileFetching || engine.fetching > 0
+
+	return nil
+}
+
+func (engine *mockEngine) ExportJSONEntityReport(_ context.Context, _ int64) (uintptr, error) {
+	engine.record("ExportJSONEntityReport")
+	engine.sleep()
+
+	return exportHandle, engine.err
+}
+
+func (engine *mockEngine) FetchNext(_ context.Context, _ uintptr) (string, error) {
+	engine.record("FetchNext")
+
+	engine.mutex.Lock()
+	engine.fetching++
+	engine.mutex.Unlock()
+
+	time.Sleep(engine.fetchDelay)
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.fetching--
+
+	if engine.fetchCount >= len(engine.fragments) {
+		return "", nil
+	}
+
+	engine.fetchCount++
+
+	return engine.fragments[engine.fetchCount-1], nil
+}
+
+func (engine *mockEngine) FindNetworkByEntityID(
+	_ context.Context,
+	_ string,
+	_, _, _, _ int64,
+) (string, error) {
+	engine.record("FindNetworkByEntityID")
+	engine.sleep()
+
+	return "{}", engine.err
+}
+
+func (engine *mockEngine) GetActiveConfigID(_ context.Context) (int64, error) {
+	engine.record("GetActiveConfigID")
+	engine.sleep()
+
+	return 1, engine.err
+}
+
+func (engine *mockEngine) SearchByAttributes(_ context.Context, _, _ string, _ int64) (string, error) {
+	engine.record("SearchByAttributes")
+	engine.sleep()
+
+	return "{}", engine.err
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestChain_Order(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	order := []string{}
+	tracer := func(name string) middleware.Interceptor {
+		return func(ctx context.Context, call middleware.Call) (any, error) {
+			order = append(order, name+":"+call.Method)
+
+			return call.Invoke(ctx)
+		}
+	}
+	engine := middleware.NewEngine(&mockEngine{}, tracer("outer"), tracer("inner")) //exhaustruct:ignore
+	result, err := engine.AddRecord(ctx, "TEST", "1", "{}", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.JSONEq(test, "{}", result)
+	assert.Equal(test, []string{"outer:szengine.AddRecord", "inner:szengine.AddRecord"}, order)
+}
+
+func TestChain_Empty(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	engine := middleware.NewEngine(&mockEngine{}) //exhaustruct:ignore
+	actual, err := engine.GetActiveConfigID(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), actual)
+}
+
+func TestEngine_ExportJSONEntityReportIterator(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.TODO()
+	mock := &mockEngine{fragments: []string{"a\n", "b\n", "c\n"}} //exhaustruct:ignore
+	engine := middleware.NewEngine(mock)
+	actual := ""
+
+	for fragment := range engine.ExportJSONEntityReportIterator(ctx, senzing.SzExportDefaultFlags) {
+		require.NoError(test, fragment.Error)
+
+		actual += fragment.Value
+	}
+
+	assert.Equal(test, "a\nb\nc\n", actual)
+	assert.Equal(test, []uintptr{exportHandle}, mock.closed())
+}
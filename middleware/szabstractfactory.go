@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Method name prefix for SzAbstractFactory methods.
+const AbstractFactoryPrefix = "szabstractfactory."
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type AbstractFactory struct is a [senzing.SzAbstractFactory] whose created objects
+route every call through an interceptor chain.
+*/
+type AbstractFactory struct {
+	interceptor       Interceptor
+	szAbstractFactory senzing.SzAbstractFactory
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewAbstractFactory wraps an SzAbstractFactory.
+
+Input
+  - szAbstractFactory: The SzAbstractFactory implementation to wrap.
+  - interceptors: An ordered list of interceptors. The first interceptor is the outermost.
+
+Output
+  - An SzAbstractFactory whose Create methods return wrapped objects.
+*/
+func NewAbstractFactory(szAbstractFactory senzing.SzAbstractFactory, interceptors ...Interceptor) *AbstractFactory {
+	return &AbstractFactory{
+		interceptor:       Chain(interceptors...),
+		szAbstractFactory: szAbstractFactory,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Close calls SzAbstractFactory.Close through the interceptor chain.
+*/
+func (factory *AbstractFactory) Close(ctx context.Context) error {
+	method := AbstractFactoryPrefix + "Close"
+
+	return invokeError(ctx, factory.interceptor, method, func(ctx context.Context) error {
+		return factory.szAbstractFactory.Close(ctx)
+	})
+}
+
+/*
+Method CreateConfigManager returns an SzConfigManager wrapped with the factory's interceptors.
+*/
+func (factory *AbstractFactory) CreateConfigManager(ctx context.Context) (senzing.SzConfigManager, error) {
+	szConfigManager, err := factory.szAbstractFactory.CreateConfigManager(ctx)
+	if err != nil {
+		return szConfigManager, err //nolint:wrapcheck
+	}
+
+	return NewConfigManager(szConfigManager, factory.interceptor), nil
+}
+
+/*
+Method CreateDiagnostic returns an SzDiagnostic wrapped with the factory's interceptors.
+*/
+func (factory *AbstractFactory) CreateDiagnostic(ctx context.Context) (senzing.SzDiagnostic, error) {
+	szDiagnostic, err := factory.szAbstractFactory.CreateDiagnostic(ctx)
+	if err != nil {
+		return szDiagnostic, err //nolint:wrapcheck
+	}
+
+	return NewDiagnostic(szDiagnostic, factory.interceptor), nil
+}
+
+/*
+Method CreateEngine returns an SzEngine wrapped with the factory's interceptors.
+*/
+func (factory *AbstractFactory) CreateEngine(ctx context.Context) (senzing.SzEngine, error) {
+	szEngine, err := factory.szAbstractFactory.CreateEngine(ctx)
+	if err != nil {
+		return szEngine, err //nolint:wrapcheck
+	}
+
+	return NewEngine(szEngine, factory.interceptor), nil
+}
+
+/*
+Method CreateProduct returns an SzProduct wrapped with the factory's interceptors.
+*/
+func (factory *AbstractFactory) CreateProduct(ctx context.Context) (senzing.SzProduct, error) {
+	szProduct, err := factory.szAbstractFactory.CreateProduct(ctx)
+	if err != nil {
+		return szProduct, err //nolint:wrapcheck
+	}
+
+	return NewProduct(szProduct, factory.interceptor), nil
+}
+
+/*
+Method Reinitialize calls SzAbstractFactory.Reinitialize through the interceptor chain.
+*/
+func (factory *AbstractFactory) Reinitialize(ctx context.Context, configID int64) error {
+	method := AbstractFactoryPrefix + "Reinitialize"
+
+	return invokeError(ctx, factory.interceptor, method, func(ctx context.Context) error {
+		return factory.szAbstractFactory.Reinitialize(ctx, configID)
+	})
+}
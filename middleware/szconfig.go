@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szconfig"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Config struct is a [senzing.SzConfig] that routes every call through an interceptor chain.
+*/
+type Config struct {
+	interceptor Interceptor
+	szConfig    senzing.SzConfig
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewConfig wraps an SzConfig.
+
+Input
+  - szConfig: The SzConfig implementation to wrap.
+  - interceptors: An ordered list of interceptors. The first interceptor is the outermost.
+
+Output
+  - An SzConfig that calls the interceptors before calling szConfig.
+*/
+func NewConfig(szConfig senzing.SzConfig, interceptors ...Interceptor) *Config {
+	return &Config{
+		interceptor: Chain(interceptors...),
+		szConfig:    szConfig,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Export calls SzConfig.Export through the interceptor chain.
+*/
+func (config *Config) Export(ctx context.Context) (string, error) {
+	method := szconfig.Prefix + "Export"
+
+	return invoke(ctx, config.interceptor, method, func(ctx context.Context) (string, error) {
+		return config.szConfig.Export(ctx)
+	})
+}
+
+/*
+Method GetDataSourceRegistry calls SzConfig.GetDataSourceRegistry through the interceptor chain.
+*/
+func (config *Config) GetDataSourceRegistry(ctx context.Context) (string, error) {
+	method := szconfig.Prefix + "GetDataSourceRegistry"
+
+	return invoke(ctx, config.interceptor, method, func(ctx context.Context) (string, error) {
+		return config.szConfig.GetDataSourceRegistry(ctx)
+	})
+}
+
+/*
+Method RegisterDataSource calls SzConfig.RegisterDataSource through the interceptor chain.
+*/
+func (config *Config) RegisterDataSource(ctx context.Context, dataSourceCode string) (string, error) {
+	method := szconfig.Prefix + "RegisterDataSource"
+
+	return invoke(ctx, config.interceptor, method, func(ctx context.Context) (string, error) {
+		return config.szConfig.RegisterDataSource(ctx, dataSourceCode)
+	})
+}
+
+/*
+Method UnregisterDataSource calls SzConfig.UnregisterDataSource through the interceptor chain.
+*/
+func (config *Config) UnregisterDataSource(ctx context.Context, dataSourceCode string) (string, error) {
+	method := szconfig.Prefix + "UnregisterDataSource"
+
+	return invoke(ctx, config.interceptor, method, func(ctx context.Context) (string, error) {
+		return config.szConfig.UnregisterDataSource(ctx, dataSourceCode)
+	})
+}
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szconfigmanager"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type ConfigManager struct is a [senzing.SzConfigManager] that routes every call through an interceptor chain.
+*/
+type ConfigManager struct {
+	interceptor     Interceptor
+	szConfigManager senzing.SzConfigManager
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewConfigManager wraps an SzConfigManager.
+
+Input
+  - szConfigManager: The SzConfigManager implementation to wrap.
+  - interceptors: An ordered list of interceptors. The first interceptor is the outermost.
+
+Output
+  - An SzConfigManager that calls the interceptors before calling szConfigManager.
+*/
+func NewConfigManager(szConfigManager senzing.SzConfigManager, interceptors ...Interceptor) *ConfigManager {
+	return &ConfigManager{
+		interceptor:     Chain(interceptors...),
+		szConfigManager: szConfigManager,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method CreateConfigFromConfigID calls SzConfigManager.CreateConfigFromConfigID through the interceptor chain.
+*/
+func (configManager *ConfigManager) CreateConfigFromConfigID(
+	ctx context.Context,
+	configID int64,
+) (senzing.SzConfig, error) {
+	method := szconfigmanager.Prefix + "CreateConfigFromConfigID"
+
+	szConfig, err := invoke(ctx, configManager.interceptor, method, func(ctx context.Context) (senzing.SzConfig, error) {
+		return configManager.szConfigManager.CreateConfigFromConfigID(ctx, configID)
+	})
+	if err != nil {
+		return szConfig, err
+	}
+
+	return NewConfig(szConfig, configManager.interceptor), nil
+}
+
+/*
+Method CreateConfigFromString calls SzConfigManager.CreateConfigFromString through the interceptor chain.
+*/
+func (configManager *ConfigManager) CreateConfigFromString(
+	ctx context.Context,
+	configDefinition string,
+) (senzing.SzConfig, error) {
+	method := szconfigmanager.Prefix + "CreateConfigFromString"
+
+	szConfig, err := invoke(ctx, configManager.interceptor, method, func(ctx context.Context) (senzing.SzConfig, error) {
+		return configManager.szConfigManager.CreateConfigFromString(ctx, configDefinition)
+	})
+	if err != nil {
+		return szConfig, err
+	}
+
+	return NewConfig(szConfig, configManager.interceptor), nil
+}
+
+/*
+Method CreateConfigFromTemplate calls SzConfigManager.CreateConfigFromTemplate through the interceptor chain.
+*/
+func (configManager *ConfigManager) CreateConfigFromTemplate(ctx context.Context) (senzing.SzConfig, error) {
+	method := szconfigmanager.Prefix + "CreateConfigFromTemplate"
+
+	szConfig, err := invoke(ctx, configManager.interceptor, method, func(ctx context.Context) (senzing.SzConfig, error) {
+		return configManager.szConfigManager.CreateConfigFromTemplate(ctx)
+	})
+	if err != nil {
+		return szConfig, err
+	}
+
+	return NewConfig(szConfig, configManager.interceptor), nil
+}
+
+/*
+Method Destroy calls SzConfigManager.Destroy through the interceptor chain.
+*/
+func (configManager *ConfigManager) Destroy(ctx context.Context) error {
+	method := szconfigmanager.Prefix + "Destroy"
+
+	return invokeError(ctx, configManager.interceptor, method, func(ctx context.Context) error {
+		return configManager.szConfigManager.Destroy(ctx)
+	})
+}
+
+/*
+Method GetConfigRegistry calls SzConfigManager.GetConfigRegistry through the interceptor chain.
+*/
+func (configManager *ConfigManager) GetConfigRegistry(ctx context.Context) (string, error) {
+	method := szconfigmanager.Prefix + "GetConfigRegistry"
+
+	return invoke(ctx, configManager.interceptor, method, func(ctx context.Context) (string, error) {
+		return configManager.szConfigManager.GetConfigRegistry(ctx)
+	})
+}
+
+/*
+Method GetDefaultConfigID calls SzConfigManager.GetDefaultConfigID through the interceptor chain.
+*/
+func (configManager *ConfigManager) GetDefaultConfigID(ctx context.Context) (int64, error) {
+	method := szconfigmanager.Prefix + "GetDefaultConfigID"
+
+	return invoke(ctx, configManager.interceptor, method, func(ctx context.Context) (int64, error) {
+		return configManager.szConfigManager.GetDefaultConfigID(ctx)
+	})
+}
+
+/*
+Method RegisterConfig calls SzConfigManager.RegisterConfig through the interceptor chain.
+*/
+func (configManager *ConfigManager) RegisterConfig(
+	ctx context.Context,
+	configDefinition string,
+	configComment string,
+) (int64, error) {
+	method := szconfigmanager.Prefix + "RegisterConfig"
+
+	return invoke(ctx, configManager.interceptor, method, func(ctx context.Context) (int64, error) {
+		return configManager.szConfigManager.RegisterConfig(ctx, configDefinition, configComment)
+	})
+}
+
+/*
+Method ReplaceDefaultConfigID calls SzConfigManager.ReplaceDefaultConfigID through the interceptor chain.
+*/
+func (configManager *ConfigManager) ReplaceDefaultConfigID(
+	ctx context.Context,
+	currentDefaultConfigID int64,
+	newDefaultConfigID int64,
+) error {
+	method := szconfigmanager.Prefix + "ReplaceDefaultConfigID"
+
+	return invokeError(ctx, configManager.interceptor, method, func(ctx context.Context) error {
+		return configManager.szConfigManager.ReplaceDefaultConfigID(ctx, currentDefaultConfigID, newDefaultConfigID)
+	})
+}
+
+/*
+Method SetDefaultConfig calls SzConfigManager.SetDefaultConfig through the interceptor chain.
+*/
+func (configManager *ConfigManager) SetDefaultConfig(
+	ctx context.Context,
+	configDefinition string,
+	configComment string,
+) (int64, error) {
+	method := szconfigmanager.Prefix + "SetDefaultConfig"
+
+	return invoke(ctx, configManager.interceptor, method, func(ctx context.Context) (int64, error) {
+		return configManager.szConfigManager.SetDefaultConfig(ctx, configDefinition, configComment)
+	})
+}
+
+/*
+Method SetDefaultConfigID calls SzConfigManager.SetDefaultConfigID through the interceptor chain.
+*/
+func (configManager *ConfigManager) SetDefaultConfigID(ctx context.Context, configID int64) error {
+	method := szconfigmanager.Prefix + "SetDefaultConfigID"
+
+	return invokeError(ctx, configManager.interceptor, method, func(ctx context.Context) error {
+		return configManager.szConfigManager.SetDefaultConfigID(ctx, configID)
+	})
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szdiagnostic"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Diagnostic struct is a [senzing.SzDiagnostic] that routes every call through an interceptor chain.
+*/
+type Diagnostic struct {
+	interceptor  Interceptor
+	szDiagnostic senzing.SzDiagnostic
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewDiagnostic wraps an SzDiagnostic.
+
+Input
+  - szDiagnostic: The SzDiagnostic implementation to wrap.
+  - interceptors: An ordered list of interceptors. The first interceptor is the outermost.
+
+Output
+  - An SzDiagnostic that calls the interceptors before calling szDiagnostic.
+*/
+func NewDiagnostic(szDiagnostic senzing.SzDiagnostic, interceptors ...Interceptor) *Diagnostic {
+	return &Diagnostic{
+		interceptor:  Chain(interceptors...),
+		szDiagnostic: szDiagnostic,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method CheckRepositoryPerformance calls SzDiagnostic.CheckRepositoryPerformance through the interceptor chain.
+*/
+func (diagnostic *Diagnostic) CheckRepositoryPerformance(ctx context.Context, secondsToRun int) (string, error) {
+	method := szdiagnostic.Prefix + "CheckRepositoryPerformance"
+
+	return invoke(ctx, diagnostic.interceptor, method, func(ctx context.Context) (string, error) {
+		return diagnostic.szDiagnostic.CheckRepositoryPerformance(ctx, secondsToRun)
+	})
+}
+
+/*
+Method Destroy calls SzDiagnostic.Destroy through the interceptor chain.
+*/
+func (diagnostic *Diagnostic) Destroy(ctx context.Context) error {
+	method := szdiagnostic.Prefix + "Destroy"
+
+	return invokeError(ctx, diagnostic.interceptor, method, func(ctx context.Context) error {
+		return diagnostic.szDiagnostic.Destroy(ctx)
+	})
+}
+
+/*
+Method GetFeature calls SzDiagnostic.GetFeature through the interceptor chain.
+*/
+func (diagnostic *Diagnostic) GetFeature(ctx context.Context, featureID int64) (string, error) {
+	method := szdiagnostic.Prefix + "GetFeature"
+
+	return invoke(ctx, diagnostic.interceptor, method, func(ctx context.Context) (string, error) {
+		return diagnostic.szDiagnostic.GetFeature(ctx, featureID)
+	})
+}
+
+/*
+Method GetRepositoryInfo calls SzDiagnostic.GetRepositoryInfo through the interceptor chain.
+*/
+func (diagnostic *Diagnostic) GetRepositoryInfo(ctx context.Context) (string, error) {
+	method := szdiagnostic.Prefix + "GetRepositoryInfo"
+
+	return invoke(ctx, diagnostic.interceptor, method, func(ctx context.Context) (string, error) {
+		return diagnostic.szDiagnostic.GetRepositoryInfo(ctx)
+	})
+}
+
+/*
+Method PurgeRepository calls SzDiagnostic.PurgeRepository through the interceptor chain.
+*/
+func (diagnostic *Diagnostic) PurgeRepository(ctx context.Context) error {
+	method := szdiagnostic.Prefix + "PurgeRepository"
+
+	return invokeError(ctx, diagnostic.interceptor, method, func(ctx context.Context) error {
+		return diagnostic.szDiagnostic.PurgeRepository(ctx)
+	})
+}
@@ -0,0 +1,660 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szengine"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Engine struct is a [senzing.SzEngine] that routes every call through an interceptor chain.
+*/
+type Engine struct {
+	interceptor Interceptor
+	szEngine    senzing.SzEngine
+}
+
+// Closes an export handle only once no FetchNext on it is running,
+// so that a FetchNext abandoned by the Deadline interceptor is not raced by CloseExportReport.
+type exportHandleCloser struct {
+	engine       *Engine
+	exportHandle uintptr
+	isClosed     bool
+	isFetching   bool
+	mutex        sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errExportHandleClosed = errors.New("export handle is closed")
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewEngine wraps an SzEngine.
+
+Input
+  - szEngine: The SzEngine implementation to wrap.
+  - interceptors: An ordered list of interceptors. The first interceptor is the outermost.
+
+Output
+  - An SzEngine that calls the interceptors before calling szEngine.
+*/
+func NewEngine(szEngine senzing.SzEngine, interceptors ...Interceptor) *Engine {
+	return &Engine{
+		interceptor: Chain(interceptors...),
+		szEngine:    szEngine,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method AddRecord calls SzEngine.AddRecord through the interceptor chain.
+*/
+func (engine *Engine) AddRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	recordDefinition string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "AddRecord"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.AddRecord(ctx, dataSourceCode, recordID, recordDefinition, flags)
+	})
+}
+
+/*
+Method CloseExportReport calls SzEngine.CloseExportReport through the interceptor chain.
+*/
+func (engine *Engine) CloseExportReport(ctx context.Context, exportHandle uintptr) error {
+	method := szengine.Prefix + "CloseExportReport"
+
+	return invokeError(ctx, engine.interceptor, method, func(ctx context.Context) error {
+		return engine.szEngine.CloseExportReport(ctx, exportHandle)
+	})
+}
+
+/*
+Method CountRedoRecords calls SzEngine.CountRedoRecords through the interceptor chain.
+*/
+func (engine *Engine) CountRedoRecords(ctx context.Context) (int64, error) {
+	method := szengine.Prefix + "CountRedoRecords"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (int64, error) {
+		return engine.szEngine.CountRedoRecords(ctx)
+	})
+}
+
+/*
+Method DeleteRecord calls SzEngine.DeleteRecord through the interceptor chain.
+*/
+func (engine *Engine) DeleteRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "DeleteRecord"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.DeleteRecord(ctx, dataSourceCode, recordID, flags)
+	})
+}
+
+/*
+Method Destroy calls SzEngine.Destroy through the interceptor chain.
+*/
+func (engine *Engine) Destroy(ctx context.Context) error {
+	method := szengine.Prefix + "Destroy"
+
+	return invokeError(ctx, engine.interceptor, method, func(ctx context.Context) error {
+		return engine.szEngine.Destroy(ctx)
+	})
+}
+
+/*
+Method ExportCsvEntityReport calls SzEngine.ExportCsvEntityReport through the interceptor chain.
+*/
+func (engine *Engine) ExportCsvEntityReport(ctx context.Context, csvColumnList string, flags int64) (uintptr, error) {
+	method := szengine.Prefix + "ExportCsvEntityReport"
+
+	return invokeWithRelease(ctx, engine.interceptor, method, func(ctx context.Context) (uintptr, error) {
+		return engine.szEngine.ExportCsvEntityReport(ctx, csvColumnList, flags)
+	}, engine.releaseExportHandle)
+}
+
+/*
+Method ExportCsvEntityReportIterator returns a channel of CSV export fragments.
+Unlike the wrapped implementation, the iterator is built from ExportCsvEntityReport, FetchNext,
+and CloseExportReport so that each step passes through the interceptor chain.
+*/
+func (engine *Engine) ExportCsvEntityReportIterator(
+	ctx context.Context,
+	csvColumnList string,
+	flags int64,
+) chan senzing.StringFragment {
+	return engine.exportIterator(ctx, func(ctx context.Context) (uintptr, error) {
+		return engine.ExportCsvEntityReport(ctx, csvColumnList, flags)
+	})
+}
+
+/*
+Method ExportJSONEntityReport calls SzEngine.ExportJSONEntityReport through the interceptor chain.
+*/
+func (engine *Engine) ExportJSONEntityReport(ctx context.Context, flags int64) (uintptr, error) {
+	method := szengine.Prefix + "ExportJSONEntityReport"
+
+	return invokeWithRelease(ctx, engine.interceptor, method, func(ctx context.Context) (uintptr, error) {
+		return engine.szEngine.ExportJSONEntityReport(ctx, flags)
+	}, engine.releaseExportHandle)
+}
+
+/*
+Method ExportJSONEntityReportIterator returns a channel of JSON export fragments.
+Unlike the wrapped implementation, the iterator is built from ExportJSONEntityReport, FetchNext,
+and CloseExportReport so that each step passes through the interceptor chain.
+*/
+func (engine *Engine) ExportJSONEntityReportIterator(ctx context.Context, flags int64) chan senzing.StringFragment {
+	return engine.exportIterator(ctx, func(ctx context.Context) (uintptr, error) {
+		return engine.ExportJSONEntityReport(ctx, flags)
+	})
+}
+
+/*
+Method FetchNext calls SzEngine.FetchNext through the interceptor chain.
+*/
+func (engine *Engine) FetchNext(ctx context.Context, exportHandle uintptr) (string, error) {
+	method := szengine.Prefix + "FetchNext"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.FetchNext(ctx, exportHandle)
+	})
+}
+
+/*
+Method FindInterestingEntitiesByEntityID calls SzEngine.FindInterestingEntitiesByEntityID through the interceptor chain.
+*/
+func (engine *Engine) FindInterestingEntitiesByEntityID(
+	ctx context.Context,
+	entityID int64,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "FindInterestingEntitiesByEntityID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.FindInterestingEntitiesByEntityID(ctx, entityID, flags)
+	})
+}
+
+/*
+Method FindInterestingEntitiesByRecordID calls SzEngine.FindInterestingEntitiesByRecordID through the interceptor chain.
+*/
+func (engine *Engine) FindInterestingEntitiesByRecordID(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "FindInterestingEntitiesByRecordID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.FindInterestingEntitiesByRecordID(ctx, dataSourceCode, recordID, flags)
+	})
+}
+
+/*
+Method FindNetworkByEntityID calls SzEngine.FindNetworkByEntityID through the interceptor chain.
+*/
+func (engine *Engine) FindNetworkByEntityID(
+	ctx context.Context,
+	entityIDs string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "FindNetworkByEntityID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.FindNetworkByEntityID(ctx, entityIDs, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags)
+	})
+}
+
+/*
+Method FindNetworkByRecordID calls SzEngine.FindNetworkByRecordID through the interceptor chain.
+*/
+func (engine *Engine) FindNetworkByRecordID(
+	ctx context.Context,
+	recordKeys string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "FindNetworkByRecordID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.FindNetworkByRecordID(ctx, recordKeys, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags)
+	})
+}
+
+/*
+Method FindPathByEntityID calls SzEngine.FindPathByEntityID through the interceptor chain.
+*/
+func (engine *Engine) FindPathByEntityID(
+	ctx context.Context,
+	startEntityID int64,
+	endEntityID int64,
+	maxDegrees int64,
+	avoidEntityIDs string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "FindPathByEntityID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.FindPathByEntityID(
+			ctx,
+			startEntityID,
+			endEntityID,
+			maxDegrees,
+			avoidEntityIDs,
+			requiredDataSources,
+			flags,
+		)
+	})
+}
+
+/*
+Method FindPathByRecordID calls SzEngine.FindPathByRecordID through the interceptor chain.
+*/
+func (engine *Engine) FindPathByRecordID(
+	ctx context.Context,
+	startDataSourceCode string,
+	startRecordID string,
+	endDataSourceCode string,
+	endRecordID string,
+	maxDegrees int64,
+	avoidRecordKeys string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "FindPathByRecordID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.FindPathByRecordID(
+			ctx,
+			startDataSourceCode,
+			startRecordID,
+			endDataSourceCode,
+			endRecordID,
+			maxDegrees,
+			avoidRecordKeys,
+			requiredDataSources,
+			flags,
+		)
+	})
+}
+
+/*
+Method GetActiveConfigID calls SzEngine.GetActiveConfigID through the interceptor chain.
+*/
+func (engine *Engine) GetActiveConfigID(ctx context.Context) (int64, error) {
+	method := szengine.Prefix + "GetActiveConfigID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (int64, error) {
+		return engine.szEngine.GetActiveConfigID(ctx)
+	})
+}
+
+/*
+Method GetEntityByEntityID calls SzEngine.GetEntityByEntityID through the interceptor chain.
+*/
+func (engine *Engine) GetEntityByEntityID(ctx context.Context, entityID int64, flags int64) (string, error) {
+	method := szengine.Prefix + "GetEntityByEntityID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.GetEntityByEntityID(ctx, entityID, flags)
+	})
+}
+
+/*
+Method GetEntityByRecordID calls SzEngine.GetEntityByRecordID through the interceptor chain.
+*/
+func (engine *Engine) GetEntityByRecordID(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "GetEntityByRecordID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.GetEntityByRecordID(ctx, dataSourceCode, recordID, flags)
+	})
+}
+
+/*
+Method GetRecord calls SzEngine.GetRecord through the interceptor chain.
+*/
+func (engine *Engine) GetRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "GetRecord"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.GetRecord(ctx, dataSourceCode, recordID, flags)
+	})
+}
+
+/*
+Method GetRecordPreview calls SzEngine.GetRecordPreview through the interceptor chain.
+*/
+func (engine *Engine) GetRecordPreview(ctx context.Context, recordDefinition string, flags int64) (string, error) {
+	method := szengine.Prefix + "GetRecordPreview"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.GetRecordPreview(ctx, recordDefinition, flags)
+	})
+}
+
+/*
+Method GetRedoRecord calls SzEngine.GetRedoRecord through the interceptor chain.
+*/
+func (engine *Engine) GetRedoRecord(ctx context.Context) (string, error) {
+	method := szengine.Prefix + "GetRedoRecord"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.GetRedoRecord(ctx)
+	})
+}
+
+/*
+Method GetStats calls SzEngine.GetStats through the interceptor chain.
+*/
+func (engine *Engine) GetStats(ctx context.Context) (string, error) {
+	method := szengine.Prefix + "GetStats"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.GetStats(ctx)
+	})
+}
+
+/*
+Method GetVirtualEntityByRecordID calls SzEngine.GetVirtualEntityByRecordID through the interceptor chain.
+*/
+func (engine *Engine) GetVirtualEntityByRecordID(
+	ctx context.Context,
+	recordKeys string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "GetVirtualEntityByRecordID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.GetVirtualEntityByRecordID(ctx, recordKeys, flags)
+	})
+}
+
+/*
+Method HowEntityByEntityID calls SzEngine.HowEntityByEntityID through the interceptor chain.
+*/
+func (engine *Engine) HowEntityByEntityID(ctx context.Context, entityID int64, flags int64) (string, error) {
+	method := szengine.Prefix + "HowEntityByEntityID"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.HowEntityByEntityID(ctx, entityID, flags)
+	})
+}
+
+/*
+Method PrimeEngine calls SzEngine.PrimeEngine through the interceptor chain.
+*/
+func (engine *Engine) PrimeEngine(ctx context.Context) error {
+	method := szengine.Prefix + "PrimeEngine"
+
+	return invokeError(ctx, engine.interceptor, method, func(ctx context.Context) error {
+		return engine.szEngine.PrimeEngine(ctx)
+	})
+}
+
+/*
+Method ProcessRedoRecord calls SzEngine.ProcessRedoRecord through the interceptor chain.
+*/
+func (engine *Engine) ProcessRedoRecord(ctx context.Context, redoRecord string, flags int64) (string, error) {
+	method := szengine.Prefix + "ProcessRedoRecord"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.ProcessRedoRecord(ctx, redoRecord, flags)
+	})
+}
+
+/*
+Method ReevaluateEntity calls SzEngine.ReevaluateEntity through the interceptor chain.
+*/
+func (engine *Engine) ReevaluateEntity(ctx context.Context, entityID int64, flags int64) (string, error) {
+	method := szengine.Prefix + "ReevaluateEntity"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.ReevaluateEntity(ctx, entityID, flags)
+	})
+}
+
+/*
+Method ReevaluateRecord calls SzEngine.ReevaluateRecord through the interceptor chain.
+*/
+func (engine *Engine) ReevaluateRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "ReevaluateRecord"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.ReevaluateRecord(ctx, dataSourceCode, recordID, flags)
+	})
+}
+
+/*
+Method SearchByAttributes calls SzEngine.SearchByAttributes through the interceptor chain.
+*/
+func (engine *Engine) SearchByAttributes(
+	ctx context.Context,
+	attributes string,
+	searchProfile string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "SearchByAttributes"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.SearchByAttributes(ctx, attributes, searchProfile, flags)
+	})
+}
+
+/*
+Method WhyEntities calls SzEngine.WhyEntities through the interceptor chain.
+*/
+func (engine *Engine) WhyEntities(
+	ctx context.Context,
+	entityID1 int64,
+	entityID2 int64,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "WhyEntities"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.WhyEntities(ctx, entityID1, entityID2, flags)
+	})
+}
+
+/*
+Method WhyRecordInEntity calls SzEngine.WhyRecordInEntity through the interceptor chain.
+*/
+func (engine *Engine) WhyRecordInEntity(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "WhyRecordInEntity"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.WhyRecordInEntity(ctx, dataSourceCode, recordID, flags)
+	})
+}
+
+/*
+Method WhyRecords calls SzEngine.WhyRecords through the interceptor chain.
+*/
+func (engine *Engine) WhyRecords(
+	ctx context.Context,
+	dataSourceCode1 string,
+	recordID1 string,
+	dataSourceCode2 string,
+	recordID2 string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "WhyRecords"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.WhyRecords(ctx, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
+	})
+}
+
+/*
+Method WhySearch calls SzEngine.WhySearch through the interceptor chain.
+*/
+func (engine *Engine) WhySearch(
+	ctx context.Context,
+	attributes string,
+	entityID int64,
+	searchProfile string,
+	flags int64,
+) (string, error) {
+	method := szengine.Prefix + "WhySearch"
+
+	return invoke(ctx, engine.interceptor, method, func(ctx context.Context) (string, error) {
+		return engine.szEngine.WhySearch(ctx, attributes, entityID, searchProfile, flags)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (engine *Engine) exportIterator(
+	ctx context.Context,
+	export func(context.Context) (uintptr, error),
+) chan senzing.StringFragment {
+	stringFragmentChannel := make(chan senzing.StringFragment)
+
+	go func() {
+		defer close(stringFragmentChannel)
+
+		exportHandle, err := export(ctx)
+		if err != nil {
+			sendStringFragment(ctx, stringFragmentChannel, senzing.StringFragment{Error: err, Value: ""})
+
+			return
+		}
+
+		closer := &exportHandleCloser{engine: engine, exportHandle: exportHandle} //exhaustruct:ignore
+		defer closer.close()
+
+		for {
+			fragment, err := invoke(ctx, engine.interceptor, szengine.Prefix+"FetchNext", closer.fetchNext)
+			if err != nil {
+				sendStringFragment(ctx, stringFragmentChannel, senzing.StringFragment{Error: err, Value: ""})
+
+				return
+			}
+
+			if len(fragment) == 0 {
+				return
+			}
+
+			if !sendStringFragment(ctx, stringFragmentChannel, senzing.StringFragment{Error: nil, Value: fragment}) {
+				return
+			}
+		}
+	}()
+
+	return stringFragmentChannel
+}
+
+// Close an export handle without the caller's context, which may already be done.
+func (engine *Engine) releaseExportHandle(exportHandle uintptr) {
+	_ = engine.szEngine.CloseExportReport(context.Background(), exportHandle)
+}
+
+// Closes the export handle now, or when the running FetchNext returns.
+func (closer *exportHandleCloser) close() {
+	closer.mutex.Lock()
+	defer closer.mutex.Unlock()
+
+	closer.isClosed = true
+	if !closer.isFetching {
+		closer.engine.releaseExportHandle(closer.exportHandle)
+	}
+}
+
+// Calls SzEngine.FetchNext unless the handle is closed. If the handle is closed meanwhile, it is released on return.
+func (closer *exportHandleCloser) fetchNext(ctx context.Context) (string, error) {
+	closer.mutex.Lock()
+	if closer.isClosed {
+		closer.mutex.Unlock()
+
+		return "", errExportHandleClosed
+	}
+
+	closer.isFetching = true
+	closer.mutex.Unlock()
+
+	defer func() {
+		closer.mutex.Lock()
+		defer closer.mutex.Unlock()
+
+		closer.isFetching = false
+		if closer.isClosed {
+			closer.engine.releaseExportHandle(closer.exportHandle)
+		}
+	}()
+
+	return closer.engine.szEngine.FetchNext(ctx, closer.exportHandle) //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func sendStringFragment(
+	ctx context.Context,
+	stringFragmentChannel chan senzing.StringFragment,
+	stringFragment senzing.StringFragment,
+) bool {
+	select {
+	case stringFragmentChannel <- stringFragment:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szproduct"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Product struct is a [senzing.SzProduct] that routes every call through an interceptor chain.
+*/
+type Product struct {
+	interceptor Interceptor
+	szProduct   senzing.SzProduct
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewProduct wraps an SzProduct.
+
+Input
+  - szProduct: The SzProduct implementation to wrap.
+  - interceptors: An ordered list of interceptors. The first interceptor is the outermost.
+
+Output
+  - An SzProduct that calls the interceptors before calling szProduct.
+*/
+func NewProduct(szProduct senzing.SzProduct, interceptors ...Interceptor) *Product {
+	return &Product{
+		interceptor: Chain(interceptors...),
+		szProduct:   szProduct,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Destroy calls SzProduct.Destroy through the interceptor chain.
+*/
+func (product *Product) Destroy(ctx context.Context) error {
+	method := szproduct.Prefix + "Destroy"
+
+	return invokeError(ctx, product.interceptor, method, func(ctx context.Context) error {
+		return product.szProduct.Destroy(ctx)
+	})
+}
+
+/*
+Method GetLicense calls SzProduct.GetLicense through the interceptor chain.
+*/
+func (product *Product) GetLicense(ctx context.Context) (string, error) {
+	method := szproduct.Prefix + "GetLicense"
+
+	return invoke(ctx, product.interceptor, method, func(ctx context.Context) (string, error) {
+		return product.szProduct.GetLicense(ctx)
+	})
+}
+
+/*
+Method GetVersion calls SzProduct.GetVersion through the interceptor chain.
+*/
+func (product *Product) GetVersion(ctx context.Context) (string, error) {
+	method := szproduct.Prefix + "GetVersion"
+
+	return invoke(ctx, product.interceptor, method, func(ctx context.Context) (string, error) {
+		return product.szProduct.GetVersion(ctx)
+	})
+}
@@ -0,0 +1,26 @@
+/*
+Package params builds and parses the JSON list parameters of senzing.SzEngine methods.
+
+Several SzEngine methods take lists as JSON strings of a specific shape:
+
+  - entityIDs and avoidEntityIDs: {"ENTITIES":[{"ENTITY_ID":1},{"ENTITY_ID":2}]}
+  - recordKeys and avoidRecordKeys: {"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}]}
+  - requiredDataSources: {"DATA_SOURCES":["CUSTOMERS","WATCHLIST"]}
+
+[EntityIDs], [RecordKeys], and [DataSources] build them from Go values and validate the values.
+[AvoidEntityIDs] and [AvoidRecordKeys] build the avoid lists, which may be empty (senzing.SzNoAvoidance).
+[ParseEntityIDs], [ParseRecordKeys], and [ParseDataSources] parse them back, e.g. for logging,
+and reject JSON of any other shape. Errors match both ErrInvalidParameter (or datasource.ErrInvalidCode)
+and szerror.ErrSzBadInput.
+
+Example:
+
+	recordKeys, err := params.RecordKeys(
+		params.RecordKey{DataSource: "CUSTOMERS", ID: "1001"},
+		params.RecordKey{DataSource: "WATCHLIST", ID: "2001"},
+	)
+	if err == nil {
+		result, err = szEngine.FindNetworkByRecordID(ctx, recordKeys, 2, 1, 10, senzing.SzFindNetworkDefaultFlags)
+	}
+*/
+package params
@@ -0,0 +1,328 @@
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go/datasource"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type RecordKey struct identifies a record by data source code and record ID.
+*/
+type RecordKey struct {
+	DataSource string `json:"DATA_SOURCE"`
+	ID         string `json:"RECORD_ID"`
+}
+
+type entityList struct {
+	Entities []entityListItem `json:"ENTITIES"`
+}
+
+type entityListItem struct {
+	EntityID int64 `json:"ENTITY_ID"`
+}
+
+type recordList struct {
+	Records []RecordKey `json:"RECORDS"`
+}
+
+type dataSourceList struct {
+	DataSources []string `json:"DATA_SOURCES"`
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidParameter is returned for invalid values and malformed JSON.
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function AvoidEntityIDs builds the avoidEntityIDs parameter of SzEngine.FindPathByEntityID,
+e.g. {"ENTITIES":[{"ENTITY_ID":1}]}.
+
+Input
+  - entityIDs: The entity IDs to avoid. Each must be positive.
+
+Output
+  - The JSON, or senzing.SzNoAvoidance if no entity IDs are given.
+*/
+func AvoidEntityIDs(entityIDs ...int64) (string, error) {
+	if len(entityIDs) == 0 {
+		return senzing.SzNoAvoidance, nil
+	}
+
+	return EntityIDs(entityIDs...)
+}
+
+/*
+Function AvoidRecordKeys builds the avoidRecordKeys parameter of SzEngine.FindPathByRecordID,
+e.g. {"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}]}.
+
+Input
+  - recordKeys: The record keys to avoid. See RecordKeys.
+
+Output
+  - The JSON, or senzing.SzNoAvoidance if no record keys are given.
+*/
+func AvoidRecordKeys(recordKeys ...RecordKey) (string, error) {
+	if len(recordKeys) == 0 {
+		return senzing.SzNoAvoidance, nil
+	}
+
+	return RecordKeys(recordKeys...)
+}
+
+/*
+Function DataSources builds the requiredDataSources parameter of SzEngine.FindPathByEntityID
+and SzEngine.FindPathByRecordID, e.g. {"DATA_SOURCES":["CUSTOMERS","WATCHLIST"]}.
+
+Input
+  - dataSourceCodes: The data source codes. They are normalized with datasource.Parse.
+
+Output
+  - The JSON, or senzing.SzNoRequiredDatasources if no codes are given.
+*/
+func DataSources(dataSourceCodes ...string) (string, error) {
+	if len(dataSourceCodes) == 0 {
+		return senzing.SzNoRequiredDatasources, nil
+	}
+
+	codes, err := datasource.ParseAll(dataSourceCodes...)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	value := dataSourceList{DataSources: make([]string, 0, len(codes))}
+	for _, code := range codes {
+		value.DataSources = append(value.DataSources, code.String())
+	}
+
+	return marshal(value)
+}
+
+/*
+Function EntityIDs builds the entityIDs parameter of SzEngine.FindNetworkByEntityID,
+e.g. {"ENTITIES":[{"ENTITY_ID":1}]}. For avoidEntityIDs, use AvoidEntityIDs.
+
+Input
+  - entityIDs: The entity IDs. At least one is required, and each must be positive.
+
+Output
+  - The JSON.
+*/
+func EntityIDs(entityIDs ...int64) (string, error) {
+	if len(entityIDs) == 0 {
+		return "", invalidParameter("no entity IDs")
+	}
+
+	value := entityList{Entities: make([]entityListItem, 0, len(entityIDs))}
+
+	for _, entityID := range entityIDs {
+		if entityID <= 0 {
+			return "", invalidParameter(fmt.Sprintf("entity ID %d is not positive", entityID))
+		}
+
+		value.Entities = append(value.Entities, entityListItem{EntityID: entityID})
+	}
+
+	return marshal(value)
+}
+
+/*
+Function ParseDataSources parses a requiredDataSources parameter, e.g. for logging.
+
+Input
+  - dataSourcesJSON: The JSON built by DataSources, or senzing.SzNoRequiredDatasources.
+
+Output
+  - The normalized data source codes.
+*/
+func ParseDataSources(dataSourcesJSON string) ([]string, error) {
+	if dataSourcesJSON == senzing.SzNoRequiredDatasources {
+		return []string{}, nil
+	}
+
+	value := dataSourceList{} //exhaustruct:ignore
+
+	err := unmarshal(dataSourcesJSON, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := datasource.ParseAll(value.DataSources...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	result := make([]string, 0, len(codes))
+	for _, code := range codes {
+		result = append(result, code.String())
+	}
+
+	return result, nil
+}
+
+/*
+Function ParseEntityIDs parses an entityIDs or avoidEntityIDs parameter, e.g. for logging.
+
+Input
+  - entityIDsJSON: The JSON built by EntityIDs or AvoidEntityIDs.
+
+Output
+  - The entity IDs.
+*/
+func ParseEntityIDs(entityIDsJSON string) ([]int64, error) {
+	if entityIDsJSON == senzing.SzNoAvoidance {
+		return []int64{}, nil
+	}
+
+	value := entityList{} //exhaustruct:ignore
+
+	err := unmarshal(entityIDsJSON, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]int64, 0, len(value.Entities))
+
+	for _, entity := range value.Entities {
+		if entity.EntityID <= 0 {
+			return nil, invalidParameter(fmt.Sprintf("entity ID %d is not positive", entity.EntityID))
+		}
+
+		result = append(result, entity.EntityID)
+	}
+
+	return result, nil
+}
+
+/*
+Function ParseRecordKeys parses a recordKeys or avoidRecordKeys parameter, e.g. for logging.
+
+Input
+  - recordKeysJSON: The JSON built by RecordKeys or AvoidRecordKeys.
+
+Output
+  - The record keys, with normalized data source codes.
+*/
+func ParseRecordKeys(recordKeysJSON string) ([]RecordKey, error) {
+	if recordKeysJSON == senzing.SzNoAvoidance {
+		return []RecordKey{}, nil
+	}
+
+	value := recordList{} //exhaustruct:ignore
+
+	err := unmarshal(recordKeysJSON, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeRecordKeys(value.Records)
+}
+
+/*
+Function RecordKeys builds the recordKeys parameter of SzEngine.FindNetworkByRecordID and
+SzEngine.GetVirtualEntityByRecordID, e.g. {"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}]}.
+For avoidRecordKeys, use AvoidRecordKeys.
+
+Input
+  - recordKeys: The record keys. At least one is required. Data source codes are normalized
+    with datasource.Parse; record IDs must not be empty.
+
+Output
+  - The JSON.
+*/
+func RecordKeys(recordKeys ...RecordKey) (string, error) {
+	if len(recordKeys) == 0 {
+		return "", invalidParameter("no record keys")
+	}
+
+	normalized, err := normalizeRecordKeys(recordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(recordList{Records: normalized})
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method String returns the record key as "DATA_SOURCE:RECORD_ID".
+*/
+func (recordKey RecordKey) String() string {
+	return recordKey.DataSource + ":" + recordKey.ID
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func invalidParameter(reason string) error {
+	return szerror.Wrap(
+		fmt.Errorf("%w: %s", ErrInvalidParameter, reason),
+		szerror.SzBadInputError,
+		szerror.SzError,
+	)
+}
+
+func marshal(value any) (string, error) {
+	result, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("params cannot marshal %v: %w", value, err)
+	}
+
+	return string(result), nil
+}
+
+func normalizeRecordKeys(recordKeys []RecordKey) ([]RecordKey, error) {
+	result := make([]RecordKey, 0, len(recordKeys))
+
+	for _, recordKey := range recordKeys {
+		code, err := datasource.Parse(recordKey.DataSource)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		if recordKey.ID == "" {
+			return nil, invalidParameter("record ID for data source " + code.String() + " is empty")
+		}
+
+		result = append(result, RecordKey{DataSource: code.String(), ID: recordKey.ID})
+	}
+
+	return result, nil
+}
+
+// Unknown fields are rejected, so a misspelled key such as "ENTITY_IDS" is reported instead of ignored.
+func unmarshal(value string, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(target)
+	if err != nil {
+		return szerror.Wrap(
+			fmt.Errorf("%w: cannot unmarshal %s: %w", ErrInvalidParameter, value, err),
+			szerror.SzBadInputError,
+			szerror.SzError,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package params_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/datasource"
+	"github.com/senzing-garage/sz-sdk-go/params"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestEntityIDs(test *testing.T) {
+	test.Parallel()
+
+	actual, err := params.EntityIDs(1, 2, 3)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"ENTITIES":[{"ENTITY_ID":1},{"ENTITY_ID":2},{"ENTITY_ID":3}]}`, actual)
+
+	entityIDs, err := params.ParseEntityIDs(actual)
+	require.NoError(test, err)
+	assert.Equal(test, []int64{1, 2, 3}, entityIDs)
+
+	_, err = params.EntityIDs()
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	actual, err = params.AvoidEntityIDs()
+	require.NoError(test, err)
+	assert.Equal(test, senzing.SzNoAvoidance, actual)
+
+	actual, err = params.AvoidEntityIDs(4)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"ENTITIES":[{"ENTITY_ID":4}]}`, actual)
+
+	_, err = params.EntityIDs(1, 0)
+	require.ErrorIs(test, err, params.ErrInvalidParameter)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestParseEntityIDs_Bad(test *testing.T) {
+	test.Parallel()
+
+	for _, bad := range []string{
+		`{"ENTITIES":[{"ENTITY_IDS":1}]}`,
+		`{"ENTITIES":[1,2]}`,
+		`{"ENTITIES":[{"ENTITY_ID":-1}]}`,
+		`[1,2]`,
+	} {
+		_, err := params.ParseEntityIDs(bad)
+		require.ErrorIs(test, err, params.ErrInvalidParameter, bad)
+	}
+
+	entityIDs, err := params.ParseEntityIDs(senzing.SzNoAvoidance)
+	require.NoError(test, err)
+	assert.Empty(test, entityIDs)
+}
+
+func TestRecordKeys(test *testing.T) {
+	test.Parallel()
+
+	actual, err := params.RecordKeys(
+		params.RecordKey{DataSource: "customers", ID: "1001"},
+		params.RecordKey{DataSource: "WATCHLIST", ID: "2001"},
+	)
+	require.NoError(test, err)
+	assert.JSONEq(test,
+		`{"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},{"DATA_SOURCE":"WATCHLIST","RECORD_ID":"2001"}]}`,
+		actual)
+
+	recordKeys, err := params.ParseRecordKeys(actual)
+	require.NoError(test, err)
+	require.Len(test, recordKeys, 2)
+	assert.Equal(test, "CUSTOMERS:1001", recordKeys[0].String())
+
+	_, err = params.RecordKeys(params.RecordKey{DataSource: "CUSTOMERS", ID: ""})
+	require.ErrorIs(test, err, params.ErrInvalidParameter)
+
+	_, err = params.RecordKeys(params.RecordKey{DataSource: "MY CUSTOMERS", ID: "1"})
+	require.ErrorIs(test, err, datasource.ErrInvalidCode)
+
+	_, err = params.ParseRecordKeys(`{"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORDID":"1"}]}`)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.ErrorIs(test, err, params.ErrInvalidParameter)
+
+	_, err = params.RecordKeys()
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	actual, err = params.AvoidRecordKeys()
+	require.NoError(test, err)
+	assert.Equal(test, senzing.SzNoAvoidance, actual)
+}
+
+func TestDataSources(test *testing.T) {
+	test.Parallel()
+
+	actual, err := params.DataSources("customers", "WATCHLIST")
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES":["CUSTOMERS","WATCHLIST"]}`, actual)
+
+	dataSources, err := params.ParseDataSources(actual)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"CUSTOMERS", "WATCHLIST"}, dataSources)
+
+	actual, err = params.DataSources()
+	require.NoError(test, err)
+	assert.Equal(test, senzing.SzNoRequiredDatasources, actual)
+
+	_, err = params.DataSources("A", "B!")
+	require.ErrorIs(test, err, datasource.ErrInvalidCode)
+
+	_, err = params.ParseDataSources(`{"DATA_SOURCES":"CUSTOMERS"}`)
+	require.ErrorIs(test, err, params.ErrInvalidParameter)
+}
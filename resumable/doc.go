@@ -0,0 +1,27 @@
+/*
+Package resumable runs SzEngine.ExportJSONEntityReport so that a failed export can be
+resumed instead of restarted.
+
+[Run] sends each exported entity to a [Sink] and, every Config.CheckpointEvery entities,
+commits the sink and saves a [Checkpoint] to a local file: the number of entities and bytes
+emitted, the last entity ID, and a SHA-256 checksum of the emitted lines. Running again with
+the same checkpoint file starts a new export, reads past the entities the checkpoint holds,
+checks that they match it, and emits only the rest. A [FileSink] truncates its file to the
+checkpoint on resume, so every entity is in the file exactly once:
+
+	sink := resumable.NewFileSink("export.jsonl")
+	defer sink.Close()
+	checkpoint, err := resumable.Run(ctx, szEngine, "export.checkpoint", sink, resumable.Config{})
+	...
+
+If the repository changed since the checkpoint was saved, the export no longer starts with
+the same entities and Run returns an error wrapping [ErrCheckpointMismatch]. Remove the
+checkpoint and the output to start again.
+
+When the export is complete, [Verify] checks the output against the final checkpoint:
+
+	file, err := os.Open("export.jsonl")
+	...
+	err = resumable.Verify(file, checkpoint)
+*/
+package resumable
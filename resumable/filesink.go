@@ -0,0 +1,130 @@
+package resumable
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type FileSink struct is a [Sink] that writes entities to a file as lines of JSON.
+On Resume it truncates the file to the byte count of the checkpoint, dropping entities
+written after the last checkpoint. Create one with [NewFileSink].
+*/
+type FileSink struct {
+	file   *os.File
+	path   string
+	writer *bufio.Writer
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewFileSink creates a FileSink. The file is opened by Resume.
+*/
+func NewFileSink(path string) *FileSink {
+	return &FileSink{file: nil, path: path, writer: nil}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Commit flushes and syncs the file.
+*/
+func (sink *FileSink) Commit(_ context.Context, _ Checkpoint) error {
+	err := sink.writer.Flush()
+	if err != nil {
+		return fmt.Errorf("resumable cannot write %s: %w", sink.path, err)
+	}
+
+	err = sink.file.Sync()
+	if err != nil {
+		return fmt.Errorf("resumable cannot sync %s: %w", sink.path, err)
+	}
+
+	return nil
+}
+
+/*
+Method Emit writes a line.
+*/
+func (sink *FileSink) Emit(_ context.Context, _ int64, line []byte) error {
+	_, err := sink.writer.Write(line)
+	if err == nil {
+		err = sink.writer.WriteByte('\n')
+	}
+
+	if err != nil {
+		return fmt.Errorf("resumable cannot write %s: %w", sink.path, err)
+	}
+
+	return nil
+}
+
+/*
+Method Resume opens the file, creating it if needed, and truncates it to checkpoint.Bytes.
+*/
+func (sink *FileSink) Resume(_ context.Context, checkpoint Checkpoint) error {
+	file, err := os.OpenFile(sink.path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("resumable cannot open %s: %w", sink.path, err)
+	}
+
+	info, err := file.Stat()
+	if err == nil && info.Size() < checkpoint.Bytes {
+		err = fmt.Errorf("%w: %s has %d bytes, not at least %d", ErrIntegrity, sink.path, info.Size(), checkpoint.Bytes)
+	}
+
+	if err == nil {
+		err = file.Truncate(checkpoint.Bytes)
+	}
+
+	if err == nil {
+		_, err = file.Seek(0, io.SeekEnd)
+	}
+
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("resumable cannot resume %s: %w", sink.path, err)
+	}
+
+	sink.file = file
+	sink.writer = bufio.NewWriter(file)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Close closes the file without flushing it. Entities emitted after the last Commit are
+not kept, as they will be emitted again when the export is resumed.
+*/
+func (sink *FileSink) Close() error {
+	if sink.file == nil {
+		return nil
+	}
+
+	err := sink.file.Close()
+	sink.file = nil
+	sink.writer = nil
+
+	if err != nil {
+		return fmt.Errorf("resumable cannot close %s: %w", sink.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,396 @@
+package resumable
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Checkpoint struct is the progress of an export: what has been emitted so far.
+The counts and checksum cover the emitted lines, each followed by a newline.
+*/
+type Checkpoint struct {
+	Bytes        int64  `json:"bytes"`
+	Checksum     string `json:"checksum"` // Hex SHA-256 of the emitted lines.
+	Entities     int64  `json:"entities"`
+	Flags        int64  `json:"flags"`
+	IsComplete   bool   `json:"complete"`
+	LastEntityID int64  `json:"lastEntityId"`
+}
+
+/*
+Type Config struct configures [Run].
+*/
+type Config struct {
+	CheckpointEvery int64 // Entities between checkpoints. Default: DefaultCheckpointEvery.
+	Flags           int64 // Export flags. Default: senzing.SzExportDefaultFlags.
+}
+
+/*
+Type Sink interface receives exported entities. For each entity to be seen exactly once,
+Resume must discard whatever was emitted after the checkpoint it is given,
+e.g. by truncating a file or rolling back a transaction. [FileSink] does.
+*/
+type Sink interface {
+	// Commit makes the entities emitted so far durable. The checkpoint is saved after Commit returns.
+	Commit(ctx context.Context, checkpoint Checkpoint) error
+	// Emit receives one entity of the export as a line of JSON. The line is reused after Emit returns.
+	Emit(ctx context.Context, entityID int64, line []byte) error
+	// Resume is called once, before the first Emit, with the saved checkpoint, or a zero checkpoint.
+	Resume(ctx context.Context, checkpoint Checkpoint) error
+}
+
+type entityResponse struct {
+	ResolvedEntity struct {
+		EntityID int64 `json:"ENTITY_ID"`
+	} `json:"RESOLVED_ENTITY"`
+}
+
+type progress struct {
+	checkpoint Checkpoint
+	hash       hash.Hash
+}
+
+type runner struct {
+	checkpointPath string
+	config         Config
+	progress       *progress
+	saved          Checkpoint
+	sink           Sink
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultCheckpointEvery is the entities between checkpoints when Config.CheckpointEvery is not set.
+const DefaultCheckpointEvery = 1000
+
+// The longest line Verify reads. An entity with about 100,000 records fits.
+const maxLineSize = 16 * 1024 * 1024
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrCheckpointMismatch is returned when a resumed export does not begin with the entities
+// of the checkpoint, e.g. because data was loaded since, or the flags differ.
+var ErrCheckpointMismatch = errors.New("resumable export does not match the checkpoint")
+
+// ErrIntegrity is returned by Verify when output does not match its checkpoint.
+var ErrIntegrity = errors.New("resumable output does not match the checkpoint")
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+func newProgress(flags int64) *progress {
+	return &progress{
+		checkpoint: Checkpoint{Flags: flags}, //exhaustruct:ignore
+		hash:       sha256.New(),
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function ReadCheckpoint reads a checkpoint file.
+
+Input
+  - path: The checkpoint file.
+
+Output
+  - The checkpoint. A zero checkpoint if the file does not exist.
+*/
+func ReadCheckpoint(path string) (Checkpoint, error) {
+	result := Checkpoint{} //exhaustruct:ignore
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, nil
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("resumable cannot read checkpoint: %w", err)
+	}
+
+	err = json.Unmarshal(content, &result)
+	if err != nil {
+		return result, fmt.Errorf("resumable cannot unmarshal checkpoint %s: %w", path, err)
+	}
+
+	return result, nil
+}
+
+/*
+Function Run exports with SzEngine.ExportJSONEntityReport into a sink, saving a checkpoint
+every Config.CheckpointEvery entities and at the end. If the checkpoint file exists, the
+export is resumed: the entities it records are read again, checked against its count,
+byte count, checksum, and last entity ID, and not emitted.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - checkpointPath: The checkpoint file. It is replaced atomically each time it is saved.
+  - sink: Receives each entity once.
+  - config: The checkpoint interval and export flags.
+
+Output
+  - The last checkpoint saved. If the checkpoint file records a complete export, it is returned without exporting.
+*/
+func Run(
+	ctx context.Context,
+	szEngine senzing.SzEngine,
+	checkpointPath string,
+	sink Sink,
+	config Config,
+) (result Checkpoint, err error) {
+	if config.CheckpointEvery < 1 {
+		config.CheckpointEvery = DefaultCheckpointEvery
+	}
+
+	if config.Flags == 0 {
+		config.Flags = senzing.SzExportDefaultFlags
+	}
+
+	saved, err := ReadCheckpoint(checkpointPath)
+	if err != nil || saved.IsComplete {
+		return saved, err
+	}
+
+	if saved.Entities > 0 && saved.Flags != config.Flags {
+		return saved, fmt.Errorf("%w: flags %d, not %d", ErrCheckpointMismatch, config.Flags, saved.Flags)
+	}
+
+	err = sink.Resume(ctx, saved)
+	if err != nil {
+		return saved, fmt.Errorf("resumable cannot resume sink: %w", err)
+	}
+
+	exportHandle, err := senzing.OpenJSONExport(ctx, szEngine, config.Flags, nil)
+	if err != nil {
+		return saved, err //nolint:wrapcheck
+	}
+
+	defer func() {
+		closeErr := exportHandle.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	runner := &runner{
+		checkpointPath: checkpointPath,
+		config:         config,
+		progress:       newProgress(config.Flags),
+		saved:          saved,
+		sink:           sink,
+	}
+
+	for line, err := range exportHandle.Lines() {
+		if err != nil {
+			return runner.saved, err //nolint:wrapcheck
+		}
+
+		err = runner.add(ctx, line)
+		if err != nil {
+			return runner.saved, err
+		}
+	}
+
+	if runner.progress.checkpoint.Entities < saved.Entities {
+		return runner.saved, fmt.Errorf("%w: the export has %d entities, not at least %d",
+			ErrCheckpointMismatch, runner.progress.checkpoint.Entities, saved.Entities)
+	}
+
+	err = runner.save(ctx, true)
+
+	return runner.saved, err
+}
+
+/*
+Function Verify checks that output holds exactly the lines a checkpoint records,
+e.g. the file written by a [FileSink].
+
+Input
+  - reader: The output, as lines of JSON.
+  - checkpoint: The checkpoint saved by Run.
+
+Output
+  - An error wrapping ErrIntegrity if the count, byte count, checksum, or last entity ID differ.
+*/
+func Verify(reader io.Reader, checkpoint Checkpoint) error {
+	actual := newProgress(checkpoint.Flags)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	for scanner.Scan() {
+		err := actual.add(scanner.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return fmt.Errorf("resumable cannot read output: %w", err)
+	}
+
+	return actual.compare(checkpoint, ErrIntegrity)
+}
+
+/*
+Function WriteCheckpoint saves a checkpoint file atomically, by writing a temporary file and renaming it.
+*/
+func WriteCheckpoint(path string, checkpoint Checkpoint) error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("resumable cannot marshal checkpoint: %w", err)
+	}
+
+	temporaryPath := path + ".tmp"
+
+	file, err := os.Create(temporaryPath)
+	if err != nil {
+		return fmt.Errorf("resumable cannot write checkpoint: %w", err)
+	}
+
+	_, err = file.Write(content)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(temporaryPath, path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("resumable cannot write checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Counts and hashes one line. Blank lines are not entities.
+func (progress *progress) add(line []byte) error {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil
+	}
+
+	entity := entityResponse{} //exhaustruct:ignore
+
+	err := json.Unmarshal(line, &entity)
+	if err != nil {
+		return fmt.Errorf("resumable cannot unmarshal entity %d: %w", progress.checkpoint.Entities+1, err)
+	}
+
+	progress.hash.Write(line)
+	progress.hash.Write([]byte("\n"))
+	progress.checkpoint.Bytes += int64(len(line)) + 1
+	progress.checkpoint.Entities++
+	progress.checkpoint.LastEntityID = entity.ResolvedEntity.EntityID
+	progress.checkpoint.Checksum = ""
+
+	return nil
+}
+
+func (progress *progress) compare(expected Checkpoint, sentinel error) error {
+	actual := progress.current()
+
+	switch {
+	case actual.Entities != expected.Entities:
+		return fmt.Errorf("%w: %d entities, not %d", sentinel, actual.Entities, expected.Entities)
+	case actual.Bytes != expected.Bytes:
+		return fmt.Errorf("%w: %d bytes, not %d", sentinel, actual.Bytes, expected.Bytes)
+	case actual.LastEntityID != expected.LastEntityID:
+		return fmt.Errorf("%w: last entity %d, not %d", sentinel, actual.LastEntityID, expected.LastEntityID)
+	case actual.Checksum != expected.Checksum:
+		return fmt.Errorf("%w: checksum %s, not %s", sentinel, actual.Checksum, expected.Checksum)
+	default:
+		return nil
+	}
+}
+
+func (progress *progress) current() Checkpoint {
+	result := progress.checkpoint
+	result.Checksum = hex.EncodeToString(progress.hash.Sum(nil))
+
+	return result
+}
+
+// Counts one line of the export, and emits it if the saved checkpoint does not already hold it.
+func (runner *runner) add(ctx context.Context, line []byte) error {
+	before := runner.progress.checkpoint.Entities
+
+	err := runner.progress.add(line)
+	if err != nil || runner.progress.checkpoint.Entities == before {
+		return err
+	}
+
+	entities := runner.progress.checkpoint.Entities
+	if entities < runner.saved.Entities {
+		return nil
+	}
+
+	if entities == runner.saved.Entities {
+		return runner.progress.compare(runner.saved, ErrCheckpointMismatch)
+	}
+
+	err = runner.sink.Emit(ctx, runner.progress.checkpoint.LastEntityID, line)
+	if err != nil {
+		return fmt.Errorf("resumable cannot emit entity %d: %w", runner.progress.checkpoint.LastEntityID, err)
+	}
+
+	if entities-runner.saved.Entities >= runner.config.CheckpointEvery {
+		return runner.save(ctx, false)
+	}
+
+	return nil
+}
+
+// Commits the sink, then saves the checkpoint.
+func (runner *runner) save(ctx context.Context, isComplete bool) error {
+	checkpoint := runner.progress.current()
+	checkpoint.IsComplete = isComplete
+
+	err := runner.sink.Commit(ctx, checkpoint)
+	if err != nil {
+		return fmt.Errorf("resumable cannot commit sink: %w", err)
+	}
+
+	err = WriteCheckpoint(runner.checkpointPath, checkpoint)
+	if err != nil {
+		return err
+	}
+
+	runner.saved = checkpoint
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package resumable_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/internal/exporttest"
+	"github.com/senzing-garage/sz-sdk-go/resumable"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockSink struct {
+	commits int
+	emitted []int64
+	resumed []resumable.Checkpoint
+}
+
+func (sink *mockSink) Commit(_ context.Context, _ resumable.Checkpoint) error {
+	sink.commits++
+
+	return nil
+}
+
+func (sink *mockSink) Emit(_ context.Context, entityID int64, _ []byte) error {
+	sink.emitted = append(sink.emitted, entityID)
+
+	return nil
+}
+
+func (sink *mockSink) Resume(_ context.Context, checkpoint resumable.Checkpoint) error {
+	sink.resumed = append(sink.resumed, checkpoint)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func export(entityIDs ...int64) string {
+	var result strings.Builder
+
+	for _, entityID := range entityIDs {
+		fmt.Fprintf(&result,
+			`{"RESOLVED_ENTITY": {"ENTITY_ID": %d, "RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "%d"}]}}`+"\n",
+			entityID, 1000+entityID)
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRun(test *testing.T) {
+	test.Parallel()
+
+	directory := test.TempDir()
+	checkpointPath := filepath.Join(directory, "export.checkpoint")
+	engine := &exporttest.Engine{ChunkSize: 50, Export: export(1, 2, 3, 5, 8)} //exhaustruct:ignore
+	sink := &mockSink{}                                                        //exhaustruct:ignore
+	config := resumable.Config{CheckpointEvery: 2}                             //exhaustruct:ignore
+
+	checkpoint, err := resumable.Run(test.Context(), engine, checkpointPath, sink, config)
+	require.NoError(test, err)
+	assert.True(test, engine.IsClosed)
+	assert.True(test, checkpoint.IsComplete)
+	assert.Equal(test, int64(5), checkpoint.Entities)
+	assert.Equal(test, int64(len(engine.Export)), checkpoint.Bytes)
+	assert.Equal(test, int64(8), checkpoint.LastEntityID)
+	assert.Equal(test, senzing.SzExportDefaultFlags, checkpoint.Flags)
+	assert.Equal(test, []int64{1, 2, 3, 5, 8}, sink.emitted)
+	assert.Equal(test, 3, sink.commits)
+	assert.Len(test, sink.resumed, 1)
+	require.NoError(test, resumable.Verify(strings.NewReader(engine.Export), checkpoint))
+
+	saved, err := resumable.ReadCheckpoint(checkpointPath)
+	require.NoError(test, err)
+	assert.Equal(test, checkpoint, saved)
+
+	// A complete export is not run again.
+	again, err := resumable.Run(test.Context(), engine, checkpointPath, sink, resumable.Config{}) //exhaustruct:ignore
+	require.NoError(test, err)
+	assert.Equal(test, checkpoint, again)
+	assert.Len(test, sink.emitted, 5)
+}
+
+func TestRun_Resume(test *testing.T) {
+	test.Parallel()
+
+	directory := test.TempDir()
+	checkpointPath := filepath.Join(directory, "export.checkpoint")
+	outputPath := filepath.Join(directory, "export.jsonl")
+	config := resumable.Config{CheckpointEvery: 2} //exhaustruct:ignore
+	content := export(1, 2, 3, 5, 8, 13, 21)
+	engine := &exporttest.Engine{ChunkSize: 64, Export: content, FailAfter: 5 * len(content) / 7} //exhaustruct:ignore
+
+	sink := resumable.NewFileSink(outputPath)
+	partial, err := resumable.Run(test.Context(), engine, checkpointPath, sink, config)
+	require.Error(test, err)
+	require.NoError(test, sink.Close())
+	assert.True(test, engine.IsClosed)
+	assert.False(test, partial.IsComplete)
+	assert.Equal(test, int64(4), partial.Entities)
+	assert.Equal(test, int64(5), partial.LastEntityID)
+
+	engine.FailAfter = 0
+	sink = resumable.NewFileSink(outputPath)
+	checkpoint, err := resumable.Run(test.Context(), engine, checkpointPath, sink, config)
+	require.NoError(test, err)
+	require.NoError(test, sink.Close())
+	assert.True(test, checkpoint.IsComplete)
+	assert.Equal(test, int64(7), checkpoint.Entities)
+
+	output, err := os.ReadFile(outputPath)
+	require.NoError(test, err)
+	assert.Equal(test, content, string(output))
+
+	file, err := os.Open(outputPath)
+	require.NoError(test, err)
+
+	defer func() { require.NoError(test, file.Close()) }()
+
+	require.NoError(test, resumable.Verify(file, checkpoint))
+}
+
+func TestRun_Mismatch(test *testing.T) {
+	test.Parallel()
+
+	directory := test.TempDir()
+	checkpointPath := filepath.Join(directory, "export.checkpoint")
+	config := resumable.Config{CheckpointEvery: 2}                                          //exhaustruct:ignore
+	engine := &exporttest.Engine{ChunkSize: 64, Export: export(1, 2, 3, 5), FailAfter: 300} //exhaustruct:ignore
+	sink := &mockSink{}                                                                     //exhaustruct:ignore
+
+	partial, err := resumable.Run(test.Context(), engine, checkpointPath, sink, config)
+	require.Error(test, err)
+	assert.Equal(test, int64(2), partial.Entities)
+
+	// Entity 2 was merged into entity 1 since the checkpoint.
+	engine.Export = export(1, 3, 5)
+	engine.FailAfter = 0
+	_, err = resumable.Run(test.Context(), engine, checkpointPath, sink, config)
+	require.ErrorIs(test, err, resumable.ErrCheckpointMismatch)
+	assert.Equal(test, []int64{1, 2, 3}, sink.emitted) // Entity 3 was emitted before the failure, but not committed.
+
+	// The flags differ from those of the checkpoint.
+	engine.Export = export(1, 2, 3, 5)
+	_, err = resumable.Run(test.Context(), engine, checkpointPath, sink, resumable.Config{Flags: 1}) //exhaustruct:ignore
+	require.ErrorIs(test, err, resumable.ErrCheckpointMismatch)
+
+	// The export shrank below the checkpoint.
+	engine.Export = export(1)
+	_, err = resumable.Run(test.Context(), engine, checkpointPath, sink, config)
+	require.ErrorIs(test, err, resumable.ErrCheckpointMismatch)
+}
+
+func TestVerify(test *testing.T) {
+	test.Parallel()
+
+	content := export(1, 2)
+	engine := &exporttest.Engine{ChunkSize: 1000, Export: content} //exhaustruct:ignore
+	checkpointPath := filepath.Join(test.TempDir(), "export.checkpoint")
+
+	sink := &mockSink{} //exhaustruct:ignore
+
+	checkpoint, err := resumable.Run(test.Context(), engine, checkpointPath, sink, resumable.Config{}) //exhaustruct:ignore
+	require.NoError(test, err)
+	require.NoError(test, resumable.Verify(strings.NewReader(content), checkpoint))
+
+	err = resumable.Verify(strings.NewReader(export(1)), checkpoint)
+	require.ErrorIs(test, err, resumable.ErrIntegrity)
+
+	err = resumable.Verify(strings.NewReader(strings.Replace(content, "1001", "1009", 1)), checkpoint)
+	require.ErrorIs(test, err, resumable.ErrIntegrity)
+	assert.Contains(test, err.Error(), "checksum")
+}
@@ -0,0 +1,26 @@
+/*
+Package screening screens records against a watchlist kept in its own data source.
+
+A [Screener] loads watchlist records, e.g. testdata/truthsets/watchlist.jsonl, with SzEngine.AddRecord.
+The watchlist data source must already be registered in the configuration.
+Records can then be screened two ways:
+
+  - [Screener.Screen] does not change the repository. SzEngine.GetRecordPreview validates the record
+    and SzEngine.SearchByAttributes finds the entities it matches.
+  - [Screener.ScreenAndAdd] adds the record with WithInfo, then reports the watchlist entities
+    it resolved into or is related to.
+
+Either way, each [Alert] is an entity with watchlist records at Config.MinMatchLevel or stronger,
+explained by SzEngine.WhySearch:
+
+	screener := screening.New(szEngine, screening.Config{MinMatchLevel: graph.MatchLevelPossiblySame})
+	_, err := screener.Load(ctx, watchlistFile)
+	...
+	report, err := screener.Screen(ctx, customerRecord)
+	...
+	for _, alert := range report.Alerts {
+		fmt.Println(alert.EntityID, alert.MatchLevelCode, alert.MatchKey)
+		fmt.Print(alert.Explanation)
+	}
+*/
+package screening
@@ -0,0 +1,417 @@
+package screening
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/explain"
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-go/search"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Alert struct is an entity with watchlist records that a screened record matches.
+*/
+type Alert struct {
+	EntityID         int64                `json:"entityId"`
+	EntityName       string               `json:"entityName"`
+	Explanation      *explain.Explanation `json:"explanation"` // From SzEngine.WhySearch. Nil if Config.SkipWhy.
+	MatchKey         string               `json:"matchKey"`
+	MatchLevelCode   string               `json:"matchLevelCode"`
+	RuleCode         string               `json:"ruleCode"`
+	WatchlistRecords int64                `json:"watchlistRecords"` // Records of the watchlist data source.
+}
+
+/*
+Type Config struct configures a [Screener].
+*/
+type Config struct {
+	DataSource    string // Watchlist data source. It must be registered. Default: DefaultDataSource.
+	MinMatchLevel string // Weakest match level that raises an alert. Default: graph.MatchLevelPossiblyRelated.
+	SearchProfile string // Default: search.ProfileDefault.
+	SkipWhy       bool   // Do not call SzEngine.WhySearch for alerts.
+}
+
+/*
+Type Report struct is the outcome of screening one record.
+*/
+type Report struct {
+	Alerts  []Alert `json:"alerts"`  // Strongest match level first.
+	Info    string  `json:"info"`    // The WithInfo response of SzEngine.AddRecord. Empty if not persisted.
+	Preview string  `json:"preview"` // The response of SzEngine.GetRecordPreview. Empty if persisted.
+}
+
+/*
+Type Screener struct loads a watchlist and screens records against it. It is safe for concurrent use
+if the engine is.
+*/
+type Screener struct {
+	config   Config
+	szEngine senzing.SzEngine
+}
+
+type entityResponse struct {
+	RelatedEntities []struct {
+		EntityID       int64         `json:"ENTITY_ID"`
+		EntityName     string        `json:"ENTITY_NAME"`
+		ErruleCode     string        `json:"ERRULE_CODE"`
+		MatchKey       string        `json:"MATCH_KEY"`
+		MatchLevelCode string        `json:"MATCH_LEVEL_CODE"`
+		RecordSummary  recordSummary `json:"RECORD_SUMMARY"`
+	} `json:"RELATED_ENTITIES"`
+	ResolvedEntity struct {
+		EntityID      int64         `json:"ENTITY_ID"`
+		EntityName    string        `json:"ENTITY_NAME"`
+		RecordSummary recordSummary `json:"RECORD_SUMMARY"`
+	} `json:"RESOLVED_ENTITY"`
+}
+
+type recordSummary []struct {
+	DataSource  string `json:"DATA_SOURCE"`
+	RecordCount int64  `json:"RECORD_COUNT"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultDataSource is the watchlist data source when Config.DataSource is not set.
+const DefaultDataSource = "WATCHLIST"
+
+// Flags of SzEngine.GetEntityByRecordID when screening a persisted record.
+const entityFlags = senzing.SzEntityIncludeEntityName |
+	senzing.SzEntityIncludeRecordSummary |
+	senzing.SzEntityIncludeAllRelations |
+	senzing.SzEntityIncludeRelatedEntityName |
+	senzing.SzEntityIncludeRelatedMatchingInfo |
+	senzing.SzEntityIncludeRelatedRecordSummary
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidRecord is returned when a record is not a JSON object or lacks DATA_SOURCE or RECORD_ID.
+var ErrInvalidRecord = errors.New("invalid screening record")
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function New creates a Screener.
+
+Input
+  - szEngine: The engine to call.
+  - config: The watchlist data source, alert threshold, and search profile.
+
+Output
+  - A Screener.
+*/
+func New(szEngine senzing.SzEngine, config Config) *Screener {
+	if config.DataSource == "" {
+		config.DataSource = DefaultDataSource
+	}
+
+	if config.MinMatchLevel == "" {
+		config.MinMatchLevel = graph.MatchLevelPossiblyRelated
+	}
+
+	if config.SearchProfile == "" {
+		config.SearchProfile = search.ProfileDefault
+	}
+
+	return &Screener{config: config, szEngine: szEngine}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Load adds watchlist records with SzEngine.AddRecord.
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: Records as JSON lines, e.g. testdata/truthsets/watchlist.jsonl. Each needs a RECORD_ID;
+    its DATA_SOURCE is replaced by Config.DataSource. Blank lines are skipped.
+
+Output
+  - The number of records added.
+*/
+func (screener *Screener) Load(ctx context.Context, reader io.Reader) (int64, error) {
+	var result int64
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record, err := parseRecord(scanner.Text())
+		if err != nil {
+			return result, fmt.Errorf("screening cannot load line %d: %w", line, err)
+		}
+
+		record["DATA_SOURCE"] = screener.config.DataSource
+
+		recordID, isString := record["RECORD_ID"].(string)
+		if !isString || recordID == "" {
+			return result, fmt.Errorf("screening cannot load line %d: %w", line, invalidRecord("no RECORD_ID"))
+		}
+
+		recordDefinition, err := json.Marshal(record)
+		if err != nil {
+			return result, fmt.Errorf("screening cannot marshal line %d: %w", line, err)
+		}
+
+		_, err = screener.szEngine.AddRecord(
+			ctx, screener.config.DataSource, recordID, string(recordDefinition), senzing.SzNoFlags)
+		if err != nil {
+			return result, fmt.Errorf("screening cannot load line %d: %w", line, err)
+		}
+
+		result++
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return result, fmt.Errorf("screening cannot read watchlist: %w", err)
+	}
+
+	return result, nil
+}
+
+/*
+Method Screen screens a record without adding it to the repository.
+SzEngine.GetRecordPreview validates the record, then SzEngine.SearchByAttributes finds
+entities with watchlist records.
+
+Input
+  - ctx: A context to control lifecycle.
+  - recordDefinition: The record as JSON. DATA_SOURCE and RECORD_ID are optional and ignored.
+
+Output
+  - The alerts, and the preview of the record.
+*/
+func (screener *Screener) Screen(ctx context.Context, recordDefinition string) (*Report, error) {
+	attributes, err := search.RecordAttributes(recordDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+
+	preview, err := screener.szEngine.GetRecordPreview(ctx, recordDefinition, senzing.SzRecordPreviewDefaultFlags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	responseJSON, err := screener.szEngine.SearchByAttributes(
+		ctx, attributes, screener.config.SearchProfile, senzing.SzSearchByAttributesDefaultFlags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	result, err := search.ParseResult(responseJSON)
+	if err != nil {
+		return nil, fmt.Errorf("screening cannot parse search: %w", err)
+	}
+
+	report := &Report{Alerts: []Alert{}, Info: "", Preview: preview}
+
+	for _, hit := range result.AtLeast(screener.config.MinMatchLevel) {
+		if hit.RecordSummary[screener.config.DataSource] == 0 {
+			continue
+		}
+
+		report.Alerts = append(report.Alerts, Alert{
+			EntityID:         hit.EntityID,
+			EntityName:       hit.EntityName,
+			Explanation:      nil,
+			MatchKey:         hit.MatchKey,
+			MatchLevelCode:   hit.MatchLevelCode,
+			RuleCode:         hit.RuleCode,
+			WatchlistRecords: hit.RecordSummary[screener.config.DataSource],
+		})
+	}
+
+	err = screener.explain(ctx, attributes, report.Alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+/*
+Method ScreenAndAdd adds a record with SzEngine.AddRecord and reports watchlist entities it resolved
+into or is related to.
+
+Input
+  - ctx: A context to control lifecycle.
+  - recordDefinition: The record as JSON, with DATA_SOURCE and RECORD_ID.
+
+Output
+  - The alerts, and the WithInfo response of SzEngine.AddRecord.
+*/
+func (screener *Screener) ScreenAndAdd(ctx context.Context, recordDefinition string) (*Report, error) {
+	record, err := parseRecord(recordDefinition)
+	if err != nil {
+		return nil, err
+	}
+
+	dataSourceCode, _ := record["DATA_SOURCE"].(string)
+	recordID, _ := record["RECORD_ID"].(string)
+
+	if dataSourceCode == "" || recordID == "" {
+		return nil, invalidRecord("no DATA_SOURCE or RECORD_ID")
+	}
+
+	attributes, err := search.RecordAttributes(recordDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+
+	info, err := screener.szEngine.AddRecord(ctx, dataSourceCode, recordID, recordDefinition, senzing.SzWithInfo)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	entityJSON, err := screener.szEngine.GetEntityByRecordID(ctx, dataSourceCode, recordID, entityFlags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	entity := entityResponse{} //exhaustruct:ignore
+
+	err = json.Unmarshal([]byte(entityJSON), &entity)
+	if err != nil {
+		return nil, fmt.Errorf("screening cannot unmarshal entity: %w", err)
+	}
+
+	report := &Report{Alerts: []Alert{}, Info: info, Preview: ""}
+	resolved := entity.ResolvedEntity
+
+	if count := resolved.RecordSummary.count(screener.config.DataSource); count > 0 {
+		report.Alerts = append(report.Alerts, Alert{
+			EntityID:         resolved.EntityID,
+			EntityName:       resolved.EntityName,
+			Explanation:      nil,
+			MatchKey:         "",
+			MatchLevelCode:   graph.MatchLevelResolved,
+			RuleCode:         "",
+			WatchlistRecords: count,
+		})
+	}
+
+	for _, related := range entity.RelatedEntities {
+		count := related.RecordSummary.count(screener.config.DataSource)
+		isWeaker := search.MatchLevelRank(related.MatchLevelCode) > search.MatchLevelRank(screener.config.MinMatchLevel)
+
+		if count == 0 || isWeaker {
+			continue
+		}
+
+		report.Alerts = append(report.Alerts, Alert{
+			EntityID:         related.EntityID,
+			EntityName:       related.EntityName,
+			Explanation:      nil,
+			MatchKey:         related.MatchKey,
+			MatchLevelCode:   related.MatchLevelCode,
+			RuleCode:         related.ErruleCode,
+			WatchlistRecords: count,
+		})
+	}
+
+	slices.SortStableFunc(report.Alerts, func(a, b Alert) int {
+		return cmp.Compare(search.MatchLevelRank(a.MatchLevelCode), search.MatchLevelRank(b.MatchLevelCode))
+	})
+
+	err = screener.explain(ctx, attributes, report.Alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (summary recordSummary) count(dataSourceCode string) int64 {
+	for _, item := range summary {
+		if item.DataSource == dataSourceCode {
+			return item.RecordCount
+		}
+	}
+
+	return 0
+}
+
+// Sets the explanation of each alert, and its match key and rule code if they are missing.
+func (screener *Screener) explain(ctx context.Context, attributes string, alerts []Alert) error {
+	if screener.config.SkipWhy {
+		return nil
+	}
+
+	for index := range alerts {
+		alert := &alerts[index]
+
+		responseJSON, err := screener.szEngine.WhySearch(
+			ctx, attributes, alert.EntityID, screener.config.SearchProfile, senzing.SzWhySearchDefaultFlags)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		whySearchResponse, err := response.SzEngineWhySearch(ctx, responseJSON)
+		if err != nil {
+			return fmt.Errorf("screening cannot parse why response of entity %d: %w", alert.EntityID, err)
+		}
+
+		alert.Explanation, err = explain.Why(whySearchResponse)
+		if err != nil {
+			return fmt.Errorf("screening cannot explain entity %d: %w", alert.EntityID, err)
+		}
+
+		if len(alert.Explanation.Comparisons) > 0 {
+			comparison := alert.Explanation.Comparisons[0]
+			alert.MatchKey = cmp.Or(alert.MatchKey, comparison.MatchKey)
+			alert.RuleCode = cmp.Or(alert.RuleCode, comparison.RuleCode)
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func invalidRecord(reason string) error {
+	return szerror.Wrap(fmt.Errorf("%w: %s", ErrInvalidRecord, reason), szerror.SzBadInputError, szerror.SzError)
+}
+
+func parseRecord(recordDefinition string) (map[string]any, error) {
+	var result map[string]any
+
+	err := json.Unmarshal([]byte(recordDefinition), &result)
+	if err != nil || result == nil {
+		return nil, invalidRecord("not a JSON object")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,382 @@
+package screening_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/screening"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+// Resolves records with a toy matcher: the same driver's license resolves, the same first and last name
+// is possibly the same, and the same last name is name only. Each watchlist record is its own entity.
+type mockEngine struct {
+	senzing.SzEngine
+
+	keys    []string // DATA_SOURCE:RECORD_ID, in order of addition. Entity IDs are positions plus one.
+	records map[string]map[string]any
+}
+
+func (engine *mockEngine) AddRecord(
+	_ context.Context,
+	dataSourceCode string,
+	recordID string,
+	recordDefinition string,
+	flags int64,
+) (string, error) {
+	record := map[string]any{}
+
+	err := json.Unmarshal([]byte(recordDefinition), &record)
+	if err != nil {
+		return "", szerror.Wrap(err, szerror.SzBadInputError, szerror.SzError)
+	}
+
+	key := dataSourceCode + ":" + recordID
+	if _, isPresent := engine.records[key]; !isPresent {
+		engine.keys = append(engine.keys, key)
+	}
+
+	engine.records[key] = record
+
+	if flags&senzing.SzWithInfo == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf(`{"DATA_SOURCE": %q, "RECORD_ID": %q, "AFFECTED_ENTITIES": [{"ENTITY_ID": %d}]}`,
+		dataSourceCode, recordID, len(engine.keys)), nil
+}
+
+func (engine *mockEngine) GetEntityByRecordID(
+	_ context.Context,
+	dataSourceCode string,
+	recordID string,
+	_ int64,
+) (string, error) {
+	record := engine.records[dataSourceCode+":"+recordID]
+	resolved := map[string]any{
+		"ENTITY_ID":      engine.entityID(dataSourceCode + ":" + recordID),
+		"RECORD_SUMMARY": []map[string]any{{"DATA_SOURCE": dataSourceCode, "RECORD_COUNT": 1}},
+	}
+	related := []map[string]any{}
+
+	for _, key := range engine.watchlistKeys() {
+		matchLevelCode, matchKey, ruleCode := match(record, engine.records[key])
+
+		switch matchLevelCode {
+		case "":
+		case graph.MatchLevelResolved:
+			resolved["ENTITY_ID"] = engine.entityID(key)
+			resolved["RECORD_SUMMARY"] = []map[string]any{
+				{"DATA_SOURCE": dataSourceCode, "RECORD_COUNT": 1},
+				{"DATA_SOURCE": screening.DefaultDataSource, "RECORD_COUNT": 1},
+			}
+		default:
+			related = append(related, map[string]any{
+				"ENTITY_ID":        engine.entityID(key),
+				"ERRULE_CODE":      ruleCode,
+				"MATCH_KEY":        matchKey,
+				"MATCH_LEVEL_CODE": matchLevelCode,
+				"RECORD_SUMMARY":   []map[string]any{{"DATA_SOURCE": screening.DefaultDataSource, "RECORD_COUNT": 1}},
+			})
+		}
+	}
+
+	result, err := json.Marshal(map[string]any{"RESOLVED_ENTITY": resolved, "RELATED_ENTITIES": related})
+
+	return string(result), err
+}
+
+func (engine *mockEngine) GetRecordPreview(_ context.Context, recordDefinition string, _ int64) (string, error) {
+	if !json.Valid([]byte(recordDefinition)) {
+		return "", szerror.New(7, "invalid record")
+	}
+
+	return `{"FEATURES": {}}`, nil
+}
+
+func (engine *mockEngine) SearchByAttributes(
+	_ context.Context,
+	attributes string,
+	_ string,
+	_ int64,
+) (string, error) {
+	query := map[string]any{}
+
+	err := json.Unmarshal([]byte(attributes), &query)
+	if err != nil {
+		return "", err
+	}
+
+	hits := []map[string]any{}
+
+	for _, key := range engine.watchlistKeys() {
+		matchLevelCode, matchKey, ruleCode := match(query, engine.records[key])
+		if matchLevelCode == "" {
+			continue
+		}
+
+		hits = append(hits, map[string]any{
+			"ENTITY": map[string]any{"RESOLVED_ENTITY": map[string]any{
+				"ENTITY_ID":      engine.entityID(key),
+				"RECORD_SUMMARY": []map[string]any{{"DATA_SOURCE": screening.DefaultDataSource, "RECORD_COUNT": 1}},
+			}},
+			"MATCH_INFO": map[string]any{"ERRULE_CODE": ruleCode, "MATCH_KEY": matchKey, "MATCH_LEVEL_CODE": matchLevelCode},
+		})
+	}
+
+	result, err := json.Marshal(map[string]any{"RESOLVED_ENTITIES": hits})
+
+	return string(result), err
+}
+
+func (engine *mockEngine) WhySearch(
+	_ context.Context,
+	attributes string,
+	entityID int64,
+	_ string,
+	_ int64,
+) (string, error) {
+	query := map[string]any{}
+
+	err := json.Unmarshal([]byte(attributes), &query)
+	if err != nil {
+		return "", err
+	}
+
+	matchLevelCode, matchKey, ruleCode := match(query, engine.records[engine.keys[entityID-1]])
+
+	return fmt.Sprintf(`{"WHY_RESULTS": [{"ENTITY_ID": %d, "MATCH_INFO": {
+		"MATCH_LEVEL_CODE": %q, "WHY_ERRULE_CODE": %q, "WHY_KEY": %q}}]}`,
+		entityID, matchLevelCode, ruleCode, matchKey), nil
+}
+
+func (engine *mockEngine) entityID(key string) int64 {
+	for index, existing := range engine.keys {
+		if existing == key {
+			return int64(index + 1)
+		}
+	}
+
+	return 0
+}
+
+func (engine *mockEngine) watchlistKeys() []string {
+	result := []string{}
+
+	for _, key := range engine.keys {
+		if strings.HasPrefix(key, screening.DefaultDataSource+":") {
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func match(record map[string]any, candidate map[string]any) (string, string, string) {
+	value := func(values map[string]any, name string) string {
+		text, _ := values[name].(string)
+
+		return strings.ToUpper(strings.TrimSpace(text))
+	}
+
+	switch {
+	case value(record, "DRIVERS_LICENSE_NUMBER") != "" &&
+		value(record, "DRIVERS_LICENSE_NUMBER") == value(candidate, "DRIVERS_LICENSE_NUMBER"):
+		return graph.MatchLevelResolved, "+DRLIC", "SF1"
+	case value(record, "PRIMARY_NAME_LAST") == "" ||
+		value(record, "PRIMARY_NAME_LAST") != value(candidate, "PRIMARY_NAME_LAST"):
+		return "", "", ""
+	case value(record, "PRIMARY_NAME_FIRST") == value(candidate, "PRIMARY_NAME_FIRST"):
+		return graph.MatchLevelPossiblySame, "+NAME", "CNAME"
+	default:
+		return graph.MatchLevelNameOnly, "+SURNAME", "SNAME"
+	}
+}
+
+func newScreener(test *testing.T, config screening.Config) (*screening.Screener, *mockEngine) {
+	test.Helper()
+
+	engine := &mockEngine{keys: []string{}, records: map[string]map[string]any{}} //exhaustruct:ignore
+	screener := screening.New(engine, config)
+
+	file, err := os.Open(filepath.Join("..", "testdata", "truthsets", "watchlist.jsonl"))
+	require.NoError(test, err)
+
+	defer func() {
+		require.NoError(test, file.Close())
+	}()
+
+	count, err := screener.Load(test.Context(), file)
+	require.NoError(test, err)
+	assert.Equal(test, int64(17), count)
+
+	return screener, engine
+}
+
+func readRecords(test *testing.T, fileName string) map[string]string {
+	test.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "truthsets", fileName))
+	require.NoError(test, err)
+
+	result := map[string]string{}
+
+	for line := range strings.Lines(string(data)) {
+		record := struct {
+			RecordID string `json:"RECORD_ID"`
+		}{}
+		require.NoError(test, json.Unmarshal([]byte(line), &record))
+
+		result[record.RecordID] = strings.TrimSpace(line)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestScreener_Load(test *testing.T) {
+	test.Parallel()
+
+	_, engine := newScreener(test, screening.Config{}) //exhaustruct:ignore
+	assert.Len(test, engine.watchlistKeys(), 17)
+	assert.Equal(test, "WATCHLIST", engine.records["WATCHLIST:1006"]["DATA_SOURCE"])
+
+	screener := screening.New(engine, screening.Config{DataSource: "SANCTIONS"}) //exhaustruct:ignore
+	count, err := screener.Load(test.Context(), strings.NewReader(`{"DATA_SOURCE": "OTHER", "RECORD_ID": "1"}
+
+{"NAME_FULL": "No Record ID"}`))
+	require.ErrorIs(test, err, screening.ErrInvalidRecord)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Contains(test, err.Error(), "line 3")
+	assert.Equal(test, int64(1), count)
+	assert.Equal(test, "SANCTIONS", engine.records["SANCTIONS:1"]["DATA_SOURCE"])
+}
+
+func TestScreener_Screen(test *testing.T) {
+	test.Parallel()
+
+	screener, engine := newScreener(test, screening.Config{}) //exhaustruct:ignore
+	customers := readRecords(test, "customers.jsonl")
+
+	report, err := screener.Screen(test.Context(), customers["1001"])
+	require.NoError(test, err)
+	assert.NotEmpty(test, report.Preview)
+	assert.Empty(test, report.Info)
+	assert.Len(test, engine.keys, 17)
+	require.Len(test, report.Alerts, 1)
+
+	alert := report.Alerts[0]
+	assert.Equal(test, engine.entityID("WATCHLIST:1008"), alert.EntityID)
+	assert.Equal(test, graph.MatchLevelPossiblySame, alert.MatchLevelCode)
+	assert.Equal(test, "+NAME", alert.MatchKey)
+	assert.Equal(test, int64(1), alert.WatchlistRecords)
+	require.NotNil(test, alert.Explanation)
+	require.Len(test, alert.Explanation.Comparisons, 1)
+	assert.Equal(test, "CNAME", alert.Explanation.Comparisons[0].RuleCode)
+
+	report, err = screener.Screen(test.Context(), customers["1005"])
+	require.NoError(test, err)
+	require.Len(test, report.Alerts, 1)
+	assert.Equal(test, graph.MatchLevelResolved, report.Alerts[0].MatchLevelCode)
+}
+
+func TestScreener_Screen_MinMatchLevel(test *testing.T) {
+	test.Parallel()
+
+	config := screening.Config{MinMatchLevel: graph.MatchLevelNameOnly, SkipWhy: true} //exhaustruct:ignore
+	screener, _ := newScreener(test, config)
+	customers := readRecords(test, "customers.jsonl")
+
+	report, err := screener.Screen(test.Context(), customers["1001"])
+	require.NoError(test, err)
+	require.Len(test, report.Alerts, 4)
+	assert.Equal(test, graph.MatchLevelPossiblySame, report.Alerts[0].MatchLevelCode)
+
+	for _, alert := range report.Alerts[1:] {
+		assert.Equal(test, graph.MatchLevelNameOnly, alert.MatchLevelCode)
+	}
+
+	assert.Nil(test, report.Alerts[0].Explanation)
+}
+
+func TestScreener_Screen_Truthset(test *testing.T) {
+	test.Parallel()
+
+	screener, engine := newScreener(test, screening.Config{}) //exhaustruct:ignore
+	alerted := 0
+
+	for _, record := range readRecords(test, "customers.jsonl") {
+		report, err := screener.Screen(test.Context(), record)
+		require.NoError(test, err)
+
+		for _, alert := range report.Alerts {
+			require.NotNil(test, alert.Explanation)
+			assert.Equal(test, alert.MatchKey, alert.Explanation.Comparisons[0].MatchKey)
+		}
+
+		if len(report.Alerts) > 0 {
+			alerted++
+		}
+	}
+
+	assert.Positive(test, alerted)
+	assert.Len(test, engine.keys, 17)
+}
+
+func TestScreener_ScreenAndAdd(test *testing.T) {
+	test.Parallel()
+
+	screener, engine := newScreener(test, screening.Config{}) //exhaustruct:ignore
+	customers := readRecords(test, "customers.jsonl")
+
+	report, err := screener.ScreenAndAdd(test.Context(), customers["1005"])
+	require.NoError(test, err)
+	assert.Contains(test, report.Info, "AFFECTED_ENTITIES")
+	assert.Empty(test, report.Preview)
+	assert.Len(test, engine.keys, 18)
+	require.Len(test, report.Alerts, 1)
+	assert.Equal(test, engine.entityID("WATCHLIST:1006"), report.Alerts[0].EntityID)
+	assert.Equal(test, graph.MatchLevelResolved, report.Alerts[0].MatchLevelCode)
+	assert.Equal(test, "+DRLIC", report.Alerts[0].MatchKey)
+	assert.Equal(test, "SF1", report.Alerts[0].RuleCode)
+
+	report, err = screener.ScreenAndAdd(test.Context(), customers["1001"])
+	require.NoError(test, err)
+	require.Len(test, report.Alerts, 1)
+	assert.Equal(test, engine.entityID("WATCHLIST:1008"), report.Alerts[0].EntityID)
+	assert.Equal(test, graph.MatchLevelPossiblySame, report.Alerts[0].MatchLevelCode)
+	assert.Equal(test, "CNAME", report.Alerts[0].RuleCode)
+}
+
+func TestScreener_InvalidRecord(test *testing.T) {
+	test.Parallel()
+
+	screener, engine := newScreener(test, screening.Config{}) //exhaustruct:ignore
+
+	_, err := screener.Screen(test.Context(), "[")
+	require.ErrorIs(test, err, screening.ErrInvalidRecord)
+
+	_, err = screener.ScreenAndAdd(test.Context(), `{"DATA_SOURCE": "CUSTOMERS", "NAME_FULL": "Robert Smith"}`)
+	require.ErrorIs(test, err, screening.ErrInvalidRecord)
+	assert.Len(test, engine.keys, 17)
+}
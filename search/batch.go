@@ -0,0 +1,242 @@
+package search
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type BatchOptions struct configures [BatchSearch].
+*/
+type BatchOptions struct {
+	Concurrency int  // Searches running at once. Default: DefaultConcurrency.
+	Dedupe      bool // Remove hits for entities already returned by an earlier result.
+	InOrder     bool // Return results in the order of the queries instead of as they complete.
+}
+
+/*
+Type BatchResult struct is the outcome of one query of a batch.
+*/
+type BatchResult struct {
+	Err    error   // The error of this query. Other queries are not affected.
+	Index  int     // Position of the query in the batch, starting at 0.
+	Query  *Query  // The query.
+	Result *Result // The ranked hits. Nil if Err is not nil.
+}
+
+// Applies ordering and de-duplication to results before yielding them.
+type batchEmitter struct {
+	next    int
+	options BatchOptions
+	pending map[int]BatchResult // Results waiting for earlier ones when InOrder is set.
+	seen    map[int64]bool      // Entities already returned when Dedupe is set.
+	window  chan struct{}       // A slot per query dispatched and not yet yielded when InOrder is set.
+	yield   func(BatchResult) bool
+}
+
+type batchJob struct {
+	index int
+	query *Query
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultConcurrency is the number of searches running at once when BatchOptions.Concurrency is not set.
+const DefaultConcurrency = 4
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+func newBatchEmitter(options BatchOptions, concurrency int, yield func(BatchResult) bool) *batchEmitter {
+	var window chan struct{}
+
+	// Queries running plus results waiting for an earlier one, so that a slow query
+	// holds back at most as many waiting results as there are workers.
+	if options.InOrder {
+		window = make(chan struct{}, 2*concurrency)
+	}
+
+	return &batchEmitter{
+		next:    0,
+		options: options,
+		pending: map[int]BatchResult{},
+		seen:    map[int64]bool{},
+		window:  window,
+		yield:   yield,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function BatchSearch runs many queries with bounded concurrency and returns their results as they are ready.
+
+A query that fails does not stop the batch; its error is in BatchResult.Err.
+With InOrder, no more queries start while as many results as there are workers wait for an earlier one.
+Stopping the iteration, or canceling ctx, stops the searches that have not started.
+All goroutines have returned by the time the iteration ends.
+If ctx is canceled, results of queries that had not completed are not returned; check ctx.Err().
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call. It must be safe for concurrent use, as the Senzing implementations are.
+  - queries: The queries. They are read as searches start, so the sequence may be long or generated.
+  - options: Concurrency, ordering, and de-duplication. With Dedupe and without InOrder,
+    which result keeps a hit depends on which search completes first.
+
+Output
+  - The results, one per query.
+*/
+func BatchSearch(
+	ctx context.Context,
+	szEngine senzing.SzEngine,
+	queries iter.Seq[*Query],
+	options BatchOptions,
+) iter.Seq[BatchResult] {
+	return func(yield func(BatchResult) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		jobs := make(chan batchJob)
+		results := make(chan BatchResult)
+
+		var producer, workers sync.WaitGroup
+
+		defer func() {
+			cancel()
+
+			for range results {
+				// Discard the results of searches that were running.
+			}
+
+			producer.Wait()
+		}()
+
+		concurrency := options.Concurrency
+		if concurrency < 1 {
+			concurrency = DefaultConcurrency
+		}
+
+		emitter := newBatchEmitter(options, concurrency, yield)
+
+		producer.Go(func() {
+			defer close(jobs)
+
+			index := 0
+
+			for query := range queries {
+				if !emitter.reserve(ctx) {
+					return
+				}
+
+				select {
+				case jobs <- batchJob{index: index, query: query}:
+				case <-ctx.Done():
+					return
+				}
+
+				index++
+			}
+		})
+
+		for range concurrency {
+			workers.Go(func() {
+				for job := range jobs {
+					result, err := job.query.Search(ctx, szEngine)
+
+					select {
+					case results <- BatchResult{Err: err, Index: job.index, Query: job.query, Result: result}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			})
+		}
+
+		go func() {
+			workers.Wait()
+			close(results)
+		}()
+
+		for result := range results {
+			if !emitter.add(result) {
+				return
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Returns false when the consumer stops the iteration.
+func (emitter *batchEmitter) add(result BatchResult) bool {
+	if !emitter.options.InOrder {
+		return emitter.emit(result)
+	}
+
+	emitter.pending[result.Index] = result
+
+	for {
+		next, isPending := emitter.pending[emitter.next]
+		if !isPending {
+			return true
+		}
+
+		delete(emitter.pending, emitter.next)
+		emitter.next++
+
+		if !emitter.emit(next) {
+			return false
+		}
+
+		<-emitter.window
+	}
+}
+
+func (emitter *batchEmitter) emit(result BatchResult) bool {
+	if emitter.options.Dedupe && result.Result != nil {
+		hits := make([]Hit, 0, len(result.Result.Hits))
+
+		for _, hit := range result.Result.Hits {
+			if !emitter.seen[hit.EntityID] {
+				emitter.seen[hit.EntityID] = true
+				hits = append(hits, hit)
+			}
+		}
+
+		for index := range hits {
+			hits[index].Rank = index + 1
+		}
+
+		result.Result = &Result{Hits: hits}
+	}
+
+	return emitter.yield(result)
+}
+
+// Waits for a slot in the reorder window before a query is dispatched. Returns false if ctx is canceled.
+func (emitter *batchEmitter) reserve(ctx context.Context) bool {
+	if emitter.window == nil {
+		return true
+	}
+
+	select {
+	case emitter.window <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Package search builds SzEngine.SearchByAttributes queries and ranks their results.
+
+SzEngine.SearchByAttributes takes the attributes as JSON and a search profile name.
+A [Query] builds both from typed values, and [ParseResult] turns the response into ranked [Hit] values
+with the match level, feature scores, and representative features of each entity:
+
+	result, err := search.NewQuery().
+		PersonName("Robert", "", "Smith").
+		Address("123 Main Street Las Vegas NV 89132").
+		Identifier(search.IdentifierPassport, "PP1234567", "US").
+		Profile(search.ProfileSearch).
+		Search(ctx, szEngine)
+	...
+	for _, hit := range result.Top(5) {
+		fmt.Println(hit.Rank, hit.EntityID, hit.MatchLevelCode, hit.MatchKey)
+	}
+
+The engine returns all matches in one response; [Result.Top] and [Result.Page] trim them on the client.
+[BatchSearch] runs many queries with bounded concurrency, e.g. to screen a list of names.
+A failed query does not stop the others, and hits can be de-duplicated across queries:
+
+	options := search.BatchOptions{Concurrency: 8, Dedupe: true, InOrder: true}
+	for result := range search.BatchSearch(ctx, szEngine, queries, options) {
+		if result.Err != nil {
+			log.Printf("query %d: %v", result.Index, result.Err)
+			continue
+		}
+		...
+	}
+
+[ParseResult] parses a response with response.SzEngineSearchByAttributes, and [NewResult] ranks the hits
+of a typedef.SzEngineSearchByAttributesResponse that was already parsed.
+*/
+package search
@@ -0,0 +1,325 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Address struct is an address split into parts. Empty parts are omitted.
+*/
+type Address struct {
+	City       string
+	Country    string
+	Line1      string
+	Line2      string
+	PostalCode string
+	State      string
+}
+
+/*
+Type IdentifierType string is a kind of identifier, named by its attribute prefix.
+*/
+type IdentifierType string
+
+/*
+Type Query struct builds the attributes and search profile of SzEngine.SearchByAttributes.
+Create one with [NewQuery]. Methods return the query so calls can be chained; empty values are ignored.
+*/
+type Query struct {
+	attributes map[string]string
+	flags      int64
+	lists      map[string][]map[string]string
+	profile    string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Identifier types. Each names a NUMBER attribute and, except for SSNs, a COUNTRY or STATE attribute.
+const (
+	IdentifierDriversLicense IdentifierType = "DRIVERS_LICENSE" // Issuer is DRIVERS_LICENSE_STATE.
+	IdentifierNationalID     IdentifierType = "NATIONAL_ID"
+	IdentifierPassport       IdentifierType = "PASSPORT"
+	IdentifierSSN            IdentifierType = "SSN" // Issuer is ignored.
+	IdentifierTaxID          IdentifierType = "TAX_ID"
+)
+
+// Search profiles. Any profile defined in the configuration can also be used.
+const (
+	ProfileDefault = senzing.SzNoSearchProfile // The engine's default profile.
+	ProfileIngest  = "INGEST"                  // Score candidates as if the attributes were being added as a record.
+	ProfileSearch  = "SEARCH"
+)
+
+// The JSON list each kind of attribute group is added to.
+const (
+	listAddresses   = "ADDRESSES"
+	listEmails      = "EMAILS"
+	listIdentifiers = "IDENTIFIERS"
+	listNames       = "NAMES"
+	listPhones      = "PHONES"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrEmptyQuery is returned when a query has no attributes.
+var ErrEmptyQuery = errors.New("search query has no attributes")
+
+// ErrInvalidRecord is returned by RecordAttributes when a record is not a JSON object.
+var ErrInvalidRecord = errors.New("search record is not a JSON object")
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewQuery creates an empty query using ProfileDefault and senzing.SzSearchByAttributesDefaultFlags.
+*/
+func NewQuery() *Query {
+	return &Query{
+		attributes: map[string]string{},
+		flags:      senzing.SzSearchByAttributesDefaultFlags,
+		lists:      map[string][]map[string]string{},
+		profile:    ProfileDefault,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function RecordAttributes returns a record definition without DATA_SOURCE and RECORD_ID,
+to search for the entities the record would resolve into or relate to.
+
+Input
+  - recordDefinition: The record as JSON.
+
+Output
+  - The attributes for SzEngine.SearchByAttributes or SzEngine.WhySearch.
+    An error matching ErrInvalidRecord and szerror.ErrSzBadInput if the record is not a JSON object.
+*/
+func RecordAttributes(recordDefinition string) (string, error) {
+	var record map[string]any
+
+	err := json.Unmarshal([]byte(recordDefinition), &record)
+	if err != nil || record == nil {
+		return "", szerror.Wrap(ErrInvalidRecord, szerror.SzBadInputError, szerror.SzError)
+	}
+
+	delete(record, "DATA_SOURCE")
+	delete(record, "RECORD_ID")
+
+	result, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("search cannot marshal attributes: %w", err)
+	}
+
+	return string(result), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Address adds an address as one string, e.g. "123 Main Street Las Vegas NV 89132".
+*/
+func (query *Query) Address(fullAddress string) *Query {
+	return query.add(listAddresses, map[string]string{"ADDR_FULL": fullAddress})
+}
+
+/*
+Method AddressParts adds an address split into parts.
+*/
+func (query *Query) AddressParts(address Address) *Query {
+	return query.add(listAddresses, map[string]string{
+		"ADDR_CITY":        address.City,
+		"ADDR_COUNTRY":     address.Country,
+		"ADDR_LINE1":       address.Line1,
+		"ADDR_LINE2":       address.Line2,
+		"ADDR_POSTAL_CODE": address.PostalCode,
+		"ADDR_STATE":       address.State,
+	})
+}
+
+/*
+Method Attribute sets any top-level attribute, e.g. "RECORD_TYPE", replacing an earlier value.
+*/
+func (query *Query) Attribute(name string, value string) *Query {
+	if value != "" {
+		query.attributes[name] = value
+	}
+
+	return query
+}
+
+/*
+Method DateOfBirth sets the date of birth, e.g. "1978-12-11".
+*/
+func (query *Query) DateOfBirth(date string) *Query {
+	return query.Attribute("DATE_OF_BIRTH", date)
+}
+
+/*
+Method Email adds an email address.
+*/
+func (query *Query) Email(address string) *Query {
+	return query.add(listEmails, map[string]string{"EMAIL_ADDRESS": address})
+}
+
+/*
+Method Flags replaces the flags passed to SzEngine.SearchByAttributes.
+*/
+func (query *Query) Flags(flags int64) *Query {
+	query.flags = flags
+
+	return query
+}
+
+/*
+Method Identifier adds an identifier.
+
+Input
+  - identifierType: The kind of identifier, e.g. IdentifierPassport.
+  - number: The identifier.
+  - issuer: The issuing country, or state for IdentifierDriversLicense. May be empty.
+*/
+func (query *Query) Identifier(identifierType IdentifierType, number string, issuer string) *Query {
+	if number == "" {
+		return query
+	}
+
+	attributes := map[string]string{string(identifierType) + "_NUMBER": number}
+
+	switch identifierType {
+	case IdentifierSSN:
+	case IdentifierDriversLicense:
+		attributes[string(identifierType)+"_STATE"] = issuer
+	default:
+		attributes[string(identifierType)+"_COUNTRY"] = issuer
+	}
+
+	return query.add(listIdentifiers, attributes)
+}
+
+/*
+Method JSON returns the attributes parameter of SzEngine.SearchByAttributes.
+Groups of attributes are in lists such as "NAMES" and "ADDRESSES".
+*/
+func (query *Query) JSON() (string, error) {
+	if len(query.attributes) == 0 && len(query.lists) == 0 {
+		return "", szerror.Wrap(ErrEmptyQuery, szerror.SzBadInputError, szerror.SzError)
+	}
+
+	value := make(map[string]any, len(query.attributes)+len(query.lists))
+	for name, attribute := range query.attributes {
+		value[name] = attribute
+	}
+
+	for name, list := range query.lists {
+		value[name] = list
+	}
+
+	result, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("search cannot marshal query: %w", err)
+	}
+
+	return string(result), nil
+}
+
+/*
+Method Name adds a name as one string, e.g. "Robert Smith".
+*/
+func (query *Query) Name(fullName string) *Query {
+	return query.add(listNames, map[string]string{"NAME_FULL": fullName})
+}
+
+/*
+Method OrganizationName adds the name of an organization.
+*/
+func (query *Query) OrganizationName(name string) *Query {
+	return query.add(listNames, map[string]string{"NAME_ORG": name})
+}
+
+/*
+Method PersonName adds the name of a person split into parts. Empty parts are omitted.
+*/
+func (query *Query) PersonName(first string, middle string, last string) *Query {
+	return query.add(listNames, map[string]string{"NAME_FIRST": first, "NAME_MIDDLE": middle, "NAME_LAST": last})
+}
+
+/*
+Method Phone adds a phone number.
+*/
+func (query *Query) Phone(number string) *Query {
+	return query.add(listPhones, map[string]string{"PHONE_NUMBER": number})
+}
+
+/*
+Method Profile sets the search profile, e.g. ProfileSearch.
+*/
+func (query *Query) Profile(searchProfile string) *Query {
+	query.profile = searchProfile
+
+	return query
+}
+
+/*
+Method Search calls SzEngine.SearchByAttributes and parses the response.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+
+Output
+  - The ranked hits.
+*/
+func (query *Query) Search(ctx context.Context, szEngine senzing.SzEngine) (*Result, error) {
+	attributes, err := query.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := szEngine.SearchByAttributes(ctx, attributes, query.profile, query.flags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return ParseResult(response)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Adds a group of attributes to a list, without its empty values. Groups with no values are ignored.
+func (query *Query) add(list string, attributes map[string]string) *Query {
+	maps.DeleteFunc(attributes, func(_ string, value string) bool {
+		return value == ""
+	})
+
+	if len(attributes) > 0 && !slices.ContainsFunc(query.lists[list], func(existing map[string]string) bool {
+		return maps.Equal(existing, attributes)
+	}) {
+		query.lists[list] = append(query.lists[list], attributes)
+	}
+
+	return query
+}
@@ -0,0 +1,306 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/internal/typedefview"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-json-type-definition/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type FeatureScore struct is the comparison of a searched value with a value of the entity.
+*/
+type FeatureScore struct {
+	Bucket    string `json:"bucket"`    // SCORE_BUCKET, e.g. "CLOSE".
+	Candidate string `json:"candidate"` // The entity's value.
+	Feature   string `json:"feature"`   // Feature type code, e.g. "NAME".
+	Inbound   string `json:"inbound"`   // The searched value.
+	Score     int64  `json:"score"`
+}
+
+/*
+Type Hit struct is an entity found by a search.
+*/
+type Hit struct {
+	BestScores     map[string]int64    `json:"bestScores"` // Feature type code to the highest score for it.
+	EntityID       int64               `json:"entityId"`
+	EntityName     string              `json:"entityName"`
+	Features       map[string][]string `json:"features"` // Feature type code to representative values.
+	MatchKey       string              `json:"matchKey"`
+	MatchLevelCode string              `json:"matchLevelCode"`
+	Rank           int                 `json:"rank"` // 1 for the best hit.
+	RecordSummary  map[string]int64    `json:"recordSummary"`
+	RuleCode       string              `json:"ruleCode"`
+	Scores         []FeatureScore      `json:"scores"`
+}
+
+/*
+Type Result struct is the ranked hits of a search.
+*/
+type Result struct {
+	Hits []Hit `json:"hits"`
+}
+
+// The parts of an element of RESOLVED_ENTITIES in typedef.SzEngineSearchByAttributesResponse used by a Hit.
+type resolvedEntity struct {
+	Entity struct {
+		ResolvedEntity struct {
+			EntityID   int64  `json:"ENTITY_ID"`
+			EntityName string `json:"ENTITY_NAME"`
+			Features   map[string][]struct {
+				FeatDesc string `json:"FEAT_DESC"`
+			} `json:"FEATURES"`
+			RecordSummary []struct {
+				DataSource  string `json:"DATA_SOURCE"`
+				RecordCount int64  `json:"RECORD_COUNT"`
+			} `json:"RECORD_SUMMARY"`
+		} `json:"RESOLVED_ENTITY"`
+	} `json:"ENTITY"`
+	MatchInfo struct {
+		ErruleCode    string `json:"ERRULE_CODE"`
+		FeatureScores map[string][]struct {
+			CandidateFeatDesc string `json:"CANDIDATE_FEAT_DESC"`
+			InboundFeatDesc   string `json:"INBOUND_FEAT_DESC"`
+			Score             int64  `json:"SCORE"`
+			ScoreBucket       string `json:"SCORE_BUCKET"`
+		} `json:"FEATURE_SCORES"`
+		MatchKey       string `json:"MATCH_KEY"`
+		MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	} `json:"MATCH_INFO"`
+}
+
+type responseView struct {
+	ResolvedEntities []resolvedEntity `json:"RESOLVED_ENTITIES"`
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Match level codes from strongest to weakest. Other codes rank after these.
+var matchLevelOrder = []string{
+	"RESOLVED",
+	"POSSIBLY_SAME",
+	"POSSIBLY_RELATED",
+	"NAME_ONLY",
+	"DISCLOSED",
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function MatchLevelRank ranks match level codes from strongest to weakest: RESOLVED is 0,
+then POSSIBLY_SAME, POSSIBLY_RELATED, NAME_ONLY, and DISCLOSED. Other codes rank after these.
+*/
+func MatchLevelRank(matchLevelCode string) int {
+	index := slices.Index(matchLevelOrder, matchLevelCode)
+	if index < 0 {
+		return len(matchLevelOrder)
+	}
+
+	return index
+}
+
+/*
+Function NewResult ranks the hits of a response of SzEngine.SearchByAttributes.
+
+Hits are ranked by match level (RESOLVED first), then by the sum of their best feature scores,
+then by entity ID. Feature scores and representative features are only present if the search used
+senzing.SzIncludeFeatureScores and senzing.SzEntityIncludeRepresentativeFeatures,
+as senzing.SzSearchByAttributesDefaultFlags does.
+
+Input
+  - searchResponse: The response, e.g. from response.SzEngineSearchByAttributes.
+
+Output
+  - The ranked hits.
+*/
+func NewResult(searchResponse *typedef.SzEngineSearchByAttributesResponse) (*Result, error) {
+	resolvedEntities, err := resolvedEntitiesOf(searchResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Hits: make([]Hit, 0, len(resolvedEntities))}
+
+	for _, resolved := range resolvedEntities {
+		result.Hits = append(result.Hits, newHit(resolved))
+	}
+
+	slices.SortStableFunc(result.Hits, compareHits)
+
+	for index := range result.Hits {
+		result.Hits[index].Rank = index + 1
+	}
+
+	return result, nil
+}
+
+/*
+Function ParseResult parses a response of SzEngine.SearchByAttributes with
+response.SzEngineSearchByAttributes and ranks the hits as NewResult does.
+
+Input
+  - responseJSON: The JSON response.
+
+Output
+  - The ranked hits.
+*/
+func ParseResult(responseJSON string) (*Result, error) {
+	searchResponse, err := response.SzEngineSearchByAttributes(context.Background(), responseJSON)
+	if err != nil {
+		return nil, fmt.Errorf("search cannot parse response: %w", err)
+	}
+
+	return NewResult(searchResponse)
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method AtLeast returns the hits with the match level or a stronger one, e.g. "POSSIBLY_SAME".
+*/
+func (result *Result) AtLeast(matchLevelCode string) []Hit {
+	hits := []Hit{}
+
+	for _, hit := range result.Hits {
+		if MatchLevelRank(hit.MatchLevelCode) <= MatchLevelRank(matchLevelCode) {
+			hits = append(hits, hit)
+		}
+	}
+
+	return hits
+}
+
+/*
+Method Page returns one page of hits.
+
+Input
+  - number: The page number, starting at 1.
+  - size: The number of hits per page.
+
+Output
+  - The hits on the page. Empty if the page is past the end or the arguments are not positive.
+*/
+func (result *Result) Page(number int, size int) []Hit {
+	if number < 1 || size < 1 || (number-1)*size >= len(result.Hits) {
+		return []Hit{}
+	}
+
+	start := (number - 1) * size
+
+	return slices.Clone(result.Hits[start:min(start+size, len(result.Hits))])
+}
+
+/*
+Method Pages returns the number of pages of the size.
+*/
+func (result *Result) Pages(size int) int {
+	if size < 1 {
+		return 0
+	}
+
+	return (len(result.Hits) + size - 1) / size
+}
+
+/*
+Method Top returns the n best hits, or all of them if there are fewer.
+*/
+func (result *Result) Top(n int) []Hit {
+	return result.Page(1, n)
+}
+
+/*
+Method TotalScore returns the sum of the best score of each feature type.
+*/
+func (hit Hit) TotalScore() int64 {
+	var result int64
+	for _, score := range hit.BestScores {
+		result += score
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func compareHits(a, b Hit) int {
+	return cmp.Or(
+		cmp.Compare(MatchLevelRank(a.MatchLevelCode), MatchLevelRank(b.MatchLevelCode)),
+		cmp.Compare(b.TotalScore(), a.TotalScore()),
+		cmp.Compare(a.EntityID, b.EntityID),
+	)
+}
+
+func newHit(resolved resolvedEntity) Hit {
+	entity := resolved.Entity.ResolvedEntity
+	hit := Hit{
+		BestScores:     map[string]int64{},
+		EntityID:       entity.EntityID,
+		EntityName:     entity.EntityName,
+		Features:       map[string][]string{},
+		MatchKey:       resolved.MatchInfo.MatchKey,
+		MatchLevelCode: resolved.MatchInfo.MatchLevelCode,
+		Rank:           0,
+		RecordSummary:  map[string]int64{},
+		RuleCode:       resolved.MatchInfo.ErruleCode,
+		Scores:         []FeatureScore{},
+	}
+
+	for feature, values := range entity.Features {
+		for _, value := range values {
+			hit.Features[feature] = append(hit.Features[feature], value.FeatDesc)
+		}
+	}
+
+	for _, summary := range entity.RecordSummary {
+		hit.RecordSummary[summary.DataSource] = summary.RecordCount
+	}
+
+	for feature, scores := range resolved.MatchInfo.FeatureScores {
+		for _, score := range scores {
+			hit.Scores = append(hit.Scores, FeatureScore{
+				Bucket:    score.ScoreBucket,
+				Candidate: score.CandidateFeatDesc,
+				Feature:   feature,
+				Inbound:   score.InboundFeatDesc,
+				Score:     score.Score,
+			})
+
+			if best, isPresent := hit.BestScores[feature]; !isPresent || score.Score > best {
+				hit.BestScores[feature] = score.Score
+			}
+		}
+	}
+
+	slices.SortFunc(hit.Scores, func(a, b FeatureScore) int {
+		return cmp.Or(cmp.Compare(a.Feature, b.Feature), cmp.Compare(b.Score, a.Score))
+	})
+
+	return hit
+}
+
+func resolvedEntitiesOf(searchResponse *typedef.SzEngineSearchByAttributesResponse) ([]resolvedEntity, error) {
+	view := responseView{} //exhaustruct:ignore
+
+	err := typedefview.Decode(searchResponse, &view)
+	if err != nil {
+		return nil, fmt.Errorf("search cannot read response: %w", err)
+	}
+
+	return view.ResolvedEntities, nil
+}
@@ -0,0 +1,457 @@
+package search_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-go/search"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const searchResponse = `{"RESOLVED_ENTITIES": [
+	{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 3, "ENTITY_NAME": "Bob Smith"}},
+		"MATCH_INFO": {"MATCH_KEY": "+NAME", "MATCH_LEVEL_CODE": "NAME_ONLY"}},
+	{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 2, "ENTITY_NAME": "Robert Smith"}},
+		"MATCH_INFO": {"ERRULE_CODE": "CNAME_CFF", "MATCH_KEY": "+NAME+ADDRESS", "MATCH_LEVEL_CODE": "POSSIBLY_SAME",
+			"FEATURE_SCORES": {"NAME": [{"SCORE": 90, "SCORE_BUCKET": "CLOSE"}], "ADDRESS": [{"SCORE": 80}]}}},
+	{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 1, "ENTITY_NAME": "Robert Smith",
+			"FEATURES": {"NAME": [{"FEAT_DESC": "Robert Smith"}, {"FEAT_DESC": "B Smith"}]},
+			"RECORD_SUMMARY": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_COUNT": 4}]}},
+		"MATCH_INFO": {"MATCH_KEY": "+NAME+ADDRESS", "MATCH_LEVEL_CODE": "POSSIBLY_SAME",
+			"FEATURE_SCORES": {"NAME": [{"SCORE": 100, "CANDIDATE_FEAT_DESC": "Robert Smith",
+				"INBOUND_FEAT_DESC": "Robert Smith", "SCORE_BUCKET": "SAME"}, {"SCORE": 70}],
+				"ADDRESS": [{"SCORE": 100}]}}},
+	{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 4}},
+		"MATCH_INFO": {"MATCH_KEY": "+NAME+DOB+ADDRESS", "MATCH_LEVEL_CODE": "RESOLVED"}}
+]}`
+
+var errSearch = errors.New("search failed")
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+// Returns entity N and N+1 for a search of the name "N", after a delay that shrinks as N grows.
+type batchEngine struct {
+	senzing.SzEngine
+
+	active    atomic.Int64
+	maxActive atomic.Int64
+	started   atomic.Int64
+}
+
+func (engine *batchEngine) SearchByAttributes(
+	_ context.Context,
+	attributes string,
+	_ string,
+	_ int64,
+) (string, error) {
+	engine.started.Add(1)
+
+	active := engine.active.Add(1)
+	defer engine.active.Add(-1)
+
+	for {
+		maxActive := engine.maxActive.Load()
+		if active <= maxActive || engine.maxActive.CompareAndSwap(maxActive, active) {
+			break
+		}
+	}
+
+	query := struct {
+		Names []struct {
+			NameFull string `json:"NAME_FULL"`
+		} `json:"NAMES"`
+	}{}
+
+	err := json.Unmarshal([]byte(attributes), &query)
+	if err != nil {
+		return "", err
+	}
+
+	var entityID int64
+
+	_, err = fmt.Sscan(query.Names[0].NameFull, &entityID)
+	if err != nil {
+		return "", errSearch
+	}
+
+	time.Sleep(time.Duration(10-entityID) * time.Millisecond)
+
+	return fmt.Sprintf(`{"RESOLVED_ENTITIES": [
+		{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": %d}}, "MATCH_INFO": {"MATCH_LEVEL_CODE": "RESOLVED"}},
+		{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": %d}}, "MATCH_INFO": {"MATCH_LEVEL_CODE": "POSSIBLY_SAME"}}
+	]}`, entityID, entityID+1), nil
+}
+
+type mockEngine struct {
+	senzing.SzEngine
+
+	attributes    string
+	flags         int64
+	searchProfile string
+}
+
+func (engine *mockEngine) SearchByAttributes(
+	_ context.Context,
+	attributes string,
+	searchProfile string,
+	flags int64,
+) (string, error) {
+	engine.attributes = attributes
+	engine.flags = flags
+	engine.searchProfile = searchProfile
+
+	return searchResponse, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func batchQueries(names ...string) func(yield func(*search.Query) bool) {
+	return func(yield func(*search.Query) bool) {
+		for _, name := range names {
+			if !yield(search.NewQuery().Name(name)) {
+				return
+			}
+		}
+	}
+}
+
+func entityIDs(hits []search.Hit) []int64 {
+	result := []int64{}
+	for _, hit := range hits {
+		result = append(result, hit.EntityID)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestQuery_JSON(test *testing.T) {
+	test.Parallel()
+
+	attributes, err := search.NewQuery().
+		Name("Robert Smith").
+		PersonName("Bob", "", "Smith").
+		Name("Robert Smith").
+		OrganizationName("").
+		Address("123 Main Street Las Vegas NV 89132").
+		AddressParts(search.Address{City: "Las Vegas", State: "NV"}). //exhaustruct:ignore
+		Phone("702-919-1300").
+		Email("bsmith@work.com").
+		DateOfBirth("1978-12-11").
+		Identifier(search.IdentifierSSN, "123-45-6789", "US").
+		Identifier(search.IdentifierDriversLicense, "112233", "NV").
+		Identifier(search.IdentifierPassport, "PP1234567", "").
+		Identifier(search.IdentifierTaxID, "", "US").
+		Attribute("RECORD_TYPE", "PERSON").
+		JSON()
+	require.NoError(test, err)
+	assert.JSONEq(test, `{
+		"NAMES": [{"NAME_FULL": "Robert Smith"}, {"NAME_FIRST": "Bob", "NAME_LAST": "Smith"}],
+		"ADDRESSES": [{"ADDR_FULL": "123 Main Street Las Vegas NV 89132"},
+			{"ADDR_CITY": "Las Vegas", "ADDR_STATE": "NV"}],
+		"PHONES": [{"PHONE_NUMBER": "702-919-1300"}],
+		"EMAILS": [{"EMAIL_ADDRESS": "bsmith@work.com"}],
+		"IDENTIFIERS": [{"SSN_NUMBER": "123-45-6789"},
+			{"DRIVERS_LICENSE_NUMBER": "112233", "DRIVERS_LICENSE_STATE": "NV"},
+			{"PASSPORT_NUMBER": "PP1234567"}],
+		"DATE_OF_BIRTH": "1978-12-11",
+		"RECORD_TYPE": "PERSON"
+	}`, attributes)
+}
+
+func TestQuery_JSON_Empty(test *testing.T) {
+	test.Parallel()
+
+	_, err := search.NewQuery().Name("").Phone("").JSON()
+	require.ErrorIs(test, err, search.ErrEmptyQuery)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestQuery_Search(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{} //exhaustruct:ignore
+	result, err := search.NewQuery().Name("Robert Smith").Search(test.Context(), engine)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"NAMES": [{"NAME_FULL": "Robert Smith"}]}`, engine.attributes)
+	assert.Equal(test, search.ProfileDefault, engine.searchProfile)
+	assert.Equal(test, senzing.SzSearchByAttributesDefaultFlags, engine.flags)
+	assert.Len(test, result.Hits, 4)
+
+	_, err = search.NewQuery().
+		Name("Robert Smith").
+		Profile(search.ProfileSearch).
+		Flags(senzing.SzSearchByAttributesMinimalStrong).
+		Search(test.Context(), engine)
+	require.NoError(test, err)
+	assert.Equal(test, search.ProfileSearch, engine.searchProfile)
+	assert.Equal(test, senzing.SzSearchByAttributesMinimalStrong, engine.flags)
+
+	_, err = search.NewQuery().Search(test.Context(), engine)
+	require.ErrorIs(test, err, search.ErrEmptyQuery)
+}
+
+func TestRecordAttributes(test *testing.T) {
+	test.Parallel()
+
+	attributes, err := search.RecordAttributes(`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1", "NAME_FULL": "Bob"}`)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"NAME_FULL": "Bob"}`, attributes)
+
+	_, err = search.RecordAttributes(`[1]`)
+	require.ErrorIs(test, err, search.ErrInvalidRecord)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestMatchLevelRank(test *testing.T) {
+	test.Parallel()
+
+	assert.Equal(test, 0, search.MatchLevelRank(graph.MatchLevelResolved))
+	assert.Less(test, search.MatchLevelRank(graph.MatchLevelNameOnly), search.MatchLevelRank(graph.MatchLevelDisclosed))
+	assert.Less(test, search.MatchLevelRank(graph.MatchLevelDisclosed), search.MatchLevelRank("UNKNOWN"))
+}
+
+func TestNewResult(test *testing.T) {
+	test.Parallel()
+
+	searchByAttributesResponse, err := response.SzEngineSearchByAttributes(test.Context(), searchResponse)
+	require.NoError(test, err)
+
+	result, err := search.NewResult(searchByAttributesResponse)
+	require.NoError(test, err)
+
+	expected, err := search.ParseResult(searchResponse)
+	require.NoError(test, err)
+	assert.Equal(test, expected, result)
+
+	result, err = search.NewResult(nil)
+	require.NoError(test, err)
+	assert.Empty(test, result.Hits)
+}
+
+func TestParseResult(test *testing.T) {
+	test.Parallel()
+
+	result, err := search.ParseResult(searchResponse)
+	require.NoError(test, err)
+	assert.Equal(test, []int64{4, 1, 2, 3}, entityIDs(result.Hits))
+
+	hit := result.Hits[1]
+	assert.Equal(test, 2, hit.Rank)
+	assert.Equal(test, "Robert Smith", hit.EntityName)
+	assert.Equal(test, graph.MatchLevelPossiblySame, hit.MatchLevelCode)
+	assert.Equal(test, map[string]int64{"ADDRESS": 100, "NAME": 100}, hit.BestScores)
+	assert.Equal(test, int64(200), hit.TotalScore())
+	assert.Equal(test, map[string][]string{"NAME": {"Robert Smith", "B Smith"}}, hit.Features)
+	assert.Equal(test, map[string]int64{"CUSTOMERS": 4}, hit.RecordSummary)
+	require.Len(test, hit.Scores, 3)
+	assert.Equal(test, search.FeatureScore{
+		Bucket: "SAME", Candidate: "Robert Smith", Feature: "NAME", Inbound: "Robert Smith", Score: 100,
+	}, hit.Scores[1])
+
+	_, err = search.ParseResult("{")
+	require.Error(test, err)
+}
+
+func TestParseResult_Fixtures(test *testing.T) {
+	test.Parallel()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", "SzEngineSearchByAttributesResponse.jsonl"))
+	require.NoError(test, err)
+
+	defer func() {
+		require.NoError(test, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+
+	for scanner.Scan() {
+		result, err := search.ParseResult(scanner.Text())
+		require.NoError(test, err)
+
+		entities := struct {
+			ResolvedEntities []json.RawMessage `json:"RESOLVED_ENTITIES"`
+		}{}
+		require.NoError(test, json.Unmarshal(scanner.Bytes(), &entities))
+		assert.Len(test, result.Hits, len(entities.ResolvedEntities))
+
+		for index, hit := range result.Hits {
+			assert.Equal(test, index+1, hit.Rank)
+		}
+	}
+
+	require.NoError(test, scanner.Err())
+}
+
+func TestResult_Page(test *testing.T) {
+	test.Parallel()
+
+	result, err := search.ParseResult(searchResponse)
+	require.NoError(test, err)
+	assert.Equal(test, []int64{4, 1}, entityIDs(result.Top(2)))
+	assert.Equal(test, []int64{4, 1, 2, 3}, entityIDs(result.Top(10)))
+	assert.Equal(test, []int64{2, 3}, entityIDs(result.Page(2, 2)))
+	assert.Equal(test, []int64{3}, entityIDs(result.Page(2, 3)))
+	assert.Empty(test, result.Page(3, 2))
+	assert.Empty(test, result.Page(0, 2))
+	assert.Empty(test, result.Top(0))
+	assert.Equal(test, 2, result.Pages(3))
+	assert.Equal(test, 0, result.Pages(0))
+
+	assert.Equal(test, []int64{4, 1, 2}, entityIDs(result.AtLeast(graph.MatchLevelPossiblySame)))
+	assert.Equal(test, []int64{4}, entityIDs(result.AtLeast(graph.MatchLevelResolved)))
+}
+
+func TestBatchSearch(test *testing.T) {
+	test.Parallel()
+
+	engine := &batchEngine{} //exhaustruct:ignore
+	options := search.BatchOptions{Concurrency: 2, Dedupe: false, InOrder: true}
+	indexes := []int{}
+
+	for result := range search.BatchSearch(test.Context(), engine, batchQueries("1", "2", "3", "4", "5"), options) {
+		require.NoError(test, result.Err)
+		assert.Equal(test, []int64{int64(result.Index + 1), int64(result.Index + 2)}, entityIDs(result.Result.Hits))
+
+		indexes = append(indexes, result.Index)
+	}
+
+	assert.Equal(test, []int{0, 1, 2, 3, 4}, indexes)
+	assert.Equal(test, int64(2), engine.maxActive.Load())
+}
+
+func TestBatchSearch_Completion(test *testing.T) {
+	test.Parallel()
+
+	engine := &batchEngine{} //exhaustruct:ignore
+	options := search.BatchOptions{Concurrency: 3, Dedupe: false, InOrder: false}
+	indexes := []int{}
+
+	for result := range search.BatchSearch(test.Context(), engine, batchQueries("1", "5", "9"), options) {
+		require.NoError(test, result.Err)
+
+		indexes = append(indexes, result.Index)
+	}
+
+	assert.ElementsMatch(test, []int{0, 1, 2}, indexes)
+	assert.Equal(test, 2, indexes[0])
+}
+
+func TestBatchSearch_Dedupe(test *testing.T) {
+	test.Parallel()
+
+	engine := &batchEngine{} //exhaustruct:ignore
+	options := search.BatchOptions{Concurrency: 0, Dedupe: true, InOrder: true}
+	hits := [][]int64{}
+
+	for result := range search.BatchSearch(test.Context(), engine, batchQueries("1", "2", "4"), options) {
+		require.NoError(test, result.Err)
+
+		hits = append(hits, entityIDs(result.Result.Hits))
+
+		for index, hit := range result.Result.Hits {
+			assert.Equal(test, index+1, hit.Rank, "ranks are renumbered after removing duplicates")
+		}
+	}
+
+	assert.Equal(test, [][]int64{{1, 2}, {3}, {4, 5}}, hits)
+}
+
+func TestBatchSearch_InOrderWindow(test *testing.T) {
+	test.Parallel()
+
+	engine := &batchEngine{} //exhaustruct:ignore
+	options := search.BatchOptions{Concurrency: 2, Dedupe: false, InOrder: true}
+	names := append([]string{"1"}, slices.Repeat([]string{"9"}, 20)...)
+	count := 0
+
+	for result := range search.BatchSearch(test.Context(), engine, batchQueries(names...), options) {
+		require.NoError(test, result.Err)
+
+		if count == 0 {
+			// The first query is the slowest, so the others wait for it.
+			assert.LessOrEqual(test, engine.started.Load(), int64(4))
+		}
+
+		count++
+	}
+
+	assert.Equal(test, len(names), count)
+}
+
+func TestBatchSearch_Errors(test *testing.T) {
+	test.Parallel()
+
+	engine := &batchEngine{} //exhaustruct:ignore
+	options := search.BatchOptions{Concurrency: 2, Dedupe: true, InOrder: true}
+	queries := func(yield func(*search.Query) bool) {
+		_ = yield(search.NewQuery().Name("1")) && yield(search.NewQuery()) &&
+			yield(search.NewQuery().Name("bad")) && yield(search.NewQuery().Name("3"))
+	}
+	results := slices.Collect(search.BatchSearch(test.Context(), engine, queries, options))
+
+	require.Len(test, results, 4)
+	require.NoError(test, results[0].Err)
+	require.ErrorIs(test, results[1].Err, search.ErrEmptyQuery)
+	assert.Nil(test, results[1].Result)
+	require.ErrorIs(test, results[2].Err, errSearch)
+	require.NoError(test, results[3].Err)
+	assert.Equal(test, []int64{3, 4}, entityIDs(results[3].Result.Hits))
+}
+
+func TestBatchSearch_Stop(test *testing.T) {
+	test.Parallel()
+
+	engine := &batchEngine{} //exhaustruct:ignore
+	options := search.BatchOptions{Concurrency: 2, Dedupe: false, InOrder: true}
+
+	var read sync.WaitGroup
+
+	read.Add(1)
+
+	queries := func(yield func(*search.Query) bool) {
+		defer read.Done()
+
+		for index := range 1000 {
+			if !yield(search.NewQuery().Name(fmt.Sprint(index % 10))) {
+				return
+			}
+		}
+	}
+
+	count := 0
+
+	for range search.BatchSearch(test.Context(), engine, queries, options) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+
+	read.Wait()
+	assert.Equal(test, 3, count)
+	assert.Equal(test, int64(0), engine.active.Load())
+}
@@ -1,4 +1,15 @@
 /*
 Package senzing contains the Senzing Go SDK API interface.
+
+An [ExportHandle] owns the handle of an export report, reads it as an io.Reader,
+and closes it once:
+
+	exportHandle, err := senzing.OpenJSONExport(ctx, szEngine, senzing.SzExportDefaultFlags, nil)
+	...
+	defer exportHandle.Close()
+	_, err = io.Copy(file, exportHandle)
+
+An [ExportTracker] reports handles left open past a deadline or never closed. In tests,
+pass one when opening exports and finish with require.NoError(test, tracker.CheckClosed()).
 */
 package senzing
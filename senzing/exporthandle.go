@@ -0,0 +1,387 @@
+package senzing
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types - struct
+// ----------------------------------------------------------------------------
+
+/*
+Type ExportHandle struct owns a handle returned by SzEngine.ExportJSONEntityReport or
+SzEngine.ExportCsvEntityReport. It reads the report as an [io.Reader] and closes the
+handle at most once. Create one with [OpenJSONExport], [OpenCsvExport], or [NewExportHandle].
+*/
+type ExportHandle struct {
+	closeErr  error
+	ctx       context.Context
+	fragment  string // The unread part of the last fragment.
+	handle    uintptr
+	isClosed  bool
+	isEOF     bool
+	mutex     sync.Mutex
+	szEngine  SzEngine
+	tracker   *ExportTracker
+	trackerID int64
+}
+
+/*
+Type ExportTracker struct records open export handles, to find those that are not closed.
+Create one with [NewExportTracker] and pass it when opening exports.
+*/
+type ExportTracker struct {
+	deadline time.Duration
+	lastID   int64
+	mutex    sync.Mutex
+	onLeak   func(OpenExport)
+	open     map[int64]*trackedExport
+}
+
+/*
+Type OpenExport struct describes an export handle that has not been closed.
+*/
+type OpenExport struct {
+	Handle      uintptr
+	IsCollected bool // The ExportHandle was garbage collected without being closed.
+	OpenedAt    time.Time
+	Stack       string // The stack of the goroutine that opened the export.
+}
+
+type trackedExport struct {
+	export OpenExport
+	timer  *time.Timer
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The longest line ExportHandle.Lines returns, e.g. one entity with many records.
+const maxExportLineSize = 16 * 1024 * 1024
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrExportClosed is returned when reading from a closed ExportHandle.
+var ErrExportClosed = errors.New("senzing export handle is closed")
+
+// ErrExportLeak is returned by ExportTracker.CheckClosed when export handles are open.
+var ErrExportLeak = errors.New("senzing export handles are open")
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewExportHandle takes ownership of an export handle.
+
+Input
+  - ctx: A context used by Read. Close does not stop when ctx is canceled.
+  - szEngine: The engine that returned the handle.
+  - handle: The handle.
+  - tracker: Records the handle until it is closed. May be nil.
+
+Output
+  - An ExportHandle. Close it, usually with defer.
+*/
+func NewExportHandle(ctx context.Context, szEngine SzEngine, handle uintptr, tracker *ExportTracker) *ExportHandle {
+	result := &ExportHandle{ //exhaustruct:ignore
+		ctx:      ctx,
+		handle:   handle,
+		szEngine: szEngine,
+		tracker:  tracker,
+	}
+
+	if tracker != nil {
+		result.trackerID = tracker.add(handle)
+		runtime.AddCleanup(result, tracker.collected, result.trackerID)
+	}
+
+	return result
+}
+
+/*
+Function NewExportTracker creates an ExportTracker.
+
+Input
+  - deadline: How long a handle may stay open before it is reported. Zero never reports open handles.
+  - onLeak: Called once for each handle open past the deadline, and for each handle garbage collected
+    while open. It is called on its own goroutine. May be nil.
+*/
+func NewExportTracker(deadline time.Duration, onLeak func(OpenExport)) *ExportTracker {
+	return &ExportTracker{ //exhaustruct:ignore
+		deadline: deadline,
+		onLeak:   onLeak,
+		open:     map[int64]*trackedExport{},
+	}
+}
+
+/*
+Function OpenCsvExport calls SzEngine.ExportCsvEntityReport and takes ownership of the handle.
+See [NewExportHandle].
+*/
+func OpenCsvExport(
+	ctx context.Context,
+	szEngine SzEngine,
+	csvColumnList string,
+	flags int64,
+	tracker *ExportTracker,
+) (*ExportHandle, error) {
+	handle, err := szEngine.ExportCsvEntityReport(ctx, csvColumnList, flags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return NewExportHandle(ctx, szEngine, handle, tracker), nil
+}
+
+/*
+Function OpenJSONExport calls SzEngine.ExportJSONEntityReport and takes ownership of the handle.
+See [NewExportHandle].
+*/
+func OpenJSONExport(
+	ctx context.Context,
+	szEngine SzEngine,
+	flags int64,
+	tracker *ExportTracker,
+) (*ExportHandle, error) {
+	handle, err := szEngine.ExportJSONEntityReport(ctx, flags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return NewExportHandle(ctx, szEngine, handle, tracker), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method CheckClosed returns an error wrapping ErrExportLeak, with the stack of each open handle,
+if any handle is open. In tests: require.NoError(test, tracker.CheckClosed()).
+*/
+func (tracker *ExportTracker) CheckClosed() error {
+	open := tracker.Open()
+	if len(open) == 0 {
+		return nil
+	}
+
+	var message strings.Builder
+
+	for _, export := range open {
+		fmt.Fprintf(&message, "\nhandle %d opened at %s:\n%s",
+			export.Handle, export.OpenedAt.Format(time.RFC3339), export.Stack)
+	}
+
+	return fmt.Errorf("%w: %d open%s", ErrExportLeak, len(open), message.String())
+}
+
+/*
+Method Close calls SzEngine.CloseExportReport the first time it is called, and returns its error every time.
+*/
+func (exportHandle *ExportHandle) Close() error {
+	exportHandle.mutex.Lock()
+	defer exportHandle.mutex.Unlock()
+
+	if exportHandle.isClosed {
+		return exportHandle.closeErr
+	}
+
+	exportHandle.isClosed = true
+	exportHandle.fragment = ""
+	exportHandle.closeErr = exportHandle.szEngine.CloseExportReport(
+		context.WithoutCancel(exportHandle.ctx),
+		exportHandle.handle,
+	)
+
+	if exportHandle.tracker != nil {
+		exportHandle.tracker.remove(exportHandle.trackerID)
+	}
+
+	return exportHandle.closeErr
+}
+
+/*
+Method Handle returns the raw handle, e.g. for SzEngine.FetchNext. Do not close it with SzEngine.CloseExportReport.
+*/
+func (exportHandle *ExportHandle) Handle() uintptr {
+	return exportHandle.handle
+}
+
+/*
+Method Lines returns the report one line at a time, without the line ending, reassembling lines
+split across SzEngine.FetchNext fragments. Each line of SzEngine.ExportJSONEntityReport is one entity.
+A line is only valid until the next iteration. Lines are at most 16 MiB.
+The iteration stops after the first error, which is returned with a nil line.
+*/
+func (exportHandle *ExportHandle) Lines() iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		scanner := bufio.NewScanner(exportHandle)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxExportLineSize)
+
+		for scanner.Scan() {
+			if !yield(scanner.Bytes(), nil) {
+				return
+			}
+		}
+
+		err := scanner.Err()
+		if err != nil {
+			yield(nil, fmt.Errorf("senzing cannot read lines of export handle %d: %w", exportHandle.handle, err))
+		}
+	}
+}
+
+/*
+Method Open returns the open handles, oldest first.
+*/
+func (tracker *ExportTracker) Open() []OpenExport {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	result := make([]OpenExport, 0, len(tracker.open))
+	for _, tracked := range tracker.open {
+		result = append(result, tracked.export)
+	}
+
+	slices.SortFunc(result, func(a, b OpenExport) int {
+		return cmp.Or(a.OpenedAt.Compare(b.OpenedAt), cmp.Compare(a.Handle, b.Handle))
+	})
+
+	return result
+}
+
+/*
+Method Read reads the report, calling SzEngine.FetchNext as needed. It returns io.EOF at the end
+of the report, and an error wrapping ErrExportClosed after Close.
+*/
+func (exportHandle *ExportHandle) Read(buffer []byte) (int, error) {
+	exportHandle.mutex.Lock()
+	defer exportHandle.mutex.Unlock()
+
+	if exportHandle.isClosed {
+		return 0, fmt.Errorf("senzing cannot read export handle %d: %w", exportHandle.handle, ErrExportClosed)
+	}
+
+	if len(buffer) == 0 {
+		return 0, nil
+	}
+
+	for len(exportHandle.fragment) == 0 {
+		if exportHandle.isEOF {
+			return 0, io.EOF
+		}
+
+		fragment, err := exportHandle.szEngine.FetchNext(exportHandle.ctx, exportHandle.handle)
+		if err != nil {
+			return 0, err //nolint:wrapcheck
+		}
+
+		exportHandle.fragment = fragment
+		exportHandle.isEOF = len(fragment) == 0
+	}
+
+	count := copy(buffer, exportHandle.fragment)
+	exportHandle.fragment = exportHandle.fragment[count:]
+
+	return count, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (tracker *ExportTracker) add(handle uintptr) int64 {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.lastID++
+	trackerID := tracker.lastID
+	tracked := &trackedExport{
+		export: OpenExport{Handle: handle, IsCollected: false, OpenedAt: time.Now(), Stack: string(debug.Stack())},
+		timer:  nil,
+	}
+
+	if tracker.deadline > 0 && tracker.onLeak != nil {
+		tracked.timer = time.AfterFunc(tracker.deadline, func() {
+			tracker.report(trackerID, false)
+		})
+	}
+
+	tracker.open[trackerID] = tracked
+
+	return trackerID
+}
+
+// Called when an ExportHandle is garbage collected. It is still in open only if it was not closed.
+func (tracker *ExportTracker) collected(trackerID int64) {
+	tracker.mutex.Lock()
+
+	tracked, isPresent := tracker.open[trackerID]
+	if isPresent {
+		tracked.export.IsCollected = true
+
+		if tracked.timer != nil {
+			tracked.timer.Stop()
+		}
+	}
+
+	tracker.mutex.Unlock()
+
+	// Cleanups run one at a time on a runtime goroutine, so onLeak must not block it.
+	if isPresent {
+		go tracker.report(trackerID, true)
+	}
+}
+
+func (tracker *ExportTracker) remove(trackerID int64) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracked, isPresent := tracker.open[trackerID]
+	if !isPresent {
+		return
+	}
+
+	if tracked.timer != nil {
+		tracked.timer.Stop()
+	}
+
+	delete(tracker.open, trackerID)
+}
+
+func (tracker *ExportTracker) report(trackerID int64, isCollected bool) {
+	if tracker.onLeak == nil {
+		return
+	}
+
+	tracker.mutex.Lock()
+	tracked, isPresent := tracker.open[trackerID]
+
+	var export OpenExport
+	if isPresent {
+		export = tracked.export
+		export.IsCollected = isCollected
+	}
+
+	tracker.mutex.Unlock()
+
+	if isPresent {
+		tracker.onLeak(export)
+	}
+}
@@ -0,0 +1,211 @@
+package senzing_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const report = `{"RESOLVED_ENTITY": {"ENTITY_ID": 1}}
+{"RESOLVED_ENTITY": {"ENTITY_ID": 2}}
+`
+
+var errClose = errors.New("close failed")
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockEngine struct {
+	senzing.SzEngine
+
+	closeErr   error
+	closed     int
+	fetched    int
+	lastHandle uintptr
+	mutex      sync.Mutex
+}
+
+func (engine *mockEngine) CloseExportReport(_ context.Context, _ uintptr) error {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.closed++
+
+	return engine.closeErr
+}
+
+func (engine *mockEngine) ExportCsvEntityReport(ctx context.Context, _ string, flags int64) (uintptr, error) {
+	return engine.ExportJSONEntityReport(ctx, flags)
+}
+
+func (engine *mockEngine) ExportJSONEntityReport(_ context.Context, _ int64) (uintptr, error) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.lastHandle++
+
+	return engine.lastHandle, nil
+}
+
+// Returns the report in chunks of 10 bytes.
+func (engine *mockEngine) FetchNext(_ context.Context, _ uintptr) (string, error) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	end := min(engine.fetched+10, len(report))
+	result := report[engine.fetched:end]
+	engine.fetched = end
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestExportHandle(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{} //exhaustruct:ignore
+	tracker := senzing.NewExportTracker(0, nil)
+
+	exportHandle, err := senzing.OpenJSONExport(test.Context(), engine, senzing.SzExportDefaultFlags, tracker)
+	require.NoError(test, err)
+	assert.Equal(test, uintptr(1), exportHandle.Handle())
+	assert.Len(test, tracker.Open(), 1)
+	require.ErrorIs(test, tracker.CheckClosed(), senzing.ErrExportLeak)
+
+	content, err := io.ReadAll(exportHandle)
+	require.NoError(test, err)
+	assert.Equal(test, report, string(content))
+
+	require.NoError(test, exportHandle.Close())
+	require.NoError(test, exportHandle.Close())
+	assert.Equal(test, 1, engine.closed)
+	require.NoError(test, tracker.CheckClosed())
+
+	_, err = exportHandle.Read(make([]byte, 10))
+	require.ErrorIs(test, err, senzing.ErrExportClosed)
+}
+
+func TestExportHandle_Lines(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{} //exhaustruct:ignore
+
+	exportHandle, err := senzing.OpenJSONExport(test.Context(), engine, senzing.SzExportDefaultFlags, nil)
+	require.NoError(test, err)
+
+	defer func() { require.NoError(test, exportHandle.Close()) }()
+
+	lines := []string{}
+
+	for line, err := range exportHandle.Lines() {
+		require.NoError(test, err)
+
+		lines = append(lines, string(line))
+	}
+
+	assert.Equal(test, []string{`{"RESOLVED_ENTITY": {"ENTITY_ID": 1}}`, `{"RESOLVED_ENTITY": {"ENTITY_ID": 2}}`}, lines)
+}
+
+func TestExportHandle_CloseError(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{closeErr: errClose} //exhaustruct:ignore
+
+	exportHandle, err := senzing.OpenCsvExport(test.Context(), engine, "*", senzing.SzExportDefaultFlags, nil)
+	require.NoError(test, err)
+	require.ErrorIs(test, exportHandle.Close(), errClose)
+	require.ErrorIs(test, exportHandle.Close(), errClose)
+	assert.Equal(test, 1, engine.closed)
+}
+
+func TestExportTracker_Deadline(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{} //exhaustruct:ignore
+	leaks := make(chan senzing.OpenExport, 2)
+	tracker := senzing.NewExportTracker(10*time.Millisecond, func(export senzing.OpenExport) { leaks <- export })
+
+	closed := senzing.NewExportHandle(test.Context(), engine, 1, tracker)
+	open := senzing.NewExportHandle(test.Context(), engine, 2, tracker)
+	require.NoError(test, closed.Close())
+
+	leak := <-leaks
+	assert.Equal(test, uintptr(2), leak.Handle)
+	assert.False(test, leak.IsCollected)
+	assert.Contains(test, leak.Stack, "TestExportTracker_Deadline")
+
+	err := tracker.CheckClosed()
+	require.ErrorIs(test, err, senzing.ErrExportLeak)
+	assert.Contains(test, err.Error(), "handle 2")
+
+	require.NoError(test, open.Close())
+	require.NoError(test, tracker.CheckClosed())
+	assert.Empty(test, leaks)
+}
+
+func TestExportTracker_Collected(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{} //exhaustruct:ignore
+	leaks := make(chan senzing.OpenExport, 1)
+	tracker := senzing.NewExportTracker(0, func(export senzing.OpenExport) { leaks <- export })
+
+	func() {
+		_ = senzing.NewExportHandle(test.Context(), engine, 3, tracker)
+	}()
+
+	var leak senzing.OpenExport
+
+	require.Eventually(test, func() bool {
+		runtime.GC()
+
+		select {
+		case leak = <-leaks:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(test, uintptr(3), leak.Handle)
+	assert.True(test, leak.IsCollected)
+	assert.True(test, tracker.Open()[0].IsCollected)
+	require.ErrorIs(test, tracker.CheckClosed(), senzing.ErrExportLeak)
+}
+
+func TestExportTracker_CollectedDoesNotBlock(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{} //exhaustruct:ignore
+	leaks := make(chan senzing.OpenExport, 2)
+	release := make(chan struct{})
+	tracker := senzing.NewExportTracker(0, func(export senzing.OpenExport) {
+		leaks <- export
+		<-release // A slow onLeak does not hold up the reports of other handles.
+	})
+
+	defer close(release)
+
+	func() {
+		_ = senzing.NewExportHandle(test.Context(), engine, 4, tracker)
+		_ = senzing.NewExportHandle(test.Context(), engine, 5, tracker)
+	}()
+
+	require.Eventually(test, func() bool {
+		runtime.GC()
+
+		return len(leaks) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
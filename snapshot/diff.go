@@ -0,0 +1,181 @@
+package snapshot
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/params"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Diff struct classifies the changes between two snapshots. Every list is sorted.
+
+An entity can appear in more than one list, e.g. an entity that was split and whose
+remaining records were merged with another entity.
+*/
+type Diff struct {
+	Changed []int64 `json:"changed"` // In both, with other records or relations, and not merged or split.
+	Created []int64 `json:"created"` // Only in the later snapshot, with only new records.
+	Deleted []int64 `json:"deleted"` // Only in the earlier snapshot, with only removed records.
+	Merged  []Merge `json:"merged"`  // Later entities with records of more than one earlier entity.
+	Moved   []Move  `json:"moved"`   // Records in both snapshots whose entity ID changed.
+	Split   []Split `json:"split"`   // Earlier entities with records in more than one later entity.
+}
+
+/*
+Type Merge struct is a later entity with records from more than one earlier entity.
+*/
+type Merge struct {
+	EntityID int64   `json:"id"`   // The later entity.
+	From     []int64 `json:"from"` // The earlier entities.
+}
+
+/*
+Type Move struct is a record whose entity ID changed.
+*/
+type Move struct {
+	FromEntityID int64            `json:"from"`
+	Record       params.RecordKey `json:"record"`
+	ToEntityID   int64            `json:"to"`
+}
+
+/*
+Type Split struct is an earlier entity with records in more than one later entity.
+*/
+type Split struct {
+	EntityID int64   `json:"id"`   // The earlier entity.
+	Into     []int64 `json:"into"` // The later entities.
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Compare compares two snapshots.
+
+Input
+  - before: The earlier snapshot.
+  - after: The later snapshot.
+
+Output
+  - The changes. Records added or removed between the snapshots are not moves.
+*/
+func Compare(before *Snapshot, after *Snapshot) *Diff {
+	result := &Diff{
+		Changed: []int64{},
+		Created: []int64{},
+		Deleted: []int64{},
+		Merged:  []Merge{},
+		Moved:   []Move{},
+		Split:   []Split{},
+	}
+
+	sources := map[int64]map[int64]bool{} // Later entity ID to the earlier entities of its records.
+	targets := map[int64]map[int64]bool{} // Earlier entity ID to the later entities of its records.
+
+	for record, toEntityID := range after.records {
+		if sources[toEntityID] == nil {
+			sources[toEntityID] = map[int64]bool{}
+		}
+
+		fromEntityID, isPresent := before.records[record]
+		if !isPresent {
+			continue
+		}
+
+		sources[toEntityID][fromEntityID] = true
+
+		if targets[fromEntityID] == nil {
+			targets[fromEntityID] = map[int64]bool{}
+		}
+
+		targets[fromEntityID][toEntityID] = true
+
+		if fromEntityID != toEntityID {
+			result.Moved = append(result.Moved, Move{FromEntityID: fromEntityID, Record: record, ToEntityID: toEntityID})
+		}
+	}
+
+	for _, entityID := range after.EntityIDs() {
+		switch {
+		case len(sources[entityID]) > 1:
+			result.Merged = append(result.Merged, Merge{EntityID: entityID, From: slices.Sorted(maps.Keys(sources[entityID]))})
+		case len(sources[entityID]) == 0:
+			if _, isPresent := before.entities[entityID]; !isPresent {
+				result.Created = append(result.Created, entityID)
+			}
+		}
+	}
+
+	for _, entityID := range before.EntityIDs() {
+		switch {
+		case len(targets[entityID]) > 1:
+			result.Split = append(result.Split, Split{EntityID: entityID, Into: slices.Sorted(maps.Keys(targets[entityID]))})
+		case len(targets[entityID]) == 0:
+			if _, isPresent := after.entities[entityID]; !isPresent {
+				result.Deleted = append(result.Deleted, entityID)
+			}
+		}
+
+		earlier := before.entities[entityID]
+		later, isPresent := after.entities[entityID]
+
+		if isPresent && len(sources[entityID]) <= 1 && len(targets[entityID]) <= 1 && !earlier.equal(later) {
+			result.Changed = append(result.Changed, entityID)
+		}
+	}
+
+	slices.SortFunc(result.Moved, func(a, b Move) int {
+		return cmp.Or(cmp.Compare(a.FromEntityID, b.FromEntityID), compareRecords(a.Record, b.Record))
+	})
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method IsEmpty returns true if the snapshots have the same entities.
+*/
+func (diff *Diff) IsEmpty() bool {
+	return len(diff.Changed) == 0 && len(diff.Created) == 0 && len(diff.Deleted) == 0 &&
+		len(diff.Merged) == 0 && len(diff.Moved) == 0 && len(diff.Split) == 0
+}
+
+/*
+Method String returns a summary with one line per merge and split, and counts of the other changes.
+*/
+func (diff *Diff) String() string {
+	var result strings.Builder
+
+	fmt.Fprintf(&result, "%d created, %d deleted, %d merged, %d split, %d changed, %d records moved\n",
+		len(diff.Created), len(diff.Deleted), len(diff.Merged), len(diff.Split), len(diff.Changed), len(diff.Moved))
+
+	for _, merge := range diff.Merged {
+		fmt.Fprintf(&result, "merged %v into %d\n", merge.From, merge.EntityID)
+	}
+
+	for _, split := range diff.Split {
+		fmt.Fprintf(&result, "split %d into %v\n", split.EntityID, split.Into)
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (entity Entity) equal(other Entity) bool {
+	return slices.Equal(entity.Records, other.Records) && slices.Equal(entity.Relations, other.Relations)
+}
@@ -0,0 +1,31 @@
+/*
+Package snapshot records which records are in which entities, and compares two recordings.
+
+A snapshot is the compact state of each entity: its record keys and its relationships.
+It can be captured from SzEngine.ExportJSONEntityReport with [CaptureExport],
+or from chosen entities with [CaptureEntities]. Either sends entities to a [Sink]:
+a [Snapshot] keeps them in memory, while a [Writer] streams them to disk as JSON lines,
+so capturing does not depend on the size of the repository:
+
+	file, err := os.Create("before.jsonl")
+	...
+	buffered := bufio.NewWriter(file)
+	err = snapshot.CaptureExport(ctx, szEngine, snapshot.NewWriter(buffered))
+	...
+	err = buffered.Flush()
+
+After reloading data, capture again and compare:
+
+	before, err := snapshot.Read(beforeFile)
+	...
+	after := snapshot.New()
+	err = snapshot.CaptureExport(ctx, szEngine, after)
+	...
+	diff := snapshot.Compare(before, after)
+	fmt.Print(diff)
+
+[Compare] classifies the changes as created, deleted, merged, and split entities,
+records that moved to another entity, and entities whose records or relationships changed.
+Use [Scan] to process a written snapshot one entity at a time.
+*/
+package snapshot
@@ -0,0 +1,311 @@
+package snapshot
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/params"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Entity struct is the compact state of one entity: its records and relationships.
+*/
+type Entity struct {
+	ID        int64              `json:"id"`
+	Records   []params.RecordKey `json:"records"`   // Sorted by data source code, then record ID.
+	Relations []Relation         `json:"relations"` // Sorted by entity ID.
+}
+
+/*
+Type Relation struct is a relationship of an entity to another.
+*/
+type Relation struct {
+	EntityID       int64  `json:"id"`
+	MatchLevelCode string `json:"level"`
+}
+
+/*
+Type Sink interface receives entities as they are captured. [Snapshot] and [Writer] are sinks.
+*/
+type Sink interface {
+	Add(entity Entity) error
+}
+
+/*
+Type Snapshot struct holds entities in memory, indexed by entity ID and by record. Create one with [New].
+*/
+type Snapshot struct {
+	entities map[int64]Entity
+	records  map[params.RecordKey]int64 // Record to entity ID.
+}
+
+type entityResponse struct {
+	RelatedEntities []struct {
+		EntityID       int64  `json:"ENTITY_ID"`
+		MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	} `json:"RELATED_ENTITIES"`
+	ResolvedEntity struct {
+		EntityID int64              `json:"ENTITY_ID"`
+		Records  []params.RecordKey `json:"RECORDS"`
+	} `json:"RESOLVED_ENTITY"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Flags is the flags used to capture entities: their records and all relationships with match levels.
+const Flags = senzing.SzEntityIncludeRecordData |
+	senzing.SzEntityIncludeAllRelations |
+	senzing.SzEntityIncludeRelatedMatchingInfo
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrDuplicateRecord is returned when a record is added to a Snapshot in a second entity.
+var ErrDuplicateRecord = errors.New("snapshot record is in more than one entity")
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function New creates an empty Snapshot.
+*/
+func New() *Snapshot {
+	return &Snapshot{
+		entities: map[int64]Entity{},
+		records:  map[params.RecordKey]int64{},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function CaptureEntities captures entities with SzEngine.GetEntityByEntityID.
+Entities that are not found are skipped, so IDs from an older snapshot can be captured again.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - entityIDs: The entities to capture.
+  - sink: Receives each entity, e.g. a Snapshot or a Writer.
+*/
+func CaptureEntities(ctx context.Context, szEngine senzing.SzEngine, entityIDs iter.Seq[int64], sink Sink) error {
+	for entityID := range entityIDs {
+		response, err := szEngine.GetEntityByEntityID(ctx, entityID, Flags)
+		if errors.Is(err, szerror.ErrSzNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		err = addResponse(sink, response)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+Function CaptureExport captures all entities with SzEngine.ExportJSONEntityReport.
+Entities are sent to the sink as they are fetched, so a Writer captures any number of them.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - sink: Receives each entity, e.g. a Snapshot or a Writer.
+*/
+func CaptureExport(ctx context.Context, szEngine senzing.SzEngine, sink Sink) (err error) {
+	exportHandle, err := senzing.OpenJSONExport(ctx, szEngine, Flags|senzing.SzExportIncludeAllEntities, nil)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	defer func() {
+		closeErr := exportHandle.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	for line, err := range exportHandle.Lines() {
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		err = addLine(sink, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+Function ParseEntity parses a response of SzEngine.GetEntityByEntityID, or one entity of an export,
+retrieved with Flags.
+*/
+func ParseEntity(responseJSON string) (Entity, error) {
+	response := entityResponse{} //exhaustruct:ignore
+
+	err := json.Unmarshal([]byte(responseJSON), &response)
+	if err != nil {
+		return Entity{}, fmt.Errorf("snapshot cannot unmarshal entity: %w", err) //exhaustruct:ignore
+	}
+
+	result := Entity{
+		ID:        response.ResolvedEntity.EntityID,
+		Records:   response.ResolvedEntity.Records,
+		Relations: make([]Relation, 0, len(response.RelatedEntities)),
+	}
+
+	for _, related := range response.RelatedEntities {
+		result.Relations = append(result.Relations, Relation{
+			EntityID:       related.EntityID,
+			MatchLevelCode: related.MatchLevelCode,
+		})
+	}
+
+	result.normalize()
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Add adds an entity, replacing an entity with the same ID.
+A record may only be in one entity.
+*/
+func (snapshot *Snapshot) Add(entity Entity) error {
+	entity.normalize()
+
+	for _, record := range entity.Records {
+		if entityID, isPresent := snapshot.records[record]; isPresent && entityID != entity.ID {
+			return fmt.Errorf("%w: %s is in entities %d and %d", ErrDuplicateRecord, record, entityID, entity.ID)
+		}
+	}
+
+	if previous, isPresent := snapshot.entities[entity.ID]; isPresent {
+		for _, record := range previous.Records {
+			delete(snapshot.records, record)
+		}
+	}
+
+	snapshot.entities[entity.ID] = entity
+
+	for _, record := range entity.Records {
+		snapshot.records[record] = entity.ID
+	}
+
+	return nil
+}
+
+/*
+Method Entities returns the entities in order of entity ID.
+*/
+func (snapshot *Snapshot) Entities() iter.Seq[Entity] {
+	return func(yield func(Entity) bool) {
+		for _, entityID := range snapshot.EntityIDs() {
+			if !yield(snapshot.entities[entityID]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Method Entity returns an entity by ID.
+*/
+func (snapshot *Snapshot) Entity(entityID int64) (Entity, bool) {
+	entity, isPresent := snapshot.entities[entityID]
+
+	return entity, isPresent
+}
+
+/*
+Method EntityIDs returns the entity IDs in order.
+*/
+func (snapshot *Snapshot) EntityIDs() []int64 {
+	return slices.Sorted(maps.Keys(snapshot.entities))
+}
+
+/*
+Method EntityOf returns the ID of the entity with the record.
+*/
+func (snapshot *Snapshot) EntityOf(record params.RecordKey) (int64, bool) {
+	entityID, isPresent := snapshot.records[record]
+
+	return entityID, isPresent
+}
+
+/*
+Method Len returns the number of entities.
+*/
+func (snapshot *Snapshot) Len() int {
+	return len(snapshot.entities)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Sorts copies of records and relations so equal entities have equal JSON.
+func (entity *Entity) normalize() {
+	entity.Records = append([]params.RecordKey{}, entity.Records...)
+	entity.Relations = append([]Relation{}, entity.Relations...)
+
+	slices.SortFunc(entity.Records, compareRecords)
+	slices.SortFunc(entity.Relations, func(a, b Relation) int {
+		return cmp.Compare(a.EntityID, b.EntityID)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func addLine(sink Sink, line []byte) error {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil
+	}
+
+	return addResponse(sink, string(line))
+}
+
+func compareRecords(a, b params.RecordKey) int {
+	return cmp.Or(cmp.Compare(a.DataSource, b.DataSource), cmp.Compare(a.ID, b.ID))
+}
+
+func addResponse(sink Sink, response string) error {
+	entity, err := ParseEntity(response)
+	if err != nil {
+		return err
+	}
+
+	return sink.Add(entity) //nolint:wrapcheck
+}
@@ -0,0 +1,298 @@
+package snapshot_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/internal/exporttest"
+	"github.com/senzing-garage/sz-sdk-go/params"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/snapshot"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockEngine struct {
+	*exporttest.Engine
+
+	entities map[int64]string
+}
+
+func (engine *mockEngine) GetEntityByEntityID(_ context.Context, entityID int64, _ int64) (string, error) {
+	response, isPresent := engine.entities[entityID]
+	if !isPresent {
+		return "", szerror.New(33, fmt.Sprintf("entity %d not found", entityID))
+	}
+
+	return response, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func entity(entityID int64, records ...string) snapshot.Entity {
+	result := snapshot.Entity{ID: entityID, Records: []params.RecordKey{}, Relations: []snapshot.Relation{}}
+
+	for _, record := range records {
+		dataSource, recordID, _ := strings.Cut(record, ":")
+		result.Records = append(result.Records, params.RecordKey{DataSource: dataSource, ID: recordID})
+	}
+
+	return result
+}
+
+func entityResponse(entityID int64, relatedEntityIDs []int64, records ...string) string {
+	recordsJSON := []string{}
+
+	for _, record := range records {
+		dataSource, recordID, _ := strings.Cut(record, ":")
+		recordsJSON = append(recordsJSON, fmt.Sprintf(`{"DATA_SOURCE": %q, "RECORD_ID": %q}`, dataSource, recordID))
+	}
+
+	relatedJSON := []string{}
+	for _, relatedEntityID := range relatedEntityIDs {
+		relatedJSON = append(relatedJSON,
+			fmt.Sprintf(`{"ENTITY_ID": %d, "MATCH_LEVEL_CODE": "POSSIBLY_RELATED"}`, relatedEntityID))
+	}
+
+	return fmt.Sprintf(`{"RESOLVED_ENTITY": {"ENTITY_ID": %d, "RECORDS": [%s]}, "RELATED_ENTITIES": [%s]}`,
+		entityID, strings.Join(recordsJSON, ", "), strings.Join(relatedJSON, ", "))
+}
+
+func newSnapshot(test *testing.T, entities ...snapshot.Entity) *snapshot.Snapshot {
+	test.Helper()
+
+	result := snapshot.New()
+	for _, entity := range entities {
+		require.NoError(test, result.Add(entity))
+	}
+
+	return result
+}
+
+func readResponses(test *testing.T, fileName string) []string {
+	test.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", fileName))
+	require.NoError(test, err)
+
+	defer func() {
+		require.NoError(test, file.Close())
+	}()
+
+	result := []string{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+
+	require.NoError(test, scanner.Err())
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParseEntity(test *testing.T) {
+	test.Parallel()
+
+	response := entityResponse(1, []int64{9, 3}, "WATCHLIST:2", "CUSTOMERS:1002", "CUSTOMERS:1001")
+	parsed, err := snapshot.ParseEntity(response)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), parsed.ID)
+	assert.Equal(test, entity(1, "CUSTOMERS:1001", "CUSTOMERS:1002", "WATCHLIST:2").Records, parsed.Records)
+	assert.Equal(test, []snapshot.Relation{
+		{EntityID: 3, MatchLevelCode: "POSSIBLY_RELATED"},
+		{EntityID: 9, MatchLevelCode: "POSSIBLY_RELATED"},
+	}, parsed.Relations)
+
+	_, err = snapshot.ParseEntity("{")
+	require.Error(test, err)
+}
+
+func TestParseEntity_Fixtures(test *testing.T) {
+	test.Parallel()
+
+	for _, fileName := range []string{
+		"SzEngineGetEntityByEntityIdResponse.jsonl",
+		"SzEngineGetEntityByRecordIdResponse.jsonl",
+	} {
+		for _, response := range readResponses(test, fileName) {
+			parsed, err := snapshot.ParseEntity(response)
+			require.NoError(test, err)
+			assert.NotNil(test, parsed.Records)
+			assert.NotNil(test, parsed.Relations)
+		}
+	}
+}
+
+func TestSnapshot_Add(test *testing.T) {
+	test.Parallel()
+
+	snap := newSnapshot(test, entity(2, "CUSTOMERS:2"), entity(1, "CUSTOMERS:1"))
+	assert.Equal(test, []int64{1, 2}, snap.EntityIDs())
+	assert.Equal(test, 2, snap.Len())
+
+	entityID, isPresent := snap.EntityOf(params.RecordKey{DataSource: "CUSTOMERS", ID: "2"})
+	assert.True(test, isPresent)
+	assert.Equal(test, int64(2), entityID)
+
+	err := snap.Add(entity(3, "CUSTOMERS:2"))
+	require.ErrorIs(test, err, snapshot.ErrDuplicateRecord)
+
+	require.NoError(test, snap.Add(entity(2, "CUSTOMERS:3")))
+	_, isPresent = snap.EntityOf(params.RecordKey{DataSource: "CUSTOMERS", ID: "2"})
+	assert.False(test, isPresent)
+
+	got, isPresent := snap.Entity(2)
+	assert.True(test, isPresent)
+	assert.Equal(test, entity(2, "CUSTOMERS:3"), got)
+}
+
+func TestCaptureExport(test *testing.T) {
+	test.Parallel()
+
+	export := strings.Join([]string{
+		entityResponse(1, []int64{2}, "CUSTOMERS:1001", "CUSTOMERS:1002"),
+		entityResponse(2, []int64{1}, "WATCHLIST:1"),
+		entityResponse(3, nil, "CUSTOMERS:1003"),
+	}, "\n")
+
+	for _, chunkSize := range []int{7, 64, 4096} {
+		engine := &exporttest.Engine{Export: export, ChunkSize: chunkSize} //exhaustruct:ignore
+		snap := snapshot.New()
+		require.NoError(test, snapshot.CaptureExport(test.Context(), engine, snap))
+		assert.True(test, engine.IsClosed)
+		assert.Equal(test, snapshot.Flags|senzing.SzExportIncludeAllEntities, engine.Flags)
+		assert.Equal(test, []int64{1, 2, 3}, snap.EntityIDs())
+
+		got, _ := snap.Entity(1)
+		assert.Len(test, got.Records, 2)
+		assert.Equal(test, []snapshot.Relation{{EntityID: 2, MatchLevelCode: "POSSIBLY_RELATED"}}, got.Relations)
+	}
+}
+
+func TestCaptureExport_Error(test *testing.T) {
+	test.Parallel()
+
+	engine := &exporttest.Engine{Export: "{\n", ChunkSize: 10} //exhaustruct:ignore
+	err := snapshot.CaptureExport(test.Context(), engine, snapshot.New())
+	require.Error(test, err)
+	assert.True(test, engine.IsClosed)
+}
+
+func TestCaptureEntities(test *testing.T) {
+	test.Parallel()
+
+	engine := &mockEngine{entities: map[int64]string{ //exhaustruct:ignore
+		1: entityResponse(1, nil, "CUSTOMERS:1001"),
+		3: entityResponse(3, nil, "CUSTOMERS:1003"),
+	}}
+
+	var buffer bytes.Buffer
+
+	writer := snapshot.NewWriter(&buffer)
+	require.NoError(test, snapshot.CaptureEntities(test.Context(), engine, slices.Values([]int64{1, 2, 3}), writer))
+	assert.Equal(test, int64(2), writer.Count())
+	assert.Equal(test,
+		`{"id":1,"records":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}],"relations":[]}`+"\n"+
+			`{"id":3,"records":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1003"}],"relations":[]}`+"\n",
+		buffer.String())
+}
+
+func TestWriteRead(test *testing.T) {
+	test.Parallel()
+
+	related := entity(1, "CUSTOMERS:2", "CUSTOMERS:1")
+	related.Relations = []snapshot.Relation{{EntityID: 2, MatchLevelCode: "POSSIBLY_SAME"}}
+	snap := newSnapshot(test, entity(2, "WATCHLIST:1"), related)
+
+	var buffer bytes.Buffer
+
+	require.NoError(test, snapshot.Write(&buffer, snap))
+	assert.Equal(test, 2, strings.Count(buffer.String(), "\n"))
+
+	read, err := snapshot.Read(&buffer)
+	require.NoError(test, err)
+	assert.Equal(test, slices.Collect(snap.Entities()), slices.Collect(read.Entities()))
+	assert.True(test, snapshot.Compare(snap, read).IsEmpty())
+}
+
+func TestScan_Error(test *testing.T) {
+	test.Parallel()
+
+	count := 0
+
+	for _, err := range snapshot.Scan(strings.NewReader("{\"id\":1}\n\n{\"id\":\n{\"id\":3}\n")) {
+		if err != nil {
+			assert.Contains(test, err.Error(), "line 3")
+
+			break
+		}
+
+		count++
+	}
+
+	assert.Equal(test, 1, count)
+
+	_, err := snapshot.Read(strings.NewReader("[]"))
+	require.Error(test, err)
+}
+
+func TestCompare(test *testing.T) {
+	test.Parallel()
+
+	relatedBefore := entity(7, "CUSTOMERS:7")
+	relatedAfter := entity(7, "CUSTOMERS:7")
+	relatedAfter.Relations = []snapshot.Relation{{EntityID: 1, MatchLevelCode: "POSSIBLY_RELATED"}}
+
+	before := newSnapshot(test,
+		entity(1, "CUSTOMERS:A", "CUSTOMERS:B"),
+		entity(2, "CUSTOMERS:C"),
+		entity(3, "CUSTOMERS:D", "CUSTOMERS:E"),
+		entity(4, "CUSTOMERS:F"),
+		entity(5, "CUSTOMERS:H"),
+		relatedBefore,
+	)
+	after := newSnapshot(test,
+		entity(1, "CUSTOMERS:A", "CUSTOMERS:B", "CUSTOMERS:C"),
+		entity(3, "CUSTOMERS:D"),
+		entity(5, "CUSTOMERS:H"),
+		entity(6, "CUSTOMERS:G"),
+		entity(8, "CUSTOMERS:E"),
+		relatedAfter,
+	)
+
+	diff := snapshot.Compare(before, after)
+	assert.Equal(test, []int64{6}, diff.Created)
+	assert.Equal(test, []int64{4}, diff.Deleted)
+	assert.Equal(test, []snapshot.Merge{{EntityID: 1, From: []int64{1, 2}}}, diff.Merged)
+	assert.Equal(test, []snapshot.Split{{EntityID: 3, Into: []int64{3, 8}}}, diff.Split)
+	assert.Equal(test, []int64{7}, diff.Changed)
+	assert.Equal(test, []snapshot.Move{
+		{FromEntityID: 2, Record: params.RecordKey{DataSource: "CUSTOMERS", ID: "C"}, ToEntityID: 1},
+		{FromEntityID: 3, Record: params.RecordKey{DataSource: "CUSTOMERS", ID: "E"}, ToEntityID: 8},
+	}, diff.Moved)
+	assert.False(test, diff.IsEmpty())
+	assert.Equal(test, `1 created, 1 deleted, 1 merged, 1 split, 1 changed, 2 records moved
+merged [1 2] into 1
+split 3 into [3 8]
+`, diff.String())
+}
@@ -0,0 +1,143 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Writer struct writes entities as JSON lines, one entity per line, without holding them in memory.
+Create one with [NewWriter].
+*/
+type Writer struct {
+	count   int64
+	encoder *json.Encoder
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The longest line Scan reads. An entity with about 100,000 records fits.
+const maxLineSize = 16 * 1024 * 1024
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewWriter creates a Writer. Wrap writer in a bufio.Writer, and flush it, when writing to a file.
+*/
+func NewWriter(writer io.Writer) *Writer {
+	return &Writer{count: 0, encoder: json.NewEncoder(writer)}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Read reads entities written by a Writer into a Snapshot.
+*/
+func Read(reader io.Reader) (*Snapshot, error) {
+	result := New()
+
+	for entity, err := range Scan(reader) {
+		if err != nil {
+			return nil, err
+		}
+
+		err = result.Add(entity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+/*
+Function Scan returns the entities written by a Writer, one at a time. Iteration stops after an error.
+*/
+func Scan(reader io.Reader) iter.Seq2[Entity, error] {
+	return func(yield func(Entity, error) bool) {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+		for line := 1; scanner.Scan(); line++ {
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+
+			entity := Entity{} //exhaustruct:ignore
+
+			err := json.Unmarshal(scanner.Bytes(), &entity)
+			if err != nil {
+				yield(Entity{}, fmt.Errorf("snapshot cannot unmarshal line %d: %w", line, err)) //exhaustruct:ignore
+
+				return
+			}
+
+			entity.normalize()
+
+			if !yield(entity, nil) {
+				return
+			}
+		}
+
+		err := scanner.Err()
+		if err != nil {
+			yield(Entity{}, fmt.Errorf("snapshot cannot read: %w", err)) //exhaustruct:ignore
+		}
+	}
+}
+
+/*
+Function Write writes the entities of a Snapshot in order of entity ID.
+*/
+func Write(writer io.Writer, snapshot *Snapshot) error {
+	snapshotWriter := NewWriter(writer)
+
+	for entity := range snapshot.Entities() {
+		err := snapshotWriter.Add(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Add writes an entity as one line.
+*/
+func (writer *Writer) Add(entity Entity) error {
+	entity.normalize()
+
+	err := writer.encoder.Encode(entity)
+	if err != nil {
+		return fmt.Errorf("snapshot cannot write entity %d: %w", entity.ID, err)
+	}
+
+	writer.count++
+
+	return nil
+}
+
+/*
+Method Count returns the number of entities written.
+*/
+func (writer *Writer) Count() int64 {
+	return writer.count
+}
@@ -4,6 +4,9 @@ Package szerror manages types of errors issued by Senzing.
 Note that type names have an "Error" suffix
 and instance names have an "Err" prefix.
 
+SzResourceExhaustedError is not issued by Senzing.
+It is issued by SDK components that reject calls to protect the Senzing engine, so that callers can shed load.
+
 The following is the error type hierarchy:
 
 	SzError
@@ -16,6 +19,7 @@ The following is the error type hierarchy:
 	│	└── SzReplaceConflictError
 	├── SzRetryableError
 	│	├── SzDatabaseConnectionLostError
+	│	├── SzResourceExhaustedError
 	│	└── SzRetryTimeoutExceededError
 	└── SzUnrecoverableError
 		├── SzDatabaseError
@@ -35,6 +35,7 @@ const (
 	SzUnhandledError
 	SzUnknownDataSourceError
 	SzUnrecoverableError
+	SzResourceExhaustedError // Last, so that the values of the types above do not change.
 )
 
 // ----------------------------------------------------------------------------
@@ -58,6 +59,7 @@ var (
 	ErrSzNotFound               = errors.New(emptyErrorMessage)
 	ErrSzNotInitialized         = errors.New(emptyErrorMessage)
 	ErrSzReplaceConflict        = errors.New(emptyErrorMessage)
+	ErrSzResourceExhausted      = errors.New(emptyErrorMessage)
 	ErrSzRetryable              = errors.New(emptyErrorMessage)
 	ErrSzRetryTimeoutExceeded   = errors.New(emptyErrorMessage)
 	ErrSzSdk                    = errors.New(emptyErrorMessage)
@@ -79,6 +81,7 @@ var SzErrorTypesList = []TypeIDs{
 	SzNotFoundError,
 	SzNotInitializedError,
 	SzReplaceConflictError,
+	SzResourceExhaustedError,
 	SzRetryableError,
 	SzRetryTimeoutExceededError,
 	SzSdkError,
@@ -100,6 +103,7 @@ var SzErrorMap = map[TypeIDs]error{
 	SzNotFoundError:               ErrSzNotFound,
 	SzNotInitializedError:         ErrSzNotInitialized,
 	SzReplaceConflictError:        ErrSzReplaceConflict,
+	SzResourceExhaustedError:      ErrSzResourceExhausted,
 	SzRetryableError:              ErrSzRetryable,
 	SzRetryTimeoutExceededError:   ErrSzRetryTimeoutExceeded,
 	SzSdkError:                    ErrSzSdk,
@@ -105,3 +105,26 @@ func New(senzingErrorCode int, message string) error {
 
 	return errors.Join(result...)
 }
+
+/*
+Function Wrap returns an error that is classified by the requested error types and wraps the original error.
+It is used for errors that originate in the SDK rather than in Senzing's Szxxx_getLastException message.
+
+Input
+  - err: The original error.
+  - errorTypeIDs: An ordered list of error types to wrap the original error.
+
+Output
+  - An error satisfying errors.Is() for each of the requested error types and for the original error.
+*/
+func Wrap(err error, errorTypeIDs ...TypeIDs) error {
+	result := make([]error, 0, len(errorTypeIDs)+1)
+
+	for _, errorTypeID := range errorTypeIDs {
+		result = append(result, mapErrorIDtoError(errorTypeID))
+	}
+
+	result = append(result, err)
+
+	return errors.Join(result...)
+}
@@ -1,6 +1,7 @@
 package szerror_test
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/senzing-garage/sz-sdk-go/szerror"
@@ -30,3 +31,9 @@ func ExampleNew() {
 	fmt.Println(err)
 	// Output: {"messageId": 1}
 }
+
+func ExampleWrap() {
+	err := szerror.Wrap(errors.New("context canceled"), szerror.SzSdkError, szerror.SzGeneralError, szerror.SzError) //nolint
+	fmt.Println(errors.Is(err, szerror.ErrSzGeneral))
+	// Output: true
+}
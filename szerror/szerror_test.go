@@ -1,6 +1,7 @@
 package szerror_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -314,3 +315,16 @@ func TestSzerror_SzError_BadErrorCode(test *testing.T) {
 	err := szerror.New(999999999, "Fake message")
 	require.Error(test, err)
 }
+
+func TestSzerror_Wrap(test *testing.T) {
+	test.Parallel()
+
+	original := errors.New("original error") //nolint
+	actual := szerror.Wrap(original, szerror.SzSdkError, szerror.SzGeneralError, szerror.SzError)
+	require.ErrorIs(test, actual, original)
+	require.ErrorIs(test, actual, szerror.ErrSzSdk)
+	require.ErrorIs(test, actual, szerror.ErrSzGeneral)
+	require.ErrorIs(test, actual, szerror.ErrSz)
+	require.NotErrorIs(test, actual, szerror.ErrSzRetryable)
+	assert.Equal(test, "original error", strings.TrimSpace(actual.Error()))
+}
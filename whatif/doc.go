@@ -0,0 +1,18 @@
+/*
+Package whatif predicts what adding a record would change, before it is added.
+
+SzEngine.GetRecordPreview shows the features a record would produce, but not how it would resolve.
+[Predict] also searches for the entities those features match, using the INGEST search profile,
+and explains each match with SzEngine.WhySearch. Nothing is added to the repository:
+
+	prediction, err := whatif.Predict(ctx, szEngine, recordDefinition, whatif.Config{})
+	...
+	if prediction.IsMerge() {
+		// The record would bridge existing entities; review it before calling AddRecord.
+	}
+	fmt.Print(prediction)
+
+RESOLVED matches are [Prediction.Joins]; weaker matches are [Prediction.Relates].
+The result is a prediction: re-resolution triggered by the new record is not shown.
+*/
+package whatif
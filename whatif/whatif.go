@@ -0,0 +1,298 @@
+package whatif
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/explain"
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/response"
+	"github.com/senzing-garage/sz-sdk-go/search"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Type Config struct configures [Predict].
+*/
+type Config struct {
+	MinMatchLevel string // Weakest match level to report. Default: graph.MatchLevelNameOnly.
+	SearchProfile string // Default: search.ProfileIngest, which scores candidates as AddRecord would.
+	SkipWhy       bool   // Do not call SzEngine.WhySearch for outcomes.
+}
+
+/*
+Type Outcome struct is an existing entity the record would likely resolve into or relate to.
+*/
+type Outcome struct {
+	EntityID       int64                `json:"entityId"`
+	EntityName     string               `json:"entityName"`
+	Explanation    *explain.Explanation `json:"explanation"` // From SzEngine.WhySearch. Nil if Config.SkipWhy.
+	MatchKey       string               `json:"matchKey"`
+	MatchLevelCode string               `json:"matchLevelCode"`
+	RecordSummary  map[string]int64     `json:"recordSummary"`
+	RuleCode       string               `json:"ruleCode"`
+}
+
+/*
+Type Prediction struct is the likely effect of adding a record.
+*/
+type Prediction struct {
+	Features map[string][]string `json:"features"` // Feature type code to the values the record would add.
+	Joins    []Outcome           `json:"joins"`    // Entities the record would resolve into. More than one is a merge.
+	Preview  string              `json:"preview"`  // The response of SzEngine.GetRecordPreview.
+	Relates  []Outcome           `json:"relates"`  // Entities the record would relate to, strongest first.
+}
+
+type previewResponse struct {
+	Features map[string][]struct {
+		FeatDesc string `json:"FEAT_DESC"`
+	} `json:"FEATURES"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Flags of SzEngine.GetRecordPreview. Feature details include FEAT_DESC.
+const previewFlags = senzing.SzRecordPreviewDefaultFlags | senzing.SzEntityIncludeRecordFeatures
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidRecord is returned when a record is not a JSON object. It is search.ErrInvalidRecord.
+var ErrInvalidRecord = search.ErrInvalidRecord
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function Predict predicts what adding a record would change, without adding it.
+
+SzEngine.GetRecordPreview returns the features of the record, SzEngine.SearchByAttributes finds
+the entities they match, and SzEngine.WhySearch explains each match.
+A RESOLVED hit is an entity the record would likely join; weaker hits are relationships.
+This is a prediction: the record may also be scored against entities the search does not return,
+and adding it may cause re-resolution that a search cannot show.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szEngine: The engine to call.
+  - recordDefinition: The record as JSON. DATA_SOURCE and RECORD_ID are ignored by the search.
+  - config: The threshold and search profile.
+
+Output
+  - The prediction.
+*/
+func Predict(
+	ctx context.Context,
+	szEngine senzing.SzEngine,
+	recordDefinition string,
+	config Config,
+) (*Prediction, error) {
+	if config.MinMatchLevel == "" {
+		config.MinMatchLevel = graph.MatchLevelNameOnly
+	}
+
+	if config.SearchProfile == "" {
+		config.SearchProfile = search.ProfileIngest
+	}
+
+	attributes, err := search.RecordAttributes(recordDefinition)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	preview, err := szEngine.GetRecordPreview(ctx, recordDefinition, previewFlags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	result, err := newPrediction(preview)
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON, err := szEngine.SearchByAttributes(
+		ctx, attributes, config.SearchProfile, senzing.SzSearchByAttributesDefaultFlags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	hits, err := search.ParseResult(responseJSON)
+	if err != nil {
+		return nil, fmt.Errorf("whatif cannot parse search: %w", err)
+	}
+
+	for _, hit := range hits.AtLeast(config.MinMatchLevel) {
+		outcome := Outcome{
+			EntityID:       hit.EntityID,
+			EntityName:     hit.EntityName,
+			Explanation:    nil,
+			MatchKey:       hit.MatchKey,
+			MatchLevelCode: hit.MatchLevelCode,
+			RecordSummary:  hit.RecordSummary,
+			RuleCode:       hit.RuleCode,
+		}
+
+		if !config.SkipWhy {
+			outcome.Explanation, err = whySearch(ctx, szEngine, attributes, hit.EntityID, config.SearchProfile)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if hit.MatchLevelCode == graph.MatchLevelResolved {
+			result.Joins = append(result.Joins, outcome)
+		} else {
+			result.Relates = append(result.Relates, outcome)
+		}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method IsMerge returns true if the record would join more than one entity, merging them.
+*/
+func (prediction *Prediction) IsMerge() bool {
+	return len(prediction.Joins) > 1
+}
+
+/*
+Method IsNewEntity returns true if the record would not join an existing entity.
+*/
+func (prediction *Prediction) IsNewEntity() bool {
+	return len(prediction.Joins) == 0
+}
+
+/*
+Method String returns the prediction as plain text for review before SzEngine.AddRecord.
+*/
+func (prediction *Prediction) String() string {
+	var result strings.Builder
+
+	result.WriteString("Features:\n")
+
+	for _, feature := range slices.Sorted(maps.Keys(prediction.Features)) {
+		fmt.Fprintf(&result, "  %s: %s\n", feature, strings.Join(prediction.Features[feature], "; "))
+	}
+
+	switch {
+	case prediction.IsNewEntity():
+		result.WriteString("Would create a new entity\n")
+	case prediction.IsMerge():
+		result.WriteString("Would merge entities:\n")
+	default:
+		result.WriteString("Would join:\n")
+	}
+
+	for _, outcome := range prediction.Joins {
+		result.WriteString(outcome.String())
+	}
+
+	if len(prediction.Relates) > 0 {
+		result.WriteString("Would relate to:\n")
+	}
+
+	for _, outcome := range prediction.Relates {
+		result.WriteString(outcome.String())
+	}
+
+	return result.String()
+}
+
+/*
+Method String returns the outcome as indented lines: the entity and its match, then the explanation.
+*/
+func (outcome Outcome) String() string {
+	var result strings.Builder
+
+	fmt.Fprintf(&result, "  Entity %d", outcome.EntityID)
+
+	if outcome.EntityName != "" {
+		fmt.Fprintf(&result, " (%s)", outcome.EntityName)
+	}
+
+	fmt.Fprintf(&result, ": %s, match key %s", outcome.MatchLevelCode, outcome.MatchKey)
+
+	if outcome.RuleCode != "" {
+		fmt.Fprintf(&result, ", rule %s", outcome.RuleCode)
+	}
+
+	result.WriteString("\n")
+
+	if outcome.Explanation != nil {
+		for line := range strings.Lines(outcome.Explanation.String()) {
+			result.WriteString("    " + line)
+		}
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newPrediction(preview string) (*Prediction, error) {
+	record := previewResponse{} //exhaustruct:ignore
+
+	err := json.Unmarshal([]byte(preview), &record)
+	if err != nil {
+		return nil, fmt.Errorf("whatif cannot unmarshal preview: %w", err)
+	}
+
+	result := &Prediction{
+		Features: map[string][]string{},
+		Joins:    []Outcome{},
+		Preview:  preview,
+		Relates:  []Outcome{},
+	}
+
+	for feature, values := range record.Features {
+		for _, value := range values {
+			result.Features[feature] = append(result.Features[feature], value.FeatDesc)
+		}
+	}
+
+	return result, nil
+}
+
+func whySearch(
+	ctx context.Context,
+	szEngine senzing.SzEngine,
+	attributes string,
+	entityID int64,
+	searchProfile string,
+) (*explain.Explanation, error) {
+	responseJSON, err := szEngine.WhySearch(ctx, attributes, entityID, searchProfile, senzing.SzWhySearchDefaultFlags)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	whySearchResponse, err := response.SzEngineWhySearch(ctx, responseJSON)
+	if err != nil {
+		return nil, fmt.Errorf("whatif cannot parse why response of entity %d: %w", entityID, err)
+	}
+
+	result, err := explain.Why(whySearchResponse)
+	if err != nil {
+		return nil, fmt.Errorf("whatif cannot explain entity %d: %w", entityID, err)
+	}
+
+	return result, nil
+}
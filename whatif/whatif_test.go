@@ -0,0 +1,219 @@
+package whatif_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/graph"
+	"github.com/senzing-garage/sz-sdk-go/search"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/senzing-garage/sz-sdk-go/whatif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const record = `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "9001",
+	"NAME_FULL": "Robert Smith", "DATE_OF_BIRTH": "1978-12-11"}`
+
+const searchResponse = `{"RESOLVED_ENTITIES": [
+	{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 2, "ENTITY_NAME": "Bob Smith"}},
+		"MATCH_INFO": {"ERRULE_CODE": "CNAME", "MATCH_KEY": "+NAME", "MATCH_LEVEL_CODE": "POSSIBLY_SAME"}},
+	{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 1, "ENTITY_NAME": "Robert Smith",
+			"RECORD_SUMMARY": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_COUNT": 3}]}},
+		"MATCH_INFO": {"ERRULE_CODE": "CNAME_CFF", "MATCH_KEY": "+NAME+DOB", "MATCH_LEVEL_CODE": "RESOLVED"}},
+	{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 3, "ENTITY_NAME": "R Smith"}},
+		"MATCH_INFO": {"ERRULE_CODE": "SNAME", "MATCH_KEY": "+SURNAME", "MATCH_LEVEL_CODE": "NAME_ONLY"}}
+]}`
+
+// ----------------------------------------------------------------------------
+// Test doubles
+// ----------------------------------------------------------------------------
+
+type mockEngine struct {
+	senzing.SzEngine
+
+	attributes    string
+	preview       string
+	searchProfile string
+	searchResult  string
+	whyEntityIDs  []int64
+}
+
+func (engine *mockEngine) AddRecord(context.Context, string, string, string, int64) (string, error) {
+	panic("whatif must not add records")
+}
+
+func (engine *mockEngine) GetRecordPreview(_ context.Context, _ string, _ int64) (string, error) {
+	return engine.preview, nil
+}
+
+func (engine *mockEngine) SearchByAttributes(
+	_ context.Context,
+	attributes string,
+	searchProfile string,
+	_ int64,
+) (string, error) {
+	engine.attributes = attributes
+	engine.searchProfile = searchProfile
+
+	return engine.searchResult, nil
+}
+
+func (engine *mockEngine) WhySearch(
+	_ context.Context,
+	_ string,
+	entityID int64,
+	_ string,
+	_ int64,
+) (string, error) {
+	engine.whyEntityIDs = append(engine.whyEntityIDs, entityID)
+
+	return fmt.Sprintf(`{"WHY_RESULTS": [{"ENTITY_ID": %d, "MATCH_INFO": {"WHY_KEY": "+NAME",
+		"FEATURE_SCORES": {"NAME": [{"INBOUND_FEAT_DESC": "Robert Smith", "CANDIDATE_FEAT_DESC": "Bob Smith",
+			"SCORE": 90, "SCORE_BUCKET": "CLOSE"}]}}}]}`, entityID), nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newEngine(test *testing.T) *mockEngine {
+	test.Helper()
+
+	return &mockEngine{ //exhaustruct:ignore
+		preview:      readResponses(test, "SzEngineGetRecordPreviewResponse.jsonl")[0],
+		searchResult: searchResponse,
+		whyEntityIDs: []int64{},
+	}
+}
+
+func readResponses(test *testing.T, fileName string) []string {
+	test.Helper()
+
+	file, err := os.Open(filepath.Join("..", "testdata", "responses_senzing", fileName))
+	require.NoError(test, err)
+
+	defer func() {
+		require.NoError(test, file.Close())
+	}()
+
+	result := []string{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+
+	require.NoError(test, scanner.Err())
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestPredict(test *testing.T) {
+	test.Parallel()
+
+	engine := newEngine(test)
+	prediction, err := whatif.Predict(test.Context(), engine, record, whatif.Config{}) //exhaustruct:ignore
+	require.NoError(test, err)
+
+	assert.Equal(test, search.ProfileIngest, engine.searchProfile)
+	assert.JSONEq(test, `{"NAME_FULL": "Robert Smith", "DATE_OF_BIRTH": "1978-12-11"}`, engine.attributes)
+	assert.Equal(test, []string{"Marie Sanchez"}, prediction.Features["NAME"])
+	assert.Equal(test, engine.preview, prediction.Preview)
+
+	assert.False(test, prediction.IsNewEntity())
+	assert.False(test, prediction.IsMerge())
+	require.Len(test, prediction.Joins, 1)
+	assert.Equal(test, int64(1), prediction.Joins[0].EntityID)
+	assert.Equal(test, "+NAME+DOB", prediction.Joins[0].MatchKey)
+	assert.Equal(test, map[string]int64{"CUSTOMERS": 3}, prediction.Joins[0].RecordSummary)
+	require.Len(test, prediction.Relates, 2)
+	assert.Equal(test, graph.MatchLevelPossiblySame, prediction.Relates[0].MatchLevelCode)
+	assert.Equal(test, graph.MatchLevelNameOnly, prediction.Relates[1].MatchLevelCode)
+
+	assert.Equal(test, []int64{1, 2, 3}, engine.whyEntityIDs)
+	require.NotNil(test, prediction.Relates[0].Explanation)
+	assert.Equal(test, int64(2), prediction.Relates[0].Explanation.Comparisons[0].EntityID)
+}
+
+func TestPredict_Config(test *testing.T) {
+	test.Parallel()
+
+	engine := newEngine(test)
+	config := whatif.Config{
+		MinMatchLevel: graph.MatchLevelPossiblySame,
+		SearchProfile: search.ProfileSearch,
+		SkipWhy:       true,
+	}
+	prediction, err := whatif.Predict(test.Context(), engine, record, config)
+	require.NoError(test, err)
+	assert.Equal(test, search.ProfileSearch, engine.searchProfile)
+	assert.Len(test, prediction.Joins, 1)
+	assert.Len(test, prediction.Relates, 1)
+	assert.Nil(test, prediction.Joins[0].Explanation)
+	assert.Empty(test, engine.whyEntityIDs)
+}
+
+func TestPredict_NewEntity(test *testing.T) {
+	test.Parallel()
+
+	engine := newEngine(test)
+	engine.searchResult = `{"RESOLVED_ENTITIES": []}`
+	prediction, err := whatif.Predict(test.Context(), engine, record, whatif.Config{}) //exhaustruct:ignore
+	require.NoError(test, err)
+	assert.True(test, prediction.IsNewEntity())
+	assert.Contains(test, prediction.String(), "Would create a new entity\n")
+}
+
+func TestPredict_InvalidRecord(test *testing.T) {
+	test.Parallel()
+
+	_, err := whatif.Predict(test.Context(), newEngine(test), "[1]", whatif.Config{}) //exhaustruct:ignore
+	require.ErrorIs(test, err, whatif.ErrInvalidRecord)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestPrediction_String(test *testing.T) {
+	test.Parallel()
+
+	engine := newEngine(test)
+	engine.searchResult = `{"RESOLVED_ENTITIES": [
+		{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 1}},
+			"MATCH_INFO": {"MATCH_KEY": "+NAME+DOB", "MATCH_LEVEL_CODE": "RESOLVED"}},
+		{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 4, "ENTITY_NAME": "Bob"}},
+			"MATCH_INFO": {"ERRULE_CODE": "SF1", "MATCH_KEY": "+SSN", "MATCH_LEVEL_CODE": "RESOLVED"}}]}`
+	config := whatif.Config{SkipWhy: true} //exhaustruct:ignore
+	prediction, err := whatif.Predict(test.Context(), engine, record, config)
+	require.NoError(test, err)
+	assert.True(test, prediction.IsMerge())
+	assert.Equal(test, `Features:
+  ADDRESS: P.O. Box 12987 Andersonville IL 60611
+  EMAIL: mickey@mmail.com
+  NAME: Marie Sanchez
+  RECORD_TYPE: PERSON
+Would merge entities:
+  Entity 1: RESOLVED, match key +NAME+DOB
+  Entity 4 (Bob): RESOLVED, match key +SSN, rule SF1
+`, prediction.String())
+
+	prediction, err = whatif.Predict(test.Context(), newEngine(test), record, whatif.Config{}) //exhaustruct:ignore
+	require.NoError(test, err)
+	assert.Contains(test, prediction.String(), `Would join:
+  Entity 1 (Robert Smith): RESOLVED, match key +NAME+DOB, rule CNAME_CFF
+    Why the search matched entity 1: match key +NAME
+      Matched:
+        NAME 90 CLOSE: "Robert Smith" vs "Bob Smith"
+Would relate to:
+  Entity 2 (Bob Smith): POSSIBLY_SAME, match key +NAME, rule CNAME
+`)
+}